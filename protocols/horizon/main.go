@@ -36,6 +36,7 @@ type Account struct {
 		Effects      hal.Link `json:"effects"`
 		Offers       hal.Link `json:"offers"`
 		Trades       hal.Link `json:"trades"`
+		Changes      hal.Link `json:"changes"`
 		Data         hal.Link `json:"data"`
 	} `json:"_links"`
 
@@ -52,6 +53,11 @@ type Account struct {
 	Data                 map[string]string `json:"data"`
 }
 
+// PagingToken implementation for hal.Pageable
+func (a Account) PagingToken() string {
+	return a.PT
+}
+
 // GetNativeBalance returns the native balance of the account
 func (a Account) GetNativeBalance() (string, error) {
 	for _, balance := range a.Balances {
@@ -128,10 +134,13 @@ type AssetStat struct {
 	} `json:"_links"`
 
 	base.Asset
-	PT          string       `json:"paging_token"`
-	Amount      string       `json:"amount"`
-	NumAccounts int32        `json:"num_accounts"`
-	Flags       AccountFlags `json:"flags"`
+	PT                      string       `json:"paging_token"`
+	Amount                  string       `json:"amount"`
+	NumAccounts             int32        `json:"num_accounts"`
+	NumUnauthorizedAccounts int32        `json:"num_unauthorized_accounts"`
+	Flags                   AccountFlags `json:"flags"`
+	BidDepth                string       `json:"bid_depth"`
+	AskDepth                string       `json:"ask_depth"`
 }
 
 // PagingToken implementation for hal.Pageable
@@ -139,6 +148,30 @@ func (res AssetStat) PagingToken() string {
 	return res.PT
 }
 
+// AssetStatDetails represents the detailed stats of a single asset: its
+// largest holders, its daily supply and its trades of the last 24 hours
+type AssetStatDetails struct {
+	AssetStat
+	TopHolders    []AssetHolder `json:"top_holders"`
+	SupplyHistory []AssetSupply `json:"supply_history"`
+	TradeCount24h int32         `json:"trade_count_24h"`
+	Volume24h     string        `json:"volume_24h"`
+}
+
+// AssetHolder represents the balance of an account holding an asset
+type AssetHolder struct {
+	Account    string `json:"account"`
+	Balance    string `json:"balance"`
+	Authorized bool   `json:"authorized"`
+}
+
+// AssetSupply represents the supply of an asset at the end of a day
+type AssetSupply struct {
+	Day         string `json:"day"`
+	Amount      string `json:"amount"`
+	NumAccounts int32  `json:"num_accounts"`
+}
+
 // Balance represents an account's holdings for a single currency type
 type Balance struct {
 	Balance            string `json:"balance"`
@@ -157,6 +190,34 @@ type HistoryAccount struct {
 	AccountID string `json:"account_id"`
 }
 
+// LedgerEntryChange represents the state of a single ledger entry (account,
+// trustline, offer or data entry) before and after the application of an
+// operation, or of the fee and sequence number changes made outside of any
+// operation (in which case the operation link is empty).  The states are
+// base64-encoded xdr.LedgerEntry values; the before state is omitted for
+// created entries and the after state for removed ones.
+type LedgerEntryChange struct {
+	Links struct {
+		Operation hal.Link `json:"operation"`
+		Succeeds  hal.Link `json:"succeeds"`
+		Precedes  hal.Link `json:"precedes"`
+	} `json:"_links"`
+
+	ID              string    `json:"id"`
+	PT              string    `json:"paging_token"`
+	Account         string    `json:"account"`
+	EntryType       string    `json:"entry_type"`
+	ChangeType      string    `json:"change_type"`
+	LedgerCloseTime time.Time `json:"created_at"`
+	EntryBefore     string    `json:"entry_before_xdr,omitempty"`
+	EntryAfter      string    `json:"entry_after_xdr,omitempty"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (res LedgerEntryChange) PagingToken() string {
+	return res.PT
+}
+
 // Ledger represents a single closed ledger
 type Ledger struct {
 	Links struct {
@@ -362,6 +423,43 @@ func (res TradeAggregation) PagingToken() string {
 	return string(res.Timestamp)
 }
 
+// Market represents the trades of a pair of assets over the last 24 hours,
+// along with the best prices currently offered on its order book
+type Market struct {
+	Links struct {
+		Trades    hal.Link `json:"trades"`
+		OrderBook hal.Link `json:"order_book"`
+	} `json:"_links"`
+
+	PT                 string      `json:"paging_token"`
+	BaseAssetType      string      `json:"base_asset_type"`
+	BaseAssetCode      string      `json:"base_asset_code,omitempty"`
+	BaseAssetIssuer    string      `json:"base_asset_issuer,omitempty"`
+	CounterAssetType   string      `json:"counter_asset_type"`
+	CounterAssetCode   string      `json:"counter_asset_code,omitempty"`
+	CounterAssetIssuer string      `json:"counter_asset_issuer,omitempty"`
+	TradeCount         int64       `json:"trade_count"`
+	BaseVolume         string      `json:"base_volume"`
+	CounterVolume      string      `json:"counter_volume"`
+	Open               string      `json:"open"`
+	OpenR              xdr.Price   `json:"open_r"`
+	High               string      `json:"high"`
+	HighR              xdr.Price   `json:"high_r"`
+	Low                string      `json:"low"`
+	LowR               xdr.Price   `json:"low_r"`
+	Close              string      `json:"close"`
+	CloseR             xdr.Price   `json:"close_r"`
+	Change             string      `json:"change"`
+	BestBid            *PriceLevel `json:"best_bid,omitempty"`
+	BestAsk            *PriceLevel `json:"best_ask,omitempty"`
+	LastTradeAt        time.Time   `json:"last_trade_at"`
+}
+
+// PagingToken implementation for hal.Pageable
+func (res Market) PagingToken() string {
+	return res.PT
+}
+
 // Transaction represents a single, successful transaction
 type Transaction struct {
 	Links struct {
@@ -449,6 +547,46 @@ func (resp TransactionSuccess) TransactionSuccessToString() (s string) {
 	return
 }
 
+// TransactionStatus represents the status of a submitted transaction, as
+// returned by asynchronous submissions and by the transaction status endpoint.
+// The ledger and XDR fields are only set once the transaction is included in
+// a ledger.
+type TransactionStatus struct {
+	Links struct {
+		Transaction hal.Link `json:"transaction"`
+		Status      hal.Link `json:"status"`
+	} `json:"_links"`
+	Hash   string `json:"hash"`
+	Status string `json:"status"`
+	Ledger int32  `json:"ledger,omitempty"`
+	Env    string `json:"envelope_xdr,omitempty"`
+	Result string `json:"result_xdr,omitempty"`
+	Meta   string `json:"result_meta_xdr,omitempty"`
+
+	FeeReport *TransactionFeeReport `json:"fee_report,omitempty"`
+}
+
+// TransactionFeeReport represents how the fee of a pending transaction compares
+// with the fees paid by the transactions included in recent ledgers.  Horizon
+// only reports fees when configured with a fee source account.
+type TransactionFeeReport struct {
+	Stuck            bool   `json:"stuck"`
+	Fee              int64  `json:"fee"`
+	RecommendedFee   int64  `json:"recommended_fee"`
+	RebroadcastHash  string `json:"rebroadcast_hash,omitempty"`
+	RebroadcastError string `json:"rebroadcast_error,omitempty"`
+}
+
+// TransactionSimulation represents the predicted result of a transaction
+// against the current state of the ledger, without submitting it.
+type TransactionSimulation struct {
+	Hash        string                 `json:"hash"`
+	Successful  bool                   `json:"successful"`
+	FeeCharged  int64                  `json:"fee_charged"`
+	Result      string                 `json:"result_xdr"`
+	ResultCodes TransactionResultCodes `json:"result_codes"`
+}
+
 // KeyTypeFromAddress converts the version byte of the provided strkey encoded
 // value (for example an account id or a signer key) and returns the appropriate
 // horizon-specific type name.
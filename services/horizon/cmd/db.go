@@ -10,6 +10,7 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
 	"github.com/cowry-network/go/services/horizon/internal/db2/schema"
 	"github.com/cowry-network/go/services/horizon/internal/ingest"
 	"github.com/cowry-network/go/support/db"
@@ -30,7 +31,7 @@ var dbBackfillCmd = &cobra.Command{
 			return
 		}
 
-		initApp().UpdateLedgerState()
+		initConfig()
 
 		i := ingestSystem(ingest.Config{
 			IngestFailedTransactions: config.IngestFailedTransactions,
@@ -48,6 +49,69 @@ var dbBackfillCmd = &cobra.Command{
 	},
 }
 
+var dbBackfillMemosCmd = &cobra.Command{
+	Use:   "backfill-memos",
+	Short: "records the memos of transactions that have none recorded",
+	Long:  "backfill-memos decodes the envelope of every transaction with no memo recorded in the horizon database and records its memo type and memo, so that the transaction can be found with the `memo` and `memo_type` filters.  It can be interrupted and run again safely.",
+	Run: func(cmd *cobra.Command, args []string) {
+		initConfig()
+
+		hdb, err := db.Open("postgres", config.DatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		updated, err := ingest.BackfillMemos(hdb, uint64(backfillMemosBatchSize))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		log.Println(fmt.Sprintf("Recorded the memos of %d transactions", updated))
+	},
+}
+
+var dbIngestStatusCmd = &cobra.Command{
+	Use:   "ingest-status",
+	Short: "prints the progress of recent reingest and backfill jobs",
+	Long:  "ingest-status lists the most recent reingest and backfill jobs, with the last ledger each committed.  An interrupted or failed job is resumed by running the same `reingest range` or `backfill` command again.",
+	Run: func(cmd *cobra.Command, args []string) {
+		initConfig()
+
+		hdb, err := db.Open("postgres", config.DatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var jobs []history.IngestionJob
+		q := history.Q{Session: hdb}
+		err = q.IngestionJobs(&jobs, uint64(ingestStatusLimit))
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		for _, job := range jobs {
+			lastIngested := "none"
+			if job.LastIngestedLedger.Valid {
+				lastIngested = strconv.FormatInt(job.LastIngestedLedger.Int64, 10)
+			}
+
+			fmt.Printf(
+				"%d\t%s\t%d-%d\tlast ingested: %s\t%s\tupdated: %s\n",
+				job.ID,
+				job.Kind,
+				job.FirstLedger,
+				job.LastLedger,
+				lastIngested,
+				job.Status,
+				job.UpdatedAt.Format(time.RFC3339),
+			)
+			if job.Error.Valid {
+				fmt.Printf("\terror: %s\n", job.Error.String)
+			}
+		}
+	},
+}
+
 var dbInitAssetStatsCmd = &cobra.Command{
 	Use:   "init-asset-stats",
 	Short: "initializes values for assets stats",
@@ -78,7 +142,12 @@ var dbInitAssetStatsCmd = &cobra.Command{
 
 		log.Println(fmt.Sprintf("Updating %d assets...", count))
 
-		err = assetStats.UpdateAssetStats()
+		closedAt, err := assetStats.LatestCloseTime()
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		err = assetStats.UpdateAssetStats(closedAt)
 		if err != nil {
 			log.Fatal(err)
 		}
@@ -157,6 +226,42 @@ var dbMigrateCmd = &cobra.Command{
 	},
 }
 
+var dbRebuildTradeRollupsCmd = &cobra.Command{
+	Use:   "rebuild-trade-rollups",
+	Short: "recomputes the trade aggregations precomputed by minute, hour and day",
+	Long:  "rebuild-trade-rollups recomputes the history_trades_1m, history_trades_1h and history_trades_1d tables from the trades recorded in the horizon database, one day at a time, each in a transaction.  It can run while Horizon ingests, and can be interrupted and run again safely.",
+	Run: func(cmd *cobra.Command, args []string) {
+		initConfig()
+
+		hdb, err := db.Open("postgres", config.DatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		var timeRange history.TradeTimeRange
+		q := history.Q{Session: hdb}
+		err = q.TradeTimeRange(&timeRange)
+		if err != nil {
+			log.Fatal(err)
+		}
+
+		if timeRange.First == nil {
+			log.Println("No trades to roll up")
+			return
+		}
+
+		day := 24 * time.Hour
+		for start := timeRange.First.Truncate(day); !start.After(*timeRange.Last); start = start.Add(day) {
+			err = q.RebuildTradeRollups(start, start.Add(day))
+			if err != nil {
+				log.Fatal(err)
+			}
+
+			log.Println(fmt.Sprintf("Rebuilt trade rollups of %s", start.Format("2006-01-02")))
+		}
+	},
+}
+
 var dbReapCmd = &cobra.Command{
 	Use:   "reap",
 	Short: "reaps (i.e. removes) any reapable history data",
@@ -191,7 +296,7 @@ var dbRebaseCmd = &cobra.Command{
 var dbReingestCmd = &cobra.Command{
 	Use:   "reingest",
 	Short: "imports all data",
-	Long:  "reingest runs the ingestion pipeline over every ledger",
+	Long:  "reingest runs the ingestion pipeline over every ledger. `reingest range FROM TO --parallel-workers N` splits the range into chunks reingested concurrently by N workers.",
 	Run: func(cmd *cobra.Command, args []string) {
 		initConfig()
 
@@ -199,6 +304,12 @@ var dbReingestCmd = &cobra.Command{
 			IngestFailedTransactions: config.IngestFailedTransactions,
 		})
 		i.SkipCursorUpdate = true
+
+		if parallelWorkers > 1 {
+			reingestParallel(i, args)
+			return
+		}
+
 		logStatus := func(stage string) {
 			count := i.Metrics.IngestLedgerTimer.Count()
 			rate := i.Metrics.IngestLedgerTimer.RateMean()
@@ -237,15 +348,45 @@ var dbReingestCmd = &cobra.Command{
 	},
 }
 
+var (
+	backfillMemosBatchSize int
+	ingestStatusLimit      int
+	parallelWorkers        int
+)
+
 func init() {
+	dbReingestCmd.Flags().IntVar(
+		&parallelWorkers,
+		"parallel-workers",
+		1,
+		"number of workers reingesting chunks of the range concurrently, only supported by `reingest range FROM TO`",
+	)
+
+	dbBackfillMemosCmd.Flags().IntVar(
+		&backfillMemosBatchSize,
+		"batch-size",
+		1000,
+		"number of transactions loaded at a time",
+	)
+
+	dbIngestStatusCmd.Flags().IntVar(
+		&ingestStatusLimit,
+		"limit",
+		20,
+		"number of jobs to list, most recent first",
+	)
+
 	rootCmd.AddCommand(dbCmd)
 	dbCmd.AddCommand(
 		dbInitCmd,
 		dbInitAssetStatsCmd,
 		dbBackfillCmd,
+		dbBackfillMemosCmd,
 		dbClearCmd,
+		dbIngestStatusCmd,
 		dbMigrateCmd,
 		dbReapCmd,
+		dbRebuildTradeRollupsCmd,
 		dbReingestCmd,
 		dbRebaseCmd,
 	)
@@ -257,9 +398,13 @@ func ingestSystem(ingestConfig ingest.Config) *ingest.System {
 		log.Fatal(err)
 	}
 
-	cdb, err := db.Open("postgres", config.StellarCoreDatabaseURL)
-	if err != nil {
-		log.Fatal(err)
+	// ledgers read from a history archive don't require a stellar-core database
+	var cdb *db.Session
+	if config.HistoryArchiveURL == "" {
+		cdb, err = db.Open("postgres", config.StellarCoreDatabaseURL)
+		if err != nil {
+			log.Fatal(err)
+		}
 	}
 
 	passphrase := viper.GetString("network-passphrase")
@@ -267,7 +412,17 @@ func ingestSystem(ingestConfig ingest.Config) *ingest.System {
 		log.Fatal("network-passphrase is blank: reingestion requires manually setting passphrase")
 	}
 
-	return ingest.New(passphrase, config.StellarCoreURL, cdb, hdb, ingestConfig)
+	i := ingest.New(passphrase, config.StellarCoreURL, cdb, hdb, ingestConfig)
+
+	if config.HistoryArchiveURL != "" {
+		source, err := ingest.NewArchiveLedgerSource(config.HistoryArchiveURL, passphrase)
+		if err != nil {
+			log.Fatal(err)
+		}
+		i.LedgerSource = source
+	}
+
+	return i
 }
 
 func reingest(i *ingest.System, args []string) (int, error) {
@@ -306,3 +461,68 @@ func reingest(i *ingest.System, args []string) (int, error) {
 	}
 	return len(args), nil
 }
+
+// reingestParallel runs `reingest range FROM TO` with several workers, logging
+// the progress of each worker until the whole range is reingested.
+func reingestParallel(i *ingest.System, args []string) {
+	if len(args) != 3 || args[0] != "range" {
+		log.Fatal("--parallel-workers is only supported by `reingest range FROM TO`")
+	}
+
+	from, err := strconv.Atoi(args[1])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	to, err := strconv.Atoi(args[2])
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r := ingest.NewParallelReingestion(i, int32(from), int32(to), parallelWorkers)
+
+	logStatus := func(stage string) {
+		status := r.Status()
+		for idx, worker := range status.Workers {
+			entry := hlog.WithField("worker", idx).WithField("ingested", worker.Ingested)
+			if worker.Busy {
+				entry = entry.
+					WithField("chunk", fmt.Sprintf("%d-%d", worker.Chunk.Start, worker.Chunk.End)).
+					WithField("ledger", worker.Ledger)
+			}
+			entry.Infof("reingest: worker %s", stage)
+		}
+
+		hlog.WithField("ingested", status.Ingested).
+			WithField("total", status.Total).
+			WithField("failed_chunks", status.Failed).
+			WithField("eta", status.ETA.Round(time.Second).String()).
+			Infof("reingest: %s", stage)
+	}
+
+	done := make(chan error, 1)
+
+	go func() {
+		failed, err := r.Run()
+		for _, chunk := range failed {
+			hlog.WithField("err", chunk.Err.Error()).
+				Errorf("reingest: chunk failed, retry with `horizon db reingest range %d %d`", chunk.Start, chunk.End)
+		}
+		logStatus("complete")
+		done <- err
+	}()
+
+	metrics := time.Tick(2 * time.Second)
+	for {
+		select {
+		case <-metrics:
+			logStatus("status")
+
+		case err := <-done:
+			if err != nil {
+				log.Fatal(err)
+			}
+			os.Exit(0)
+		}
+	}
+}
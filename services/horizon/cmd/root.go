@@ -5,12 +5,15 @@ import (
 	"go/types"
 	stdLog "log"
 	"os"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"github.com/cowry-network/go/keypair"
 	horizon "github.com/cowry-network/go/services/horizon/internal"
 	"github.com/cowry-network/go/services/horizon/internal/db2/schema"
+	"github.com/cowry-network/go/services/horizon/internal/ratelimit"
 	apkg "github.com/cowry-network/go/support/app"
 	support "github.com/cowry-network/go/support/config"
 	"github.com/cowry-network/go/support/log"
@@ -68,6 +71,22 @@ var configOpts = []*support.ConfigOption{
 		Required:  true,
 		Usage:     "horizon postgres database to connect with",
 	},
+	&support.ConfigOption{
+		Name:      "history-replica-db-urls",
+		EnvVar:    "HISTORY_REPLICA_DATABASE_URLS",
+		ConfigKey: &config.HistoryReplicaURLs,
+		OptType:   types.String,
+		CustomSetValue: func(co *support.ConfigOption) {
+			var dsns []string
+			for _, dsn := range strings.Split(viper.GetString(co.Name), ",") {
+				if dsn = strings.TrimSpace(dsn); dsn != "" {
+					dsns = append(dsns, dsn)
+				}
+			}
+			*(co.ConfigKey.(*[]string)) = dsns
+		},
+		Usage: "comma separated read-only replicas of the horizon postgres database, history requests are served from them unless they lag more than history-stale-threshold ledgers behind it",
+	},
 	&support.ConfigOption{
 		Name:      "stellar-core-db-url",
 		EnvVar:    "STELLAR_CORE_DATABASE_URL",
@@ -90,6 +109,20 @@ var configOpts = []*support.ConfigOption{
 		FlagDefault: uint(8000),
 		Usage:       "tcp port to listen on for http requests",
 	},
+	&support.ConfigOption{
+		Name:        "admin-port",
+		ConfigKey:   &config.AdminPort,
+		OptType:     types.Uint,
+		FlagDefault: uint(0),
+		Usage:       "tcp port to listen on for admin http requests, 0 (default) disables the admin server",
+	},
+	&support.ConfigOption{
+		Name:        "admin-host",
+		ConfigKey:   &config.AdminHost,
+		OptType:     types.String,
+		FlagDefault: "127.0.0.1",
+		Usage:       "interface the admin server listens on, defaults to localhost so that it is not reachable from other machines",
+	},
 	&support.ConfigOption{
 		Name:        "max-db-connections",
 		ConfigKey:   &config.MaxDBConnections,
@@ -131,6 +164,34 @@ var configOpts = []*support.ConfigOption{
 		},
 		Usage: "max count of requests allowed in a one hour period, by remote ip address",
 	},
+	&support.ConfigOption{
+		Name:      "per-route-rate-limit",
+		ConfigKey: &config.RateLimitRules,
+		OptType:   types.String,
+		CustomSetValue: func(co *support.ConfigOption) {
+			rules, err := ratelimit.ParseRules(viper.GetString(co.Name))
+			if err != nil {
+				stdLog.Fatalf("Could not parse per-route-rate-limit: %v", err)
+			}
+			*(co.ConfigKey.(*[]ratelimit.Rule)) = rules
+		},
+		Usage: "comma separated per-route rate limits overriding per-hour-rate-limit, each an optional http method and a path, followed by the max count of requests allowed per hour and optionally the max burst size, e.g. `POST /transactions=360,/paths=720:20`",
+	},
+	&support.ConfigOption{
+		Name:      "rate-limit-allowlist",
+		ConfigKey: &config.RateLimitAllowlist,
+		OptType:   types.String,
+		CustomSetValue: func(co *support.ConfigOption) {
+			var allowlist []string
+			for _, entry := range strings.Split(viper.GetString(co.Name), ",") {
+				if entry = strings.TrimSpace(entry); entry != "" {
+					allowlist = append(allowlist, entry)
+				}
+			}
+			*(co.ConfigKey.(*[]string)) = allowlist
+		},
+		Usage: "comma separated ip addresses, networks (in CIDR notation) and api keys (sent in the X-Api-Key header) that are not rate limited",
+	},
 	&support.ConfigOption{
 		Name:      "rate-limit-redis-key",
 		ConfigKey: &config.RateLimitRedisKey,
@@ -177,6 +238,22 @@ var configOpts = []*support.ConfigOption{
 		FlagDefault: uint(4),
 		Usage:       "the maximum number of assets on the path in `/paths` endpoint",
 	},
+	&support.ConfigOption{
+		Name:        "path-finder",
+		ConfigKey:   &config.PathFinder,
+		OptType:     types.String,
+		FlagDefault: horizon.PathFinderSimple,
+		CustomSetValue: func(co *support.ConfigOption) {
+			pathFinder := viper.GetString(co.Name)
+			switch pathFinder {
+			case horizon.PathFinderSimple, horizon.PathFinderOrderBook:
+				*(co.ConfigKey.(*string)) = pathFinder
+			default:
+				stdLog.Fatalf("Invalid path-finder: %s (must be one of: %s, %s)", pathFinder, horizon.PathFinderSimple, horizon.PathFinderOrderBook)
+			}
+		},
+		Usage: "path finding engine used by `/paths` endpoint: `simple` (queries stellar-core db for every hop) or `orderbook` (keeps all offers in memory, faster but uses more RAM)",
+	},
 	&support.ConfigOption{
 		Name:      "network-passphrase",
 		ConfigKey: &config.NetworkPassphrase,
@@ -229,6 +306,12 @@ var configOpts = []*support.ConfigOption{
 		FlagDefault: false,
 		Usage:       "causes this horizon process to ingest failed transactions data",
 	},
+	&support.ConfigOption{
+		Name:      "history-archive-url",
+		ConfigKey: &config.HistoryArchiveURL,
+		OptType:   types.String,
+		Usage:     "history archive to ingest ledgers from instead of the stellar-core database, e.g. file:///var/lib/stellar/history; effects, trades and ledger entry changes are not ingested from archives",
+	},
 	&support.ConfigOption{
 		Name:        "history-retention-count",
 		ConfigKey:   &config.HistoryRetentionCount,
@@ -257,6 +340,41 @@ var configOpts = []*support.ConfigOption{
 		FlagDefault: false,
 		Usage:       "enables asset stats during the ingestion and expose `/assets` endpoint, Enabling it has a negative impact on CPU",
 	},
+	&support.ConfigOption{
+		Name:      "txsub-fee-source-secret",
+		ConfigKey: &config.TxSubFeeSource,
+		OptType:   types.String,
+		CustomSetValue: func(co *support.ConfigOption) {
+			seed := viper.GetString(co.Name)
+			if seed == "" {
+				return
+			}
+			kp, err := keypair.Parse(seed)
+			if err != nil {
+				stdLog.Fatalf("Could not parse txsub-fee-source-secret: %v", err)
+			}
+			full, ok := kp.(*keypair.Full)
+			if !ok {
+				stdLog.Fatal("txsub-fee-source-secret must be a secret seed")
+			}
+			*(co.ConfigKey.(**keypair.Full)) = full
+		},
+		Usage: "secret seed of a fee source account, enabling the monitoring of the fees of pending transaction submissions while the network is surge pricing, and the rebroadcast with a higher fee of the stuck transactions sent from that account",
+	},
+	&support.ConfigOption{
+		Name:        "txsub-max-operation-fee",
+		ConfigKey:   &config.TxSubMaxOperationFee,
+		OptType:     types.Uint,
+		FlagDefault: uint(10000),
+		Usage:       "the maximum fee per operation, in stroops, of the transactions rebroadcast with a higher fee",
+	},
+	&support.ConfigOption{
+		Name:        "txsub-shared-submissions",
+		ConfigKey:   &config.TxSubSharedSubmissions,
+		OptType:     types.Bool,
+		FlagDefault: false,
+		Usage:       "shares pending transaction submissions between the horizon servers using the same database, so that a transaction submitted to several of them is only submitted to stellar-core once",
+	},
 }
 
 func init() {
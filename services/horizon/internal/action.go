@@ -1,19 +1,26 @@
 package horizon
 
 import (
+	"encoding/base64"
+	"encoding/hex"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
 
+	"github.com/cowry-network/go/protocols/horizon/operations"
 	"github.com/cowry-network/go/services/horizon/internal/actions"
 	"github.com/cowry-network/go/services/horizon/internal/db2/core"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
 	"github.com/cowry-network/go/services/horizon/internal/httpx"
 	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/render/problem"
+	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
 	"github.com/cowry-network/go/services/horizon/internal/toid"
 	"github.com/cowry-network/go/support/errors"
 	"github.com/cowry-network/go/support/log"
+	"github.com/cowry-network/go/support/time"
+	"github.com/cowry-network/go/xdr"
 )
 
 // Action is the "base type" for all actions in horizon.  It provides
@@ -28,7 +35,9 @@ type Action struct {
 	Log *log.Entry
 
 	hq *history.Q
-	cq *core.Q
+	// hqLedger is the ledger hq was selected to have ingested.
+	hqLedger int32
+	cq       *core.Q
 }
 
 // CoreQ provides access to queries that access the stellar core database.
@@ -41,10 +50,14 @@ func (action *Action) CoreQ() *core.Q {
 }
 
 // HistoryQ provides access to queries that access the history portion of
-// horizon's database.
+// horizon's database.  Queries are read-only: they may be sent to a replica.
+// Once a stream is woken up by a new ledger, they are only sent to a replica
+// that has ingested it.
 func (action *Action) HistoryQ() *history.Q {
-	if action.hq == nil {
-		action.hq = &history.Q{Session: action.App.HorizonSession(action.R.Context())}
+	seq := action.StreamedLedger()
+	if action.hq == nil || action.hqLedger != seq {
+		action.hq = &history.Q{Session: action.App.HorizonReadSessionAt(action.R.Context(), seq)}
+		action.hqLedger = seq
 	}
 
 	return action.hq
@@ -54,7 +67,7 @@ func (action *Action) HistoryQ() *history.Q {
 func (action *Action) Prepare(w http.ResponseWriter, r *http.Request) {
 	base := &action.Base
 	action.App = AppFromContext(r.Context())
-	base.Prepare(w, r, action.App.ctx, action.App.config.SSEUpdateFrequency)
+	base.Prepare(w, r, action.App.ctx, action.App.config.SSEUpdateFrequency, action.App.ledgerEvents)
 	if action.R.Context() != nil {
 		action.Log = log.Ctx(action.R.Context())
 	} else {
@@ -152,3 +165,201 @@ func (action *Action) FullURL() *url.URL {
 func (action *Action) baseURL() *url.URL {
 	return httpx.BaseURL(action.R.Context())
 }
+
+// accountStreamFilter returns the filter of streams concerning the account
+// `address`, or an empty filter matching every ledger if `address` is blank.
+func accountStreamFilter(address string) ledger.Filter {
+	if address == "" {
+		return ledger.Filter{}
+	}
+
+	return ledger.Filter{Accounts: []string{address}}
+}
+
+// historyFilters are the filters the operation, payment and effect index
+// actions accept on top of their account, ledger or transaction scope.
+type historyFilters struct {
+	Asset     xdr.Asset
+	HasAsset  bool
+	MinAmount xdr.Int64
+	MaxAmount xdr.Int64
+	StartTime time.Millis
+	EndTime   time.Millis
+}
+
+// getHistoryFilters loads the asset (`asset_type`, `asset_code` and
+// `asset_issuer`), amount range (`min_amount` and `max_amount`) and time range
+// (`start_time` and `end_time`, in milliseconds since epoch) filters.
+func (action *Action) getHistoryFilters() (filters historyFilters) {
+	filters.Asset, filters.HasAsset = action.MaybeGetAsset("")
+
+	if action.GetString("min_amount") != "" {
+		filters.MinAmount = action.GetPositiveAmount("min_amount")
+	}
+	if action.GetString("max_amount") != "" {
+		filters.MaxAmount = action.GetPositiveAmount("max_amount")
+	}
+	if filters.MaxAmount > 0 && filters.MaxAmount < filters.MinAmount {
+		action.SetInvalidField("max_amount", errors.New("must be greater than or equal to min_amount"))
+		return
+	}
+
+	filters.StartTime = action.GetTimeMillis("start_time")
+	filters.EndTime = action.GetTimeMillis("end_time")
+	if !filters.EndTime.IsNil() && filters.EndTime <= filters.StartTime {
+		action.SetInvalidField("end_time", errors.New("must be greater than start_time"))
+		return
+	}
+
+	return
+}
+
+// getOperationTypes loads the operation types in the comma separated list of
+// operation type names `name`.
+func (action *Action) getOperationTypes(name string) []xdr.OperationType {
+	var types []xdr.OperationType
+	for _, typeName := range action.getList(name) {
+		found := false
+		for typ, n := range operations.TypeNames {
+			if n == typeName {
+				types = append(types, typ)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			action.SetInvalidField(name, errors.Errorf("unknown operation type: %s", typeName))
+			return nil
+		}
+	}
+
+	return types
+}
+
+// getEffectTypes loads the effect types in the comma separated list of effect
+// type names `name`.
+func (action *Action) getEffectTypes(name string) []history.EffectType {
+	var types []history.EffectType
+	for _, typeName := range action.getList(name) {
+		found := false
+		for typ, n := range resourceadapter.EffectTypeNames {
+			if n == typeName {
+				types = append(types, typ)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			action.SetInvalidField(name, errors.Errorf("unknown effect type: %s", typeName))
+			return nil
+		}
+	}
+
+	return types
+}
+
+// getList loads the non-blank values of the comma separated list `name`.
+func (action *Action) getList(name string) []string {
+	var values []string
+	for _, value := range strings.Split(action.GetString(name), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+
+	return values
+}
+
+// applyToOperations adds the filters to `ops`.
+func (filters historyFilters) applyToOperations(ops *history.OperationsQ) {
+	if filters.HasAsset {
+		ops.ForAsset(filters.Asset)
+	}
+	ops.ForAmountRange(filters.MinAmount, filters.MaxAmount)
+	if !filters.StartTime.IsNil() || !filters.EndTime.IsNil() {
+		ops.ForTimeRange(filters.StartTime, filters.EndTime)
+	}
+}
+
+// applyToEffects adds the filters to `effects`.
+func (filters historyFilters) applyToEffects(effects *history.EffectsQ) {
+	if filters.HasAsset {
+		effects.ForAsset(filters.Asset)
+	}
+	effects.ForAmountRange(filters.MinAmount, filters.MaxAmount)
+	if !filters.StartTime.IsNil() || !filters.EndTime.IsNil() {
+		effects.ForTimeRange(filters.StartTime, filters.EndTime)
+	}
+}
+
+// streamFilter returns the filter of streams concerning the account `address`,
+// if not blank, and the asset of the filters.  Ledgers are not published with
+// the native asset of account creations and merges, so a stream filtered by
+// the native asset matches every ledger.
+func (filters historyFilters) streamFilter(address string) ledger.Filter {
+	filter := accountStreamFilter(address)
+	if filters.HasAsset && filters.Asset.Type != xdr.AssetTypeAssetTypeNative {
+		filter.Assets = []xdr.Asset{filters.Asset}
+	}
+
+	return filter
+}
+
+// memoFilter is the filter of the transaction and payment index actions on the
+// memo of transactions.
+type memoFilter struct {
+	Type string
+	Memo string
+}
+
+// getMemoFilter loads the `memo_type` and `memo` filters.  Memos are matched as
+// transaction resources render them: id memos in decimal, hash and return memos
+// in base64, for which hex encoded hashes are accepted too.
+func (action *Action) getMemoFilter() (filter memoFilter) {
+	filter.Type = action.GetString("memo_type")
+	filter.Memo = action.GetString("memo")
+	if action.Err != nil {
+		return
+	}
+
+	switch filter.Type {
+	case "":
+	case "none":
+		if filter.Memo != "" {
+			action.SetInvalidField("memo", errors.New("must be blank when memo_type is none"))
+		}
+	case "text":
+		if len(filter.Memo) > 28 {
+			action.SetInvalidField("memo", errors.New("text memos are at most 28 bytes long"))
+		}
+	case "id":
+		if filter.Memo == "" {
+			return
+		}
+
+		id, err := strconv.ParseUint(filter.Memo, 10, 64)
+		if err != nil {
+			action.SetInvalidField("memo", errors.New("id memos are unsigned 64-bit integers"))
+			return
+		}
+		filter.Memo = strconv.FormatUint(id, 10)
+	case "hash", "return":
+		if filter.Memo == "" {
+			return
+		}
+
+		if raw, err := hex.DecodeString(filter.Memo); err == nil && len(raw) == 32 {
+			filter.Memo = base64.StdEncoding.EncodeToString(raw)
+			return
+		}
+		if raw, err := base64.StdEncoding.DecodeString(filter.Memo); err != nil || len(raw) != 32 {
+			action.SetInvalidField("memo", errors.New("hash memos are 32 bytes, encoded in base64 or hex"))
+		}
+	default:
+		action.SetInvalidField("memo_type", errors.New("must be one of none, text, id, hash or return"))
+	}
+
+	return
+}
@@ -28,17 +28,32 @@ type Base struct {
 
 	appCtx             context.Context
 	sseUpdateFrequency time.Duration
+	ledgerEvents       *ledger.Bus
+	streamedLedger     int32
 	isSetup            bool
 }
 
 // Prepare established the common attributes that get used in nearly every
 // action.  "Child" actions may override this method to extend action, but it
 // is advised you also call this implementation to maintain behavior.
-func (base *Base) Prepare(w http.ResponseWriter, r *http.Request, appCtx context.Context, sseUpdateFrequency time.Duration) {
+func (base *Base) Prepare(
+	w http.ResponseWriter,
+	r *http.Request,
+	appCtx context.Context,
+	sseUpdateFrequency time.Duration,
+	ledgerEvents *ledger.Bus,
+) {
 	base.W = w
 	base.R = r
 	base.sseUpdateFrequency = sseUpdateFrequency
 	base.appCtx = appCtx
+	base.ledgerEvents = ledgerEvents
+}
+
+// StreamedLedger returns the latest ledger that woke up the stream served by
+// the action, 0 until it is woken up.
+func (base *Base) StreamedLedger() int32 {
+	return base.streamedLedger
 }
 
 // Execute trigger content negotiation and the actual execution of one of the
@@ -69,14 +84,22 @@ func (base *Base) Execute(action interface{}) {
 
 		stream := sse.NewStream(ctx, base.W)
 
+		// On the server that ingests, streams wake up when the ingester commits
+		// a ledger touching the accounts or assets they are filtered by.  Other
+		// servers poll the ledger state.  The subscription is made before the
+		// first query, so that no ledger is missed until the stream's filter is
+		// known.
+		events := base.ledgerEvents.Subscribe()
+		defer events.Close()
+
 		var oldHash [32]byte
-		for {
+		for iteration := 0; ; iteration++ {
 			lastLedgerState := ledger.CurrentState()
 
 			// Rate limit the request if it's a call to stream since it queries the DB every second. See
 			// https://github.com/cowry-network/go/issues/715 for more details.
 			app := base.R.Context().Value(&horizonContext.AppContextKey)
-			rateLimiter := app.(RateLimiterProvider).GetRateLimiter()
+			rateLimiter := app.(RateLimiterProvider).GetRateLimiter(base.R)
 			if rateLimiter != nil {
 				limited, _, err := rateLimiter.RateLimiter.RateLimit(rateLimiter.VaryBy.Key(base.R), 1)
 				if err != nil {
@@ -128,23 +151,37 @@ func (base *Base) Execute(action interface{}) {
 				return
 			}
 
+			// The filter depends on the parameters loaded by the first query.
+			if filterer, ok := action.(StreamFilterer); ok && iteration == 0 {
+				events.SetFilter(filterer.StreamFilter())
+			}
+
 			// Make sure this is buffered channel of size 1. Otherwise, the go routine below
 			// will never return if `newLedgers` channel is not read. From Effective Go:
 			// > If the channel is unbuffered, the sender blocks until the receiver has received the value.
-			newLedgers := make(chan bool, 1)
-			go func() {
-				for {
-					time.Sleep(base.sseUpdateFrequency)
-					currentLedgerState := ledger.CurrentState()
-					if currentLedgerState.HistoryLatest >= lastLedgerState.HistoryLatest+1 {
-						newLedgers <- true
-						return
+			var newLedgers chan int32
+			if base.ledgerEvents == nil {
+				newLedgers = make(chan int32, 1)
+				go func() {
+					for {
+						time.Sleep(base.sseUpdateFrequency)
+						currentLedgerState := ledger.CurrentState()
+						if currentLedgerState.HistoryLatest >= lastLedgerState.HistoryLatest+1 {
+							newLedgers <- currentLedgerState.HistoryLatest
+							return
+						}
 					}
-				}
-			}()
+				}()
+			}
 
+			// The ledger that woke the stream up may not have reached every
+			// replica of the horizon database yet, see StreamedLedger.
 			select {
-			case <-newLedgers:
+			case seq := <-newLedgers:
+				base.streamedLedger = seq
+				continue
+			case event := <-events.C:
+				base.streamedLedger = event.Sequence
 				continue
 			case <-ctx.Done():
 			case <-base.appCtx.Done():
@@ -164,6 +201,17 @@ func (base *Base) Execute(action interface{}) {
 			problem.Render(ctx, base.W, err)
 			return
 		}
+	case render.MimePrometheus:
+		action, ok := action.(PrometheusResponder)
+		if !ok {
+			goto NotAcceptable
+		}
+
+		err := action.Prometheus()
+		if err != nil {
+			problem.Render(ctx, base.W, err)
+			return
+		}
 	default:
 		goto NotAcceptable
 	}
@@ -5,6 +5,7 @@ import (
 	"mime"
 	"net/url"
 	"strconv"
+	"strings"
 	"unicode/utf8"
 
 	"github.com/go-chi/chi"
@@ -405,6 +406,48 @@ func (base *Base) MaybeGetAsset(prefix string) (xdr.Asset, bool) {
 	return base.GetAsset(prefix), true
 }
 
+// MaybeGetCreditAsset decodes a credit asset in the `CODE:ISSUER` format from
+// the request field `name`, only if the field is populated. Returns an
+// additional boolean reflecting whether or not the decoding was performed.
+func (base *Base) MaybeGetCreditAsset(name string) (xdr.Asset, bool) {
+	if base.Err != nil {
+		return xdr.Asset{}, false
+	}
+
+	val := base.GetString(name)
+	if base.Err != nil || val == "" {
+		return xdr.Asset{}, false
+	}
+
+	parts := strings.Split(val, ":")
+	if len(parts) != 2 {
+		base.SetInvalidField(name, errors.New("asset must be in the CODE:ISSUER format"))
+		return xdr.Asset{}, false
+	}
+
+	_, err := strkey.Decode(strkey.VersionByteAccountID, parts[1])
+	if err != nil {
+		base.SetInvalidField(name, errors.New("invalid issuer"))
+		return xdr.Asset{}, false
+	}
+
+	var issuer xdr.AccountId
+	err = issuer.SetAddress(parts[1])
+	if err != nil {
+		base.SetInvalidField(name, errors.New("invalid issuer"))
+		return xdr.Asset{}, false
+	}
+
+	var result xdr.Asset
+	err = result.SetCredit(parts[0], issuer)
+	if err != nil {
+		base.SetInvalidField(name, errors.New("invalid asset code"))
+		return xdr.Asset{}, false
+	}
+
+	return result, true
+}
+
 // GetTimeMillis retrieves a TimeMillis from the action parameter of the given name.
 // Populates err if the value is not a valid TimeMillis
 func (base *Base) GetTimeMillis(name string) (timeMillis time.Millis) {
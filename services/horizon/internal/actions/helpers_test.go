@@ -65,6 +65,37 @@ func TestGetAsset(t *testing.T) {
 	})
 }
 
+func TestMaybeGetCreditAsset(t *testing.T) {
+	tt := test.Start(t)
+	defer tt.Finish()
+
+	action := makeAction("/?asset=USD:GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU", nil)
+	asset, found := action.MaybeGetCreditAsset("asset")
+	if tt.Assert.NoError(action.Err) && tt.Assert.True(found) {
+		tt.Assert.Equal(xdr.AssetTypeAssetTypeCreditAlphanum4, asset.Type)
+		tt.Assert.Equal(
+			xdr.MustNewCreditAsset("USD", "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU").String(),
+			asset.String(),
+		)
+	}
+
+	_, found = action.MaybeGetCreditAsset("missing")
+	tt.Assert.NoError(action.Err)
+	tt.Assert.False(found)
+
+	for _, bad := range []string{
+		"USD",
+		"USD:GAXMF43",
+		"TOOLONGASSETCODE:GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU",
+		":GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU",
+	} {
+		action = makeAction("/?asset="+url.QueryEscape(bad), nil)
+		_, found = action.MaybeGetCreditAsset("asset")
+		tt.Assert.Error(action.Err, bad)
+		tt.Assert.False(found)
+	}
+}
+
 func TestGetAssetType(t *testing.T) {
 	tt := test.Start(t)
 	defer tt.Finish()
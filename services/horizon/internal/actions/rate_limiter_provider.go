@@ -1,8 +1,13 @@
 package actions
 
-import "github.com/throttled/throttled"
+import (
+	"net/http"
 
-// RateLimiterProvider is an interface that provides access to the type's HTTPRateLimiter.
+	"github.com/throttled/throttled"
+)
+
+// RateLimiterProvider is an interface that provides access to the type's
+// HTTPRateLimiter applying to a request, if any.
 type RateLimiterProvider interface {
-	GetRateLimiter() *throttled.HTTPRateLimiter
+	GetRateLimiter(r *http.Request) *throttled.HTTPRateLimiter
 }
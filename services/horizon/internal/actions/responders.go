@@ -1,6 +1,9 @@
 package actions
 
-import "github.com/cowry-network/go/services/horizon/internal/render/sse"
+import (
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
+	"github.com/cowry-network/go/services/horizon/internal/render/sse"
+)
 
 // JSONer implementors can respond to a request whose response type was negotiated
 // to be MimeHal or MimeJSON.
@@ -14,6 +17,12 @@ type RawDataResponder interface {
 	Raw() error
 }
 
+// PrometheusResponder implementors can respond to a request whose response
+// type was negotiated to be MimePrometheus.
+type PrometheusResponder interface {
+	Prometheus() error
+}
+
 // EventStreamer implementors can respond to a request whose response type was negotiated
 // to be MimeEventStream.
 type EventStreamer interface {
@@ -26,3 +35,11 @@ type EventStreamer interface {
 type SingleObjectStreamer interface {
 	LoadEvent() (sse.Event, error)
 }
+
+// StreamFilterer implementors stream data concerning some accounts or assets
+// only.  On the server that ingests, their stream is only run again after a
+// ledger touching one of them is ingested, instead of after every ledger.  An
+// empty filter matches every ledger.
+type StreamFilterer interface {
+	StreamFilter() ledger.Filter
+}
@@ -1,27 +1,41 @@
 package horizon
 
 import (
+	"errors"
+	"strconv"
+
 	"github.com/cowry-network/go/protocols/horizon"
 	"github.com/cowry-network/go/services/horizon/internal/actions"
 	"github.com/cowry-network/go/services/horizon/internal/db2/core"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
+	"github.com/cowry-network/go/services/horizon/internal/render/problem"
 	"github.com/cowry-network/go/services/horizon/internal/render/sse"
 	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
 	"github.com/cowry-network/go/support/render/hal"
+	supportProblem "github.com/cowry-network/go/support/render/problem"
+	"github.com/cowry-network/go/xdr"
 )
 
 // This file contains the actions:
 //
-// AccountShowAction: details for single account (including stellar-core state)
+// AccountShowAction: details for single account (including stellar-core state,
+// or the state at a past ledger reconstructed from history)
+// AccountIndexAction: pages of accounts filtered by signer or trusted asset
 
 // Interface verifications
 var _ actions.JSONer = (*AccountShowAction)(nil)
 var _ actions.SingleObjectStreamer = (*AccountShowAction)(nil)
+var _ actions.StreamFilterer = (*AccountShowAction)(nil)
+var _ actions.JSONer = (*AccountIndexAction)(nil)
 
-// AccountShowAction renders a account summary found by its address.
+// AccountShowAction renders a account summary found by its address.  When the
+// `ledger` parameter is provided, the account is rendered as it was after that
+// ledger closed.
 type AccountShowAction struct {
 	Action
 	Address        string
+	Ledger         int32
 	HistoryRecord  history.Account
 	CoreData       []core.AccountData
 	CoreRecord     core.Account
@@ -46,11 +60,26 @@ func (action *AccountShowAction) LoadEvent() (sse.Event, error) {
 	return sse.Event{Data: action.Resource}, action.Err
 }
 
+// StreamFilter is a method for actions.StreamFilterer
+func (action *AccountShowAction) StreamFilter() ledger.Filter {
+	return accountStreamFilter(action.Address)
+}
+
 func (action *AccountShowAction) loadParams() {
 	action.Address = action.GetAddress("account_id", actions.RequiredParam)
+	action.Ledger = action.GetInt32("ledger")
+
+	if action.Ledger < 0 {
+		action.SetInvalidField("ledger", errors.New("must be a positive ledger sequence"))
+	}
 }
 
 func (action *AccountShowAction) loadRecord() {
+	if action.Ledger != 0 {
+		action.loadHistoricalRecord()
+		return
+	}
+
 	action.Err = action.CoreQ().AccountByAddress(&action.CoreRecord, action.Address)
 	if action.Err != nil {
 		return
@@ -79,6 +108,81 @@ func (action *AccountShowAction) loadRecord() {
 	}
 }
 
+// loadHistoricalRecord reconstructs the account's state after ledger
+// `action.Ledger` from the changes to its ledger entries recorded in the history
+// database.
+func (action *AccountShowAction) loadHistoricalRecord() {
+	ls := ledger.CurrentState()
+	if action.Ledger < ls.HistoryElder {
+		action.Err = &problem.BeforeHistory
+		return
+	}
+
+	if action.Ledger > ls.HistoryLatest {
+		action.Err = &supportProblem.NotFound
+		return
+	}
+
+	// the changes made by ledgers ingested without meta are missing
+	var withoutMeta int
+	action.Err = action.HistoryQ().LedgersWithoutMeta(&withoutMeta, ls.HistoryElder, action.Ledger)
+	if action.Err != nil {
+		return
+	}
+	if withoutMeta > 0 {
+		action.Err = &problem.MissingLedgerEntryChanges
+		return
+	}
+
+	var changes []history.LedgerEntryChange
+	action.Err = action.HistoryQ().AccountEntriesAt(&changes, action.Address, action.Ledger)
+	if action.Err != nil {
+		return
+	}
+
+	found := false
+	action.CoreData = []core.AccountData{}
+	action.CoreSigners = []core.Signer{}
+	action.CoreTrustlines = []core.Trustline{}
+
+	for _, change := range changes {
+		// the entry had been removed by then
+		if !change.EntryAfter.Valid {
+			continue
+		}
+
+		var entry xdr.LedgerEntry
+		action.Err = xdr.SafeUnmarshalBase64(change.EntryAfter.String, &entry)
+		if action.Err != nil {
+			return
+		}
+
+		switch entry.Data.Type {
+		case xdr.LedgerEntryTypeAccount:
+			found = true
+			action.CoreRecord = core.AccountFromLedgerEntry(entry)
+			action.CoreSigners = core.SignersFromLedgerEntry(entry)
+		case xdr.LedgerEntryTypeTrustline:
+			var tl core.Trustline
+			tl, action.Err = core.TrustlineFromLedgerEntry(entry)
+			if action.Err != nil {
+				return
+			}
+			action.CoreTrustlines = append(action.CoreTrustlines, tl)
+		case xdr.LedgerEntryTypeData:
+			action.CoreData = append(action.CoreData, core.AccountDataFromLedgerEntry(entry))
+		}
+	}
+
+	// the account did not exist at that ledger
+	if !found {
+		action.Err = &supportProblem.NotFound
+		return
+	}
+
+	action.Err = action.HistoryQ().AccountByAddress(&action.HistoryRecord, action.Address)
+}
+
 func (action *AccountShowAction) loadResource() {
 	action.Err = resourceadapter.PopulateAccount(
 		action.R.Context(),
@@ -90,3 +194,122 @@ func (action *AccountShowAction) loadResource() {
 		action.HistoryRecord,
 	)
 }
+
+// AccountIndexAction renders a page of accounts, filtered by signer or by
+// trusted asset.
+type AccountIndexAction struct {
+	Action
+	Query      core.AccountsQuery
+	Records    []core.Account
+	NextCursor string
+	Page       hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *AccountIndexAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadPage,
+		func() { hal.Render(action.W, action.Page) },
+	)
+	return action.Err
+}
+
+func (action *AccountIndexAction) loadParams() {
+	action.Query.PageQuery = action.GetPageQuery(actions.DisableCursorValidation)
+	action.Query.Signer = action.GetAddress("signer")
+
+	asset, found := action.MaybeGetCreditAsset("asset")
+	if found {
+		action.Query.Asset = &asset
+	}
+
+	if action.Err == nil && action.Query.Signer == "" && action.Query.Asset == nil {
+		action.SetInvalidField("signer", errors.New("either signer or asset must be provided"))
+	}
+}
+
+func (action *AccountIndexAction) loadRecords() {
+	action.NextCursor, action.Err = action.CoreQ().Accounts(&action.Records, action.Query)
+}
+
+func (action *AccountIndexAction) loadPage() {
+	addresses := make([]string, 0, len(action.Records))
+	for _, record := range action.Records {
+		addresses = append(addresses, record.Accountid)
+	}
+
+	// the data, signers and trustlines of the whole page are loaded at once
+	var (
+		data       []core.AccountData
+		signers    []core.Signer
+		trustlines []core.Trustline
+	)
+
+	if len(addresses) > 0 {
+		action.Err = action.CoreQ().AllDataByAddresses(&data, addresses)
+		if action.Err != nil {
+			return
+		}
+
+		action.Err = action.CoreQ().SignersByAddresses(&signers, addresses)
+		if action.Err != nil {
+			return
+		}
+
+		action.Err = action.CoreQ().TrustlinesByAddresses(&trustlines, addresses)
+		if action.Err != nil {
+			return
+		}
+	}
+
+	dataByAccount := map[string][]core.AccountData{}
+	for _, d := range data {
+		dataByAccount[d.Accountid] = append(dataByAccount[d.Accountid], d)
+	}
+	signersByAccount := map[string][]core.Signer{}
+	for _, s := range signers {
+		signersByAccount[s.Accountid] = append(signersByAccount[s.Accountid], s)
+	}
+	trustlinesByAccount := map[string][]core.Trustline{}
+	for _, tl := range trustlines {
+		trustlinesByAccount[tl.Accountid] = append(trustlinesByAccount[tl.Accountid], tl)
+	}
+
+	for _, record := range action.Records {
+		var res horizon.Account
+		action.Err = resourceadapter.PopulateAccount(
+			action.R.Context(),
+			&res,
+			record,
+			dataByAccount[record.Accountid],
+			signersByAccount[record.Accountid],
+			trustlinesByAccount[record.Accountid],
+			history.Account{},
+		)
+		if action.Err != nil {
+			return
+		}
+
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.Query.PageQuery.Limit
+	action.Page.Cursor = action.Query.PageQuery.Cursor
+	action.Page.Order = action.Query.PageQuery.Order
+	action.Page.PopulateLinks()
+
+	// filtering by signer can stop examining accounts before the page is full,
+	// in which case the next page continues after the last account examined
+	if action.NextCursor != "" {
+		nextURL := action.FullURL()
+		q := nextURL.Query()
+		q.Set("cursor", action.NextCursor)
+		q.Set("order", action.Page.Order)
+		q.Set("limit", strconv.FormatUint(action.Page.Limit, 10))
+		nextURL.RawQuery = q.Encode()
+		action.Page.Links.Next = hal.NewLink(nextURL.String())
+	}
+}
@@ -4,7 +4,11 @@ import (
 	"encoding/json"
 	"testing"
 
+	"github.com/cowry-network/go/network"
 	"github.com/cowry-network/go/protocols/horizon"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/ingest"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 )
 
 func TestAccountActions_Show(t *testing.T) {
@@ -36,6 +40,94 @@ func TestAccountActions_Show(t *testing.T) {
 	ht.Assert.Equal(404, w.Code)
 }
 
+func TestAccountActions_ShowAtLedger(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// ledger entry changes are not part of the scenario dumps, ingest them.
+	ht.T.ScenarioWithoutHorizon("change_trust")
+	sys := ingest.New(
+		network.TestNetworkPassphrase,
+		"",
+		ht.T.CoreSession(),
+		ht.T.HorizonSession(),
+		ingest.Config{},
+	)
+	s := ingest.NewSession(sys)
+	s.Cursor = ingest.NewCursor(1, ledger.CurrentState().CoreLatest, sys)
+	s.Run()
+	ht.Require.NoError(s.Err)
+	ht.App.UpdateLedgerState()
+
+	load := func(url string) (result horizon.Account) {
+		w := ht.Get(url)
+		if ht.Assert.Equal(200, w.Code) {
+			err := json.Unmarshal(w.Body.Bytes(), &result)
+			ht.Require.NoError(err)
+		}
+		return
+	}
+
+	const url = "/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"
+
+	// created in ledger 2
+	result := load(url + "?ledger=2")
+	ht.Assert.Equal("8589934592", result.Sequence)
+	if ht.Assert.Len(result.Balances, 1) {
+		ht.Assert.Equal("1000.0000000", result.Balances[0].Balance)
+	}
+
+	// trusted USD in ledger 3
+	result = load(url + "?ledger=3")
+	ht.Assert.Equal("8589934593", result.Sequence)
+	ht.Assert.Equal(int32(1), result.SubentryCount)
+	if ht.Assert.Len(result.Balances, 2) {
+		ht.Assert.Equal("USD", result.Balances[0].Code)
+		ht.Assert.Equal("922337203685.4775807", result.Balances[0].Limit)
+		ht.Assert.Equal("999.9999900", result.Balances[1].Balance)
+	}
+
+	// lowered the limit in ledger 4
+	result = load(url + "?ledger=4")
+	if ht.Assert.Len(result.Balances, 2) {
+		ht.Assert.Equal("4000.0000000", result.Balances[0].Limit)
+	}
+
+	// removed the trustline in ledger 5, which matches the current state
+	current := load(url)
+	result = load(url + "?ledger=5")
+	ht.Assert.Equal(current.Sequence, result.Sequence)
+	ht.Assert.Equal(current.SubentryCount, result.SubentryCount)
+	ht.Assert.Equal(current.Balances, result.Balances)
+	ht.Assert.Equal(current.Signers, result.Signers)
+	ht.Assert.Equal(current.Thresholds, result.Thresholds)
+
+	// before the account was created
+	w := ht.Get(url + "?ledger=1")
+	ht.Assert.Equal(404, w.Code)
+
+	// after the latest ingested ledger
+	w = ht.Get(url + "?ledger=100")
+	ht.Assert.Equal(404, w.Code)
+
+	// invalid ledger
+	w = ht.Get(url + "?ledger=-1")
+	ht.Assert.Equal(400, w.Code)
+	w = ht.Get(url + "?ledger=foo")
+	ht.Assert.Equal(400, w.Code)
+
+	// the changes of a ledger ingested without meta are missing
+	_, err := ht.HorizonSession().ExecRaw(
+		"UPDATE history_ledgers SET importer_version = $1 WHERE sequence = 4",
+		history.WithoutMetaImporterVersion,
+	)
+	ht.Require.NoError(err)
+	w = ht.Get(url + "?ledger=3")
+	ht.Assert.Equal(200, w.Code)
+	w = ht.Get(url + "?ledger=4")
+	ht.Assert.Equal(503, w.Code)
+}
+
 func TestAccountActions_ShowRegressions(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -75,3 +167,32 @@ func TestAccountActions_InvalidID(t *testing.T) {
 	)
 	ht.Assert.Equal(400, w.Code)
 }
+
+func TestAccountActions_Index(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	// missing filters
+	w := ht.Get("/accounts")
+	ht.Assert.Equal(400, w.Code)
+
+	// by signer
+	w = ht.Get("/accounts?signer=GD3E7HKMRNT6HGBGHBT6I6JE4N2S4W5KZ246TGJ4KQSXJ2P4BXCUPQMP")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	// by asset
+	w = ht.Get("/accounts?asset=USD:GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	// malformed asset
+	w = ht.Get("/accounts?asset=USD")
+	ht.Assert.Equal(400, w.Code)
+
+	// invalid signer
+	w = ht.Get("/accounts?signer=GDXFAGJCSCI4CK2YHK6YRLA6TKEXFRX7BMGVMQOBMLIEUJRJ5YQNLMIB_")
+	ht.Assert.Equal(400, w.Code)
+}
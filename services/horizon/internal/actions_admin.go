@@ -0,0 +1,132 @@
+package horizon
+
+import (
+	"github.com/cowry-network/go/services/horizon/internal/actions"
+	"github.com/cowry-network/go/services/horizon/internal/ingest"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
+	"github.com/cowry-network/go/services/horizon/internal/render/problem"
+	"github.com/cowry-network/go/support/render/hal"
+)
+
+// This file contains the actions served by the admin server (see admin.go):
+//
+// AdminLedgerStateAction: current ledger state
+// AdminIngestionStatusAction: status of the ingestion system
+// AdminIngestionPauseAction: pauses ingestion
+// AdminIngestionResumeAction: resumes ingestion
+// AdminReapAction: deletes history outside of the retention window
+
+// Interface verification
+var _ actions.JSONer = (*AdminLedgerStateAction)(nil)
+var _ actions.JSONer = (*AdminIngestionStatusAction)(nil)
+var _ actions.JSONer = (*AdminIngestionPauseAction)(nil)
+var _ actions.JSONer = (*AdminIngestionResumeAction)(nil)
+var _ actions.JSONer = (*AdminReapAction)(nil)
+
+// AdminLedgerStateAction renders the ledger state currently cached by horizon.
+type AdminLedgerStateAction struct {
+	Action
+}
+
+// JSON is a method for actions.JSON
+func (action *AdminLedgerStateAction) JSON() error {
+	hal.Render(action.W, ledger.CurrentState())
+	return action.Err
+}
+
+// AdminIngestionStatusAction renders the status of the ingestion system.
+type AdminIngestionStatusAction struct {
+	Action
+	Status ingest.Status
+}
+
+// JSON is a method for actions.JSON
+func (action *AdminIngestionStatusAction) JSON() error {
+	action.Do(
+		action.loadStatus,
+		func() { hal.Render(action.W, action.Status) },
+	)
+	return action.Err
+}
+
+func (action *AdminIngestionStatusAction) loadStatus() {
+	action.Status, action.Err = ingestionStatus(action.App)
+}
+
+// AdminIngestionPauseAction pauses the ingestion system and renders its
+// resulting status.
+type AdminIngestionPauseAction struct {
+	Action
+	Status ingest.Status
+}
+
+// JSON is a method for actions.JSON
+func (action *AdminIngestionPauseAction) JSON() error {
+	action.Do(
+		action.pause,
+		func() { hal.Render(action.W, action.Status) },
+	)
+	return action.Err
+}
+
+func (action *AdminIngestionPauseAction) pause() {
+	if action.App.ingester != nil {
+		action.App.ingester.Pause()
+	}
+	action.Status, action.Err = ingestionStatus(action.App)
+}
+
+// AdminIngestionResumeAction resumes the ingestion system and renders its
+// resulting status.
+type AdminIngestionResumeAction struct {
+	Action
+	Status ingest.Status
+}
+
+// JSON is a method for actions.JSON
+func (action *AdminIngestionResumeAction) JSON() error {
+	action.Do(
+		action.resume,
+		func() { hal.Render(action.W, action.Status) },
+	)
+	return action.Err
+}
+
+func (action *AdminIngestionResumeAction) resume() {
+	if action.App.ingester != nil {
+		action.App.ingester.Resume()
+	}
+	action.Status, action.Err = ingestionStatus(action.App)
+}
+
+// AdminReapAction deletes the history that falls outside of the configured
+// retention window and renders the resulting ledger state.
+type AdminReapAction struct {
+	Action
+}
+
+// JSON is a method for actions.JSON
+func (action *AdminReapAction) JSON() error {
+	action.Do(
+		action.reap,
+		func() { hal.Render(action.W, ledger.CurrentState()) },
+	)
+	return action.Err
+}
+
+func (action *AdminReapAction) reap() {
+	action.Err = action.App.DeleteUnretainedHistory()
+	if action.Err != nil {
+		return
+	}
+	action.App.UpdateLedgerState()
+}
+
+// ingestionStatus returns the status of the app's ingestion system, or the
+// IngestionDisabled problem when the app does not ingest.
+func ingestionStatus(app *App) (ingest.Status, error) {
+	if app.ingester == nil {
+		return ingest.Status{}, &problem.IngestionDisabled
+	}
+	return app.ingester.Status(), nil
+}
@@ -0,0 +1,100 @@
+package horizon
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/cowry-network/go/network"
+	"github.com/cowry-network/go/services/horizon/internal/ingest"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+)
+
+func TestAdminActions(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	// the admin server is disabled by default
+	ht.Assert.Nil(ht.App.admin)
+
+	ht.App.config.AdminPort = 8001
+	initAdmin(ht.App)
+	ht.Require.NotNil(ht.App.admin)
+	rh := test.NewRequestHelper(ht.App.admin)
+
+	// ledger state
+	w := rh.Get("/ledger_state")
+	if ht.Assert.Equal(200, w.Code) {
+		var state ledger.State
+		err := json.Unmarshal(w.Body.Bytes(), &state)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(ledger.CurrentState(), state)
+	}
+
+	// metrics
+	w = rh.Get("/metrics")
+	ht.Assert.Equal(200, w.Code)
+
+	// pprof
+	w = rh.Get("/debug/pprof/goroutine?debug=1")
+	ht.Assert.Equal(200, w.Code)
+
+	// neither admin actions nor pprof are served by the public router
+	w = ht.Get("/ledger_state")
+	ht.Assert.Equal(404, w.Code)
+	w = ht.Get("/debug/pprof/")
+	ht.Assert.Equal(404, w.Code)
+
+	// ingestion controls without an ingester
+	w = rh.Get("/ingestion")
+	ht.Assert.Equal(409, w.Code)
+	w = rh.Post("/ingestion/pause", nil)
+	ht.Assert.Equal(409, w.Code)
+
+	// ingestion controls
+	ht.App.ingester = ingest.New(
+		network.TestNetworkPassphrase,
+		"",
+		ht.T.CoreSession(),
+		ht.T.HorizonSession(),
+		ingest.Config{},
+	)
+
+	var status ingest.Status
+	w = rh.Post("/ingestion/pause", nil)
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &status)
+		ht.Require.NoError(err)
+		ht.Assert.True(status.Paused)
+		ht.Assert.Equal(ingest.CurrentVersion, status.Version)
+	}
+
+	w = rh.Get("/ingestion")
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &status)
+		ht.Require.NoError(err)
+		ht.Assert.True(status.Paused)
+	}
+	ht.Assert.Nil(ht.App.ingester.Tick())
+
+	w = rh.Post("/ingestion/resume", nil)
+	if ht.Assert.Equal(200, w.Code) {
+		err := json.Unmarshal(w.Body.Bytes(), &status)
+		ht.Require.NoError(err)
+		ht.Assert.False(status.Paused)
+	}
+
+	// controls only accept POST
+	w = rh.Get("/ingestion/pause")
+	ht.Assert.Equal(405, w.Code)
+
+	// reap
+	ht.App.reaper.RetentionCount = 1
+	w = rh.Post("/reap", nil)
+	if ht.Assert.Equal(200, w.Code) {
+		var state ledger.State
+		err := json.Unmarshal(w.Body.Bytes(), &state)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(state.HistoryLatest, state.HistoryElder)
+	}
+}
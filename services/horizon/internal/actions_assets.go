@@ -1,22 +1,29 @@
 package horizon
 
 import (
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/cowry-network/go/protocols/horizon"
 	"github.com/cowry-network/go/services/horizon/internal/actions"
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/db2/assets"
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
 	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
 	"github.com/cowry-network/go/support/render/hal"
+	"github.com/cowry-network/go/xdr"
 )
 
 // This file contains the actions:
 //
 // AssetsAction: pages of assets
+// AssetStatsShowAction: detailed stats of a single asset
 
 // Interface verification
 var _ actions.JSONer = (*AssetsAction)(nil)
+var _ actions.JSONer = (*AssetStatsShowAction)(nil)
 
 // AssetsAction renders a page of Assets
 type AssetsAction struct {
@@ -88,3 +95,124 @@ func (action *AssetsAction) loadPage() {
 	action.Page.Order = action.PagingParams.Order
 	action.Page.PopulateLinks()
 }
+
+const (
+	defaultTopHolders   = 10
+	maxTopHolders       = 200
+	defaultSupplyDays   = 30
+	maxSupplyDays       = 365
+	tradeVolumeInterval = 24 * time.Hour
+)
+
+// AssetStatsShowAction renders the stats of a single asset along with its
+// largest holders, its supply of each of the last `days` days and its trades
+// of the last 24 hours.
+type AssetStatsShowAction struct {
+	Action
+	Asset      xdr.Asset
+	AssetID    int64
+	TopHolders int32
+	Days       int32
+	Record     assets.AssetStatsR
+	Holders    []core.Trustline
+	Snapshots  []history.AssetStatSnapshot
+	TradeCount int32
+	Volume     string
+	Resource   horizon.AssetStatDetails
+}
+
+// JSON is a method for actions.JSON
+func (action *AssetStatsShowAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecord,
+		action.loadHolders,
+		action.loadSupplyHistory,
+		action.loadTradeVolume,
+		action.loadResource,
+		func() { hal.Render(action.W, action.Resource) },
+	)
+	return action.Err
+}
+
+func (action *AssetStatsShowAction) loadParams() {
+	asset, found := action.MaybeGetCreditAsset("asset")
+	if action.Err != nil {
+		return
+	}
+	if !found {
+		action.SetInvalidField("asset", errors.New("asset must be in the CODE:ISSUER format"))
+		return
+	}
+	action.Asset = asset
+
+	action.TopHolders = action.GetInt32("top_holders")
+	if action.TopHolders == 0 {
+		action.TopHolders = defaultTopHolders
+	}
+	if action.TopHolders < 0 || action.TopHolders > maxTopHolders {
+		action.SetInvalidField("top_holders", fmt.Errorf("must be between 1 and %d", maxTopHolders))
+		return
+	}
+
+	action.Days = action.GetInt32("days")
+	if action.Days == 0 {
+		action.Days = defaultSupplyDays
+	}
+	if action.Days < 0 || action.Days > maxSupplyDays {
+		action.SetInvalidField("days", fmt.Errorf("must be between 1 and %d", maxSupplyDays))
+		return
+	}
+}
+
+func (action *AssetStatsShowAction) loadRecord() {
+	var typ, code, issuer string
+	action.Err = action.Asset.Extract(&typ, &code, &issuer)
+	if action.Err != nil {
+		return
+	}
+
+	sql, err := assets.AssetStatsQ{
+		AssetCode:   &code,
+		AssetIssuer: &issuer,
+	}.GetSQL()
+	if err != nil {
+		action.Err = err
+		return
+	}
+	action.Err = action.HistoryQ().Get(&action.Record, sql.Limit(1))
+	if action.Err != nil {
+		return
+	}
+
+	action.AssetID, action.Err = action.HistoryQ().GetAssetID(action.Asset)
+}
+
+func (action *AssetStatsShowAction) loadHolders() {
+	action.Err = action.CoreQ().TopTrustlinesForAsset(&action.Holders, action.Asset, uint64(action.TopHolders))
+}
+
+func (action *AssetStatsShowAction) loadSupplyHistory() {
+	until := time.Now().UTC()
+	since := until.AddDate(0, 0, -int(action.Days)+1)
+	action.Err = action.HistoryQ().AssetStatSnapshots(&action.Snapshots, action.AssetID, since, until)
+}
+
+func (action *AssetStatsShowAction) loadTradeVolume() {
+	action.TradeCount, action.Volume, action.Err = action.HistoryQ().TradeVolumeForAsset(
+		action.AssetID,
+		time.Now().Add(-tradeVolumeInterval),
+	)
+}
+
+func (action *AssetStatsShowAction) loadResource() {
+	action.Err = resourceadapter.PopulateAssetStatDetails(
+		action.R.Context(),
+		&action.Resource,
+		action.Record,
+		action.Holders,
+		action.Snapshots,
+		action.TradeCount,
+		action.Volume,
+	)
+}
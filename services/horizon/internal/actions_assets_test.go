@@ -1,6 +1,7 @@
 package horizon
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/cowry-network/go/protocols/horizon"
@@ -35,6 +36,8 @@ func TestAssetsActions(t *testing.T) {
 			AuthRequired:  true,
 			AuthRevocable: false,
 		},
+		BidDepth: "0.0000000",
+		AskDepth: "0.0000000",
 	}
 	SCOTScott := horizon.AssetStat{
 		Links: empty,
@@ -50,6 +53,8 @@ func TestAssetsActions(t *testing.T) {
 			AuthRequired:  false,
 			AuthRevocable: true,
 		},
+		BidDepth: "0.0000000",
+		AskDepth: "0.0000000",
 	}
 	USDGateway := horizon.AssetStat{
 		Links: testDomain,
@@ -65,6 +70,8 @@ func TestAssetsActions(t *testing.T) {
 			AuthRequired:  true,
 			AuthRevocable: false,
 		},
+		BidDepth: "0.0000000",
+		AskDepth: "0.0000000",
 	}
 
 	testCases := []struct {
@@ -301,3 +308,54 @@ func TestAssetStatsDisabledByDefault(t *testing.T) {
 	w := ht.Get("/assets?asset_issuer=GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
 	ht.Assert.Equal(404, w.Code)
 }
+
+func TestAssetStatsShowAction(t *testing.T) {
+	ht := StartHTTPTest(t, "ingest_asset_stats")
+	defer ht.Finish()
+
+	appConfig := NewTestConfig()
+	appConfig.EnableAssetStats = true
+
+	ht.App = NewApp(appConfig)
+	ht.RH = test.NewRequestHelper(ht.App.web.router)
+
+	w := ht.Get("/assets/USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4/stats")
+	ht.Require.Equal(200, w.Code)
+
+	var result horizon.AssetStatDetails
+	ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+	ht.Assert.Equal("USD", result.Code)
+	ht.Assert.Equal(int32(2), result.NumAccounts)
+	ht.Assert.Equal(int32(0), result.NumUnauthorizedAccounts)
+	ht.Assert.Equal("0.0000000", result.BidDepth)
+	ht.Assert.Equal("0.0000000", result.AskDepth)
+	ht.Assert.Equal(int32(0), result.TradeCount24h)
+	ht.Assert.Equal("0.0000000", result.Volume24h)
+	ht.Assert.Len(result.SupplyHistory, 0)
+	if ht.Assert.Len(result.TopHolders, 2) {
+		ht.Assert.Equal(horizon.AssetHolder{
+			Account:    "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2",
+			Balance:    "200121.1688680",
+			Authorized: true,
+		}, result.TopHolders[0])
+		ht.Assert.Equal("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", result.TopHolders[1].Account)
+	}
+
+	w = ht.Get("/assets/USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4/stats?top_holders=1")
+	ht.Require.Equal(200, w.Code)
+	result = horizon.AssetStatDetails{}
+	ht.Require.NoError(json.Unmarshal(w.Body.Bytes(), &result))
+	ht.Assert.Len(result.TopHolders, 1)
+
+	// invalid parameters
+	w = ht.Get("/assets/USD/stats")
+	ht.Assert.Equal(400, w.Code)
+	w = ht.Get("/assets/USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4/stats?top_holders=500")
+	ht.Assert.Equal(400, w.Code)
+	w = ht.Get("/assets/USD:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4/stats?days=400")
+	ht.Assert.Equal(400, w.Code)
+
+	// unknown asset
+	w = ht.Get("/assets/EUR:GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4/stats")
+	ht.Assert.Equal(404, w.Code)
+}
@@ -3,6 +3,7 @@ package horizon
 import (
 	"github.com/cowry-network/go/services/horizon/internal/actions"
 	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/render/sse"
 	"github.com/cowry-network/go/support/render/hal"
 )
@@ -11,6 +12,7 @@ import (
 var _ actions.JSONer = (*DataShowAction)(nil)
 var _ actions.RawDataResponder = (*DataShowAction)(nil)
 var _ actions.EventStreamer = (*DataShowAction)(nil)
+var _ actions.StreamFilterer = (*DataShowAction)(nil)
 
 // DataShowAction renders a account summary found by its address.
 type DataShowAction struct {
@@ -64,6 +66,11 @@ func (action *DataShowAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// StreamFilter is a method for actions.StreamFilterer
+func (action *DataShowAction) StreamFilter() ledger.Filter {
+	return accountStreamFilter(action.Address)
+}
+
 func (action *DataShowAction) loadParams() {
 	action.Address = action.GetAddress("account_id", actions.RequiredParam)
 	action.Key = action.GetString("key")
@@ -7,6 +7,7 @@ import (
 	"github.com/cowry-network/go/services/horizon/internal/actions"
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/render/sse"
 	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
 	"github.com/cowry-network/go/support/errors"
@@ -20,16 +21,19 @@ import (
 // Interface verifications
 var _ actions.JSONer = (*EffectIndexAction)(nil)
 var _ actions.EventStreamer = (*EffectIndexAction)(nil)
+var _ actions.StreamFilterer = (*EffectIndexAction)(nil)
 
 // EffectIndexAction renders a page of effect resources, identified by
 // a normal page query and optionally filtered by an account, ledger,
-// transaction, or operation.
+// transaction, or operation, and by effect type, asset, amount and time.
 type EffectIndexAction struct {
 	Action
 	AccountFilter     string
 	LedgerFilter      int32
 	TransactionFilter string
 	OperationFilter   int64
+	TypeFilter        []history.EffectType
+	Filters           historyFilters
 
 	PagingParams db2.PageQuery
 	Records      []history.Effect
@@ -90,6 +94,11 @@ func (action *EffectIndexAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// StreamFilter is a method for actions.StreamFilterer
+func (action *EffectIndexAction) StreamFilter() ledger.Filter {
+	return action.Filters.streamFilter(action.AccountFilter)
+}
+
 // loadLedgers populates the ledger cache for this action
 func (action *EffectIndexAction) loadLedgers() {
 	action.Ledgers = &history.LedgerCache{}
@@ -108,6 +117,8 @@ func (action *EffectIndexAction) loadParams() {
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
 	action.OperationFilter = action.GetInt64("op_id")
+	action.TypeFilter = action.getEffectTypes("type")
+	action.Filters = action.getHistoryFilters()
 }
 
 // loadRecords populates action.Records
@@ -125,6 +136,11 @@ func (action *EffectIndexAction) loadRecords() {
 		effects.ForTransaction(action.TransactionFilter)
 	}
 
+	if len(action.TypeFilter) > 0 {
+		effects.OfType(action.TypeFilter...)
+	}
+	action.Filters.applyToEffects(effects)
+
 	action.Err = effects.Page(action.PagingParams).Select(&action.Records)
 }
 
@@ -96,3 +96,53 @@ func TestEffectActions_Index(t *testing.T) {
 		}
 	})
 }
+
+func TestEffectActions_IndexFilters(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// filtered by type
+	w := ht.Get("/effects?type=account_debited")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/effects?type=account_credited,account_created")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/effects?type=not_an_effect")
+	ht.Assert.Equal(400, w.Code)
+
+	// filtered by asset, account creations included
+	w = ht.Get("/effects?asset_type=native")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(8, w.Body)
+	}
+
+	// filtered by amount, starting balances included
+	w = ht.Get("/effects?min_amount=50")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(6, w.Body)
+	}
+
+	// filtered by time, ledger 3 closed at 1550753706000
+	w = ht.Get("/effects?start_time=1550753706000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(2, w.Body)
+	}
+
+	// combined with an account
+	w = ht.Get("/accounts/GBXGQJWVLWOYHFLVTKWV5FGHA3LNYY2JQKM7OAJAUEQFU6LPCSEFVXON/effects?type=account_credited&asset_type=native&start_time=1550753706000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	// trades match either of their assets
+	ht.T.Scenario("trades")
+	w = ht.Get("/effects?type=trade&asset_type=credit_alphanum4&asset_code=EUR&asset_issuer=GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+}
@@ -0,0 +1,143 @@
+package horizon
+
+import (
+	"fmt"
+
+	"github.com/cowry-network/go/protocols/horizon"
+	"github.com/cowry-network/go/services/horizon/internal/actions"
+	"github.com/cowry-network/go/services/horizon/internal/db2"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
+	"github.com/cowry-network/go/services/horizon/internal/render/sse"
+	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
+	"github.com/cowry-network/go/support/errors"
+	"github.com/cowry-network/go/support/render/hal"
+)
+
+// This file contains the actions:
+//
+// LedgerEntryChangeIndexAction: pages of ledger entry changes for an account
+
+// Interface verifications
+var _ actions.JSONer = (*LedgerEntryChangeIndexAction)(nil)
+var _ actions.EventStreamer = (*LedgerEntryChangeIndexAction)(nil)
+var _ actions.StreamFilterer = (*LedgerEntryChangeIndexAction)(nil)
+
+// LedgerEntryChangeIndexAction renders a page of the changes made to the
+// ledger entries (account, trustlines, offers and data) of an account.
+type LedgerEntryChangeIndexAction struct {
+	Action
+	AccountFilter string
+	PagingParams  db2.PageQuery
+	Records       []history.LedgerEntryChange
+	Page          hal.Page
+	Ledgers       *history.LedgerCache
+}
+
+// JSON is a method for actions.JSON
+func (action *LedgerEntryChangeIndexAction) JSON() error {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+		action.loadRecords,
+		action.loadLedgers,
+		action.loadPage,
+		func() { hal.Render(action.W, action.Page) },
+	)
+	return action.Err
+}
+
+// SSE is a method for actions.SSE
+func (action *LedgerEntryChangeIndexAction) SSE(stream *sse.Stream) error {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.ValidateCursorWithinHistory,
+	)
+
+	action.Do(
+		action.loadRecords,
+		action.loadLedgers,
+		func() {
+			stream.SetLimit(int(action.PagingParams.Limit))
+			records := action.Records[stream.SentCount():]
+
+			for _, record := range records {
+				var res horizon.LedgerEntryChange
+				action.Err = action.populate(&res, record)
+				if action.Err != nil {
+					return
+				}
+
+				stream.Send(sse.Event{
+					ID:   res.PagingToken(),
+					Data: res,
+				})
+			}
+		},
+	)
+
+	return action.Err
+}
+
+// StreamFilter is a method for actions.StreamFilterer
+func (action *LedgerEntryChangeIndexAction) StreamFilter() ledger.Filter {
+	return accountStreamFilter(action.AccountFilter)
+}
+
+func (action *LedgerEntryChangeIndexAction) loadParams() {
+	action.PagingParams = action.GetPageQuery()
+	action.AccountFilter = action.GetAddress("account_id", actions.RequiredParam)
+}
+
+// loadRecords populates action.Records
+func (action *LedgerEntryChangeIndexAction) loadRecords() {
+	action.Err = action.HistoryQ().LedgerEntryChanges().
+		ForAccount(action.AccountFilter).
+		Page(action.PagingParams).
+		Select(&action.Records)
+}
+
+// loadLedgers populates the ledger cache for this action
+func (action *LedgerEntryChangeIndexAction) loadLedgers() {
+	action.Ledgers = &history.LedgerCache{}
+
+	for _, record := range action.Records {
+		action.Ledgers.Queue(record.LedgerSequence())
+	}
+
+	action.Err = action.Ledgers.Load(action.HistoryQ())
+}
+
+// loadPage populates action.Page
+func (action *LedgerEntryChangeIndexAction) loadPage() {
+	for _, record := range action.Records {
+		var res horizon.LedgerEntryChange
+		action.Err = action.populate(&res, record)
+		if action.Err != nil {
+			return
+		}
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.PopulateLinks()
+}
+
+func (action *LedgerEntryChangeIndexAction) populate(
+	dest *horizon.LedgerEntryChange,
+	record history.LedgerEntryChange,
+) error {
+	ledger, found := action.Ledgers.Records[record.LedgerSequence()]
+	if !found {
+		msg := fmt.Sprintf("could not find ledger data for sequence %d", record.LedgerSequence())
+		return errors.New(msg)
+	}
+
+	resourceadapter.PopulateLedgerEntryChange(action.R.Context(), dest, record, ledger)
+	return nil
+}
@@ -0,0 +1,70 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/network"
+	"github.com/cowry-network/go/protocols/horizon"
+	"github.com/cowry-network/go/services/horizon/internal/ingest"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
+)
+
+func TestLedgerEntryChangeActions_Index(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// ledger entry changes are not part of the scenario dumps, ingest them.
+	ht.T.ScenarioWithoutHorizon("change_trust")
+	sys := ingest.New(
+		network.TestNetworkPassphrase,
+		"",
+		ht.T.CoreSession(),
+		ht.T.HorizonSession(),
+		ingest.Config{},
+	)
+	s := ingest.NewSession(sys)
+	s.Cursor = ingest.NewCursor(1, ledger.CurrentState().CoreLatest, sys)
+	s.Run()
+	ht.Require.NoError(s.Err)
+	ht.App.UpdateLedgerState()
+
+	w := ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/changes")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(12, w.Body)
+
+		var records []horizon.LedgerEntryChange
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal("account", records[0].EntryType)
+		ht.Assert.Equal("created", records[0].ChangeType)
+		ht.Assert.Empty(records[0].EntryBefore)
+		ht.Assert.NotEmpty(records[0].EntryAfter)
+	}
+
+	// paging: the last change_trust operation removed the trustline and
+	// updated the account's subentry count.
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/changes?order=desc&limit=2")
+	if ht.Assert.Equal(200, w.Code) {
+		var records []horizon.LedgerEntryChange
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 2) {
+			removed := 0
+			for _, record := range records {
+				ht.Assert.NotEmpty(record.EntryBefore)
+				if record.ChangeType == "removed" {
+					removed++
+					ht.Assert.Equal("trustline", record.EntryType)
+					ht.Assert.Empty(record.EntryAfter)
+				}
+			}
+			ht.Assert.Equal(1, removed)
+		}
+	}
+
+	// unknown account
+	w = ht.Get("/accounts/GDBAPLDCAEJV6LSEDFEAUDAVFYSNFRUYZ4X75YYJJMMX5KFVUOHX46SQ/changes")
+	ht.Assert.Equal(404, w.Code)
+
+	// invalid account
+	w = ht.Get("/accounts/foo/changes")
+	ht.Assert.Equal(400, w.Code)
+}
@@ -0,0 +1,109 @@
+package horizon
+
+import (
+	gTime "time"
+
+	"github.com/cowry-network/go/protocols/horizon"
+	"github.com/cowry-network/go/services/horizon/internal/actions"
+	"github.com/cowry-network/go/services/horizon/internal/db2"
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
+	"github.com/cowry-network/go/support/render/hal"
+	"github.com/cowry-network/go/support/time"
+)
+
+// This file contains the actions:
+//
+// MarketIndexAction: pages of the markets traded in the last 24 hours
+
+// marketWindow is the window of time summarized by the markets endpoint.
+const marketWindow = 24 * gTime.Hour
+
+// Interface verification
+var _ actions.JSONer = (*MarketIndexAction)(nil)
+
+// MarketIndexAction renders a page of the asset pairs traded in the last 24
+// hours, summarizing their trades and the best prices of their order books.
+type MarketIndexAction struct {
+	Action
+	PagingParams db2.PageQuery
+	Records      []history.MarketSummary
+	OrderBooks   []core.OrderBookSummary
+	Page         hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *MarketIndexAction) JSON() error {
+	action.Do(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		action.loadRecords,
+		action.loadOrderBooks,
+		action.loadPage,
+		func() { hal.Render(action.W, action.Page) },
+	)
+	return action.Err
+}
+
+func (action *MarketIndexAction) loadParams() {
+	action.PagingParams = action.GetPageQuery()
+}
+
+// loadRecords populates action.Records
+func (action *MarketIndexAction) loadRecords() {
+	endTime := time.Now()
+	startTime := time.MillisFromInt64(endTime.ToInt64() - int64(marketWindow/gTime.Millisecond))
+
+	marketsQ, err := action.HistoryQ().GetMarketSummariesQ(startTime, endTime, action.PagingParams)
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	sql, err := marketsQ.GetSql()
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	action.Err = action.HistoryQ().Select(&action.Records, sql)
+}
+
+// loadOrderBooks loads the best bid and ask of each market in action.Records
+func (action *MarketIndexAction) loadOrderBooks() {
+	action.OrderBooks = make([]core.OrderBookSummary, len(action.Records))
+
+	for i, record := range action.Records {
+		base, err := record.BaseAsset()
+		if err != nil {
+			action.Err = err
+			return
+		}
+		counter, err := record.CounterAsset()
+		if err != nil {
+			action.Err = err
+			return
+		}
+
+		action.Err = action.CoreQ().GetOrderBookSummary(&action.OrderBooks[i], base, counter, 1)
+		if action.Err != nil {
+			return
+		}
+	}
+}
+
+// loadPage populates action.Page
+func (action *MarketIndexAction) loadPage() {
+	for i, record := range action.Records {
+		var res horizon.Market
+		resourceadapter.PopulateMarket(action.R.Context(), &res, record, action.OrderBooks[i])
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.PagingParams.Limit
+	action.Page.Cursor = action.PagingParams.Cursor
+	action.Page.Order = action.PagingParams.Order
+	action.Page.PopulateLinks()
+}
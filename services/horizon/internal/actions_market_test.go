@@ -0,0 +1,78 @@
+package horizon
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/protocols/horizon"
+	. "github.com/cowry-network/go/services/horizon/internal/db2/history"
+	. "github.com/cowry-network/go/services/horizon/internal/test/trades"
+	"github.com/cowry-network/go/support/time"
+)
+
+func TestMarketActions_Index(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	now := time.Now().ToInt64()
+	dbQ := &Q{ht.HorizonSession()}
+
+	// two markets traded within the last hour
+	ass1, ass2, err := PopulateTestTrades(dbQ, now-hour, 10, minute, 0)
+	ht.Require.NoError(err)
+	_, _, err = PopulateTestTrades(dbQ, now-hour, 5, minute, 10)
+	ht.Require.NoError(err)
+
+	// a market not traded in the last 24 hours
+	_, _, err = PopulateTestTrades(dbQ, now-2*day, 10, minute, 20)
+	ht.Require.NoError(err)
+
+	var records []horizon.Market
+	w := ht.Get("/markets")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(2, w.Body)
+		ht.UnmarshalPage(w.Body, &records)
+
+		market := records[0]
+		ht.Assert.Equal(ass1.AlphaNum4.Issuer.Address(), market.BaseAssetIssuer)
+		ht.Assert.Equal(ass2.AlphaNum4.Issuer.Address(), market.CounterAssetIssuer)
+		ht.Assert.Equal(int64(10), market.TradeCount)
+		ht.Assert.Equal("0.0005500", market.BaseVolume)
+		ht.Assert.Equal("0.0038500", market.CounterVolume)
+		ht.Assert.Equal("1.0000000", market.Open)
+		ht.Assert.Equal("10.0000000", market.High)
+		ht.Assert.Equal("1.0000000", market.Low)
+		ht.Assert.Equal("10.0000000", market.Close)
+		ht.Assert.Equal("9.0000000", market.Change)
+		ht.Assert.Nil(market.BestBid)
+		ht.Assert.Nil(market.BestAsk)
+
+		ht.Assert.Equal(int64(5), records[1].TradeCount)
+		ht.Assert.Equal("5.0000000", records[1].Close)
+	}
+
+	// paging
+	w = ht.Get("/markets?limit=1")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+		links := ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal(int64(10), records[0].TradeCount)
+
+		w = ht.Get(links.Next.Href)
+		ht.Assert.PageOf(1, w.Body)
+		links = ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal(int64(5), records[0].TradeCount)
+
+		w = ht.Get(links.Next.Href)
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	w = ht.Get("/markets?order=desc")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Len(records, 2)
+		ht.Assert.Equal(int64(5), records[0].TradeCount)
+	}
+
+	w = ht.Get("/markets?cursor=invalid")
+	ht.Assert.Equal(400, w.Code)
+}
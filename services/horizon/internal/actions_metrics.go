@@ -3,14 +3,18 @@ package horizon
 import (
 	metrics "github.com/rcrowley/go-metrics"
 	"github.com/cowry-network/go/services/horizon/internal/actions"
+	"github.com/cowry-network/go/services/horizon/internal/render/prometheus"
 	"github.com/cowry-network/go/support/render/hal"
 )
 
 // Interface verification
 var _ actions.JSONer = (*MetricsAction)(nil)
+var _ actions.PrometheusResponder = (*MetricsAction)(nil)
 
 // MetricsAction collects and renders a snapshot from the metrics system that
-// will inlude any previously registered metrics.
+// will inlude any previously registered metrics.  The snapshot is rendered as
+// HAL, or in the Prometheus text format when requested with an `Accept:
+// text/plain` header.
 type MetricsAction struct {
 	Action
 	Snapshot map[string]interface{}
@@ -27,6 +31,11 @@ func (action *MetricsAction) JSON() error {
 	return action.Err
 }
 
+// Prometheus is a method for actions.PrometheusResponder
+func (action *MetricsAction) Prometheus() error {
+	return prometheus.Render(action.W, "horizon", action.App.metrics)
+}
+
 // LoadSnapshot populates action.Snapshot
 //
 // Original code copied from github.com/rcrowley/go-metrics MarshalJSON
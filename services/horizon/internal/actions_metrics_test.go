@@ -0,0 +1,39 @@
+package horizon
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/cowry-network/go/services/horizon/internal/render/prometheus"
+)
+
+func TestMetricsActions(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+	ht.App.UpdateMetrics()
+
+	// HAL by default
+	w := ht.Get("/metrics")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Contains(w.Body.String(), `"history.latest_ledger"`)
+	}
+
+	// Prometheus text format
+	w = ht.Get("/metrics", func(r *http.Request) {
+		r.Header.Set("Accept", "text/plain;version=0.0.4")
+	})
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.Equal(prometheus.ContentType, w.Header().Get("Content-Type"))
+		body := w.Body.String()
+		ht.Assert.Contains(body, "# TYPE horizon_history_latest_ledger gauge\nhorizon_history_latest_ledger 3\n")
+		ht.Assert.Contains(body, "# TYPE horizon_requests_total_seconds summary\n")
+		ht.Assert.Contains(body, "# TYPE horizon_txsub_succeeded_total counter\n")
+		ht.Assert.Contains(body, "horizon_history_open_connections ")
+	}
+
+	// other actions do not support the format
+	w = ht.Get("/ledgers", func(r *http.Request) {
+		r.Header.Set("Accept", "text/plain")
+	})
+	ht.Assert.Equal(406, w.Code)
+}
@@ -6,16 +6,209 @@ import (
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/db2/core"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/render/sse"
 	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
 	"github.com/cowry-network/go/support/render/hal"
 )
 
 // This file contains the actions:
+//
+// OfferShowAction: details for a single offer
+// OffersAction: page of offers, optionally filtered by seller and assets
+// OffersByAccountAction: page of offers made by a given account
 
 // Interface verifications
+var _ actions.JSONer = (*OfferShowAction)(nil)
+var _ actions.SingleObjectStreamer = (*OfferShowAction)(nil)
+var _ actions.JSONer = (*OffersAction)(nil)
+var _ actions.EventStreamer = (*OffersAction)(nil)
+var _ actions.StreamFilterer = (*OffersAction)(nil)
 var _ actions.JSONer = (*OffersByAccountAction)(nil)
 var _ actions.EventStreamer = (*OffersByAccountAction)(nil)
+var _ actions.StreamFilterer = (*OffersByAccountAction)(nil)
+
+// OfferShowAction renders a single offer, found by its id.  The offer is
+// loaded from the ledger as of the latest validated ledger.
+type OfferShowAction struct {
+	Action
+	OfferID  int64
+	Record   core.Offer
+	Ledger   *history.Ledger
+	Resource horizon.Offer
+}
+
+// JSON is a method for actions.JSON
+func (action *OfferShowAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecord,
+		action.loadLedger,
+		action.loadResource,
+		func() { hal.Render(action.W, action.Resource) },
+	)
+	return action.Err
+}
+
+// LoadEvent is a method for actions.SingleObjectStreamer
+func (action *OfferShowAction) LoadEvent() (sse.Event, error) {
+	action.Do(
+		action.loadParams,
+		action.loadRecord,
+		action.loadLedger,
+		action.loadResource,
+	)
+	return sse.Event{Data: action.Resource}, action.Err
+}
+
+func (action *OfferShowAction) loadParams() {
+	action.OfferID = action.GetInt64("offer_id")
+}
+
+func (action *OfferShowAction) loadRecord() {
+	action.Err = action.CoreQ().OfferByID(&action.Record, action.OfferID)
+}
+
+// loadLedger loads the ledger the offer was last modified in. A missing
+// ledger (i.e. one outside of the known history range) is not an error.
+func (action *OfferShowAction) loadLedger() {
+	var ledger history.Ledger
+	err := action.HistoryQ().LedgerBySequence(&ledger, action.Record.Lastmodified)
+	if action.HistoryQ().NoRows(err) {
+		action.Ledger = nil
+		return
+	}
+
+	if err != nil {
+		action.Err = err
+		return
+	}
+
+	action.Ledger = &ledger
+}
+
+func (action *OfferShowAction) loadResource() {
+	resourceadapter.PopulateOffer(
+		action.R.Context(),
+		&action.Resource,
+		action.Record,
+		action.Ledger,
+	)
+}
+
+// OffersAction renders a page of offer resources, optionally filtered by
+// seller, selling asset and buying asset.  These offers are present in the
+// ledger as of the latest validated ledger.
+type OffersAction struct {
+	Action
+	Query   core.OffersQuery
+	Records []core.Offer
+	Ledgers *history.LedgerCache
+	Page    hal.Page
+}
+
+// JSON is a method for actions.JSON
+func (action *OffersAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadRecords,
+		action.loadLedgers,
+		action.loadPage,
+		func() { hal.Render(action.W, action.Page) },
+	)
+	return action.Err
+}
+
+// SSE is a method for actions.SSE
+func (action *OffersAction) SSE(stream *sse.Stream) error {
+	// Load the params the first time SSE() is called. We update the pagination
+	// cursor below before sending each event to the stream.
+	action.Setup(action.loadParams)
+
+	action.Do(
+		action.loadRecords,
+		action.loadLedgers,
+		func() {
+			stream.SetLimit(int(action.Query.PageQuery.Limit))
+			for _, record := range action.Records {
+				ledger, found := action.Ledgers.Records[record.Lastmodified]
+				ledgerPtr := &ledger
+				if !found {
+					ledgerPtr = nil
+				}
+				var res horizon.Offer
+				resourceadapter.PopulateOffer(action.R.Context(), &res, record, ledgerPtr)
+				action.Query.PageQuery.Cursor = res.PagingToken()
+				stream.Send(sse.Event{ID: res.PagingToken(), Data: res})
+			}
+		},
+	)
+
+	return action.Err
+}
+
+// StreamFilter is a method for actions.StreamFilterer
+func (action *OffersAction) StreamFilter() ledger.Filter {
+	if action.Query.SellerID != "" {
+		return accountStreamFilter(action.Query.SellerID)
+	}
+
+	var filter ledger.Filter
+	if action.Query.Selling != nil {
+		filter.Assets = append(filter.Assets, *action.Query.Selling)
+	}
+	if action.Query.Buying != nil {
+		filter.Assets = append(filter.Assets, *action.Query.Buying)
+	}
+	return filter
+}
+
+func (action *OffersAction) loadParams() {
+	action.Query.PageQuery = action.GetPageQuery()
+	action.Query.SellerID = action.GetAddress("seller")
+
+	if selling, ok := action.MaybeGetAsset("selling_"); ok {
+		action.Query.Selling = &selling
+	}
+
+	if buying, ok := action.MaybeGetAsset("buying_"); ok {
+		action.Query.Buying = &buying
+	}
+}
+
+func (action *OffersAction) loadRecords() {
+	action.Err = action.CoreQ().Offers(&action.Records, action.Query)
+}
+
+// loadLedgers populates the ledger cache for this action
+func (action *OffersAction) loadLedgers() {
+	action.Ledgers = &history.LedgerCache{}
+
+	for _, offer := range action.Records {
+		action.Ledgers.Queue(offer.Lastmodified)
+	}
+	action.Err = action.Ledgers.Load(action.HistoryQ())
+}
+
+func (action *OffersAction) loadPage() {
+	for _, record := range action.Records {
+		ledger, found := action.Ledgers.Records[record.Lastmodified]
+		ledgerPtr := &ledger
+		if !found {
+			ledgerPtr = nil
+		}
+
+		var res horizon.Offer
+		resourceadapter.PopulateOffer(action.R.Context(), &res, record, ledgerPtr)
+		action.Page.Add(res)
+	}
+
+	action.Page.FullURL = action.FullURL()
+	action.Page.Limit = action.Query.PageQuery.Limit
+	action.Page.Cursor = action.Query.PageQuery.Cursor
+	action.Page.Order = action.Query.PageQuery.Order
+	action.Page.PopulateLinks()
+}
 
 // OffersByAccountAction renders a page of offer resources, for a given
 // account.  These offers are present in the ledger as of the latest validated
@@ -74,6 +267,11 @@ func (action *OffersByAccountAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// StreamFilter is a method for actions.StreamFilterer
+func (action *OffersByAccountAction) StreamFilter() ledger.Filter {
+	return accountStreamFilter(action.Address)
+}
+
 func (action *OffersByAccountAction) loadParams() {
 	action.PageQuery = action.GetPageQuery()
 	action.Address = action.GetAddress("account_id")
@@ -2,10 +2,12 @@ package horizon
 
 import (
 	"context"
+	"encoding/json"
 	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/cowry-network/go/protocols/horizon"
 	"github.com/cowry-network/go/services/horizon/internal/render/sse"
 	"github.com/cowry-network/go/services/horizon/internal/test"
 )
@@ -76,3 +78,55 @@ func TestOfferActions_SSE(t *testing.T) {
 	oa.SSE(stream)
 	tt.Require.NoError(oa.Err)
 }
+
+func TestOfferActions_Show(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	w := ht.Get("/offers/4")
+	if ht.Assert.Equal(200, w.Code) {
+		var result horizon.Offer
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.Equal(int64(4), result.ID)
+		ht.Assert.Equal("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", result.Seller)
+		ht.Assert.Equal(int32(10), result.LastModifiedLedger)
+	}
+
+	// missing offer
+	w = ht.Get("/offers/100")
+	ht.Assert.Equal(404, w.Code)
+
+	// invalid id
+	w = ht.Get("/offers/foo")
+	ht.Assert.Equal(400, w.Code)
+}
+
+func TestOfferActions_AllOffers(t *testing.T) {
+	ht := StartHTTPTest(t, "trades")
+	defer ht.Finish()
+
+	w := ht.Get("/offers")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/offers?seller=GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/offers?selling_asset_type=credit_alphanum4&selling_asset_code=USD&selling_asset_issuer=GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4&buying_asset_type=native")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/offers?buying_asset_type=credit_alphanum4&buying_asset_code=USD&buying_asset_issuer=GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4&limit=2")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(2, w.Body)
+	}
+
+	// invalid seller
+	w = ht.Get("/offers?seller=foo")
+	ht.Assert.Equal(400, w.Code)
+}
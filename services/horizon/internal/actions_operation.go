@@ -14,6 +14,7 @@ import (
 	"github.com/cowry-network/go/services/horizon/internal/toid"
 	"github.com/cowry-network/go/support/render/hal"
 	supportProblem "github.com/cowry-network/go/support/render/problem"
+	"github.com/cowry-network/go/xdr"
 )
 
 // This file contains the actions:
@@ -24,15 +25,18 @@ import (
 // Interface verifications
 var _ actions.JSONer = (*OperationIndexAction)(nil)
 var _ actions.EventStreamer = (*OperationIndexAction)(nil)
+var _ actions.StreamFilterer = (*OperationIndexAction)(nil)
 
 // OperationIndexAction renders a page of operations resources, identified by
 // a normal page query and optionally filtered by an account, ledger, or
-// transaction.
+// transaction, and by operation type, asset, amount and time.
 type OperationIndexAction struct {
 	Action
 	LedgerFilter      int32
 	AccountFilter     string
 	TransactionFilter string
+	TypeFilter        []xdr.OperationType
+	Filters           historyFilters
 	PagingParams      db2.PageQuery
 	Records           []history.Operation
 	Ledgers           *history.LedgerCache
@@ -91,11 +95,18 @@ func (action *OperationIndexAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// StreamFilter is a method for actions.StreamFilterer
+func (action *OperationIndexAction) StreamFilter() ledger.Filter {
+	return action.Filters.streamFilter(action.AccountFilter)
+}
+
 func (action *OperationIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
 	action.AccountFilter = action.GetAddress("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
+	action.TypeFilter = action.getOperationTypes("type")
+	action.Filters = action.getHistoryFilters()
 	action.PagingParams = action.GetPageQuery()
 	action.IncludeFailed = action.GetBool("include_failed")
 
@@ -120,6 +131,11 @@ func (action *OperationIndexAction) loadRecords() {
 		ops.ForTransaction(action.TransactionFilter)
 	}
 
+	if len(action.TypeFilter) > 0 {
+		ops.OfType(action.TypeFilter...)
+	}
+	action.Filters.applyToOperations(ops)
+
 	// When querying operations for transaction return both successful
 	// and failed operations. We asume that because user is querying
 	// this specific transactions, she knows it's status.
@@ -239,3 +239,79 @@ func TestOperation_BumpSequence(t *testing.T) {
 		ht.Assert.Equal("300000000003", result.BumpTo)
 	}
 }
+
+func TestOperationActions_IndexFilters(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// filtered by type
+	w := ht.Get("/operations?type=payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?type=create_account,payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/operations?type=not_an_operation")
+	ht.Assert.Equal(400, w.Code)
+
+	// filtered by asset, account creations included
+	w = ht.Get("/operations?asset_type=native")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(4, w.Body)
+	}
+
+	w = ht.Get("/operations?asset_type=credit_alphanum4&asset_code=USD&asset_issuer=GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// filtered by amount, starting balances included
+	w = ht.Get("/operations?min_amount=10")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/operations?max_amount=10")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?min_amount=10&max_amount=5")
+	ht.Assert.Equal(400, w.Code)
+
+	// filtered by time, ledger 3 closed at 1550753706000
+	w = ht.Get("/operations?start_time=1550753706000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/operations?end_time=1550753706000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/operations?start_time=1550753706000&end_time=1550753706000")
+	ht.Assert.Equal(400, w.Code)
+
+	// combined with an account
+	w = ht.Get("/accounts/GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU/operations?type=payment&asset_type=native&min_amount=1&start_time=1550753706000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	// offers match either of their assets
+	ht.T.Scenario("trades")
+	w = ht.Get("/operations?asset_type=credit_alphanum4&asset_code=EUR&asset_issuer=GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(8, w.Body)
+	}
+
+	w = ht.Get("/operations?type=manage_offer&asset_type=native")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+}
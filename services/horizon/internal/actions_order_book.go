@@ -6,6 +6,7 @@ import (
 	"github.com/cowry-network/go/protocols/horizon"
 	"github.com/cowry-network/go/services/horizon/internal/actions"
 	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/render/sse"
 	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
 	"github.com/cowry-network/go/support/render/hal"
@@ -16,6 +17,7 @@ import (
 // Interface verifications
 var _ actions.JSONer = (*OrderBookShowAction)(nil)
 var _ actions.SingleObjectStreamer = (*OrderBookShowAction)(nil)
+var _ actions.StreamFilterer = (*OrderBookShowAction)(nil)
 
 // OrderBookShowAction renders a account summary found by its address.
 type OrderBookShowAction struct {
@@ -79,7 +81,14 @@ func (action *OrderBookShowAction) JSON() error {
 	return action.Err
 }
 
+// LoadEvent is a method for actions.SingleObjectStreamer. A new event is only
+// streamed when the order book summary changes.
 func (action *OrderBookShowAction) LoadEvent() (sse.Event, error) {
 	action.Do(action.LoadQuery, action.LoadRecord, action.LoadResource)
 	return sse.Event{Data: action.Resource}, action.Err
 }
+
+// StreamFilter is a method for actions.StreamFilterer
+func (action *OrderBookShowAction) StreamFilter() ledger.Filter {
+	return ledger.Filter{Assets: []xdr.Asset{action.Selling, action.Buying}}
+}
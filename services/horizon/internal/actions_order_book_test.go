@@ -2,6 +2,7 @@ package horizon
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
 	"github.com/cowry-network/go/protocols/horizon"
@@ -118,3 +119,25 @@ func TestOrderBookActions_Show(t *testing.T) {
 		ht.Assert.Equal("10.0000000", result.Bids[0].Amount)
 	}
 }
+
+func TestOrderBookActions_Stream(t *testing.T) {
+	ht := StartHTTPTest(t, "order_books")
+	defer ht.Finish()
+
+	w := ht.Get(
+		"/order_book?selling_asset_type=native&buying_asset_type=credit_alphanum4&buying_asset_code=USD&buying_asset_issuer=GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4&limit=1",
+		RequestHelperStreamOnce,
+	)
+	if ht.Assert.Equal(200, w.Code) {
+		body := w.Body.String()
+		// a single summary is sent until the order book changes
+		ht.Assert.Equal(1, strings.Count(body, `"bids"`))
+		ht.Assert.Contains(body, `"amount":"100.0000000"`)
+	}
+
+	// invalid parameters are reported before streaming starts
+	w = ht.Get("/order_book?selling_asset_type=native", RequestHelperStreamOnce)
+	if ht.Assert.Equal(400, w.Code) {
+		ht.Assert.ProblemType(w.Body, "invalid_order_book")
+	}
+}
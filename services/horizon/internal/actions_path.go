@@ -10,6 +10,7 @@ import (
 
 // Interface verification
 var _ actions.JSONer = (*PathIndexAction)(nil)
+var _ actions.JSONer = (*StrictSendPathIndexAction)(nil)
 
 // PathIndexAction provides path finding
 type PathIndexAction struct {
@@ -60,3 +61,55 @@ func (action *PathIndexAction) loadPage() {
 		action.Page.Add(res)
 	}
 }
+
+// StrictSendPathIndexAction provides strict send path finding: given a source
+// asset and the exact amount to send, it finds the amount of each asset
+// trusted by the destination account that can be received.
+type StrictSendPathIndexAction struct {
+	Action
+	Query   paths.Query
+	Records []paths.Path
+	Page    hal.BasePage
+}
+
+// JSON implements actions.JSON
+func (action *StrictSendPathIndexAction) JSON() error {
+	action.Do(
+		action.loadQuery,
+		action.loadDestinationAssets,
+		action.loadRecords,
+		action.loadPage,
+		func() { hal.Render(action.W, action.Page) },
+	)
+	return action.Err
+}
+
+func (action *StrictSendPathIndexAction) loadQuery() {
+	action.Query.SourceAmount = action.GetPositiveAmount("source_amount")
+	action.Query.SourceAsset = action.GetAsset("source_")
+	action.Query.DestinationAddress = action.GetAddress("destination_account", actions.RequiredParam)
+}
+
+func (action *StrictSendPathIndexAction) loadDestinationAssets() {
+	action.Err = action.CoreQ().AssetsForAddress(
+		&action.Query.DestinationAssets,
+		action.Query.DestinationAddress,
+	)
+}
+
+func (action *StrictSendPathIndexAction) loadRecords() {
+	action.Records, action.Err = action.App.paths.FindFixedPaths(action.Query, action.App.config.MaxPathLength)
+}
+
+func (action *StrictSendPathIndexAction) loadPage() {
+	action.Page.Init()
+	for _, p := range action.Records {
+		var res horizon.Path
+		action.Err = resourceadapter.PopulatePath(action.R.Context(), &res, action.Query, p)
+
+		if action.Err != nil {
+			return
+		}
+		action.Page.Add(res)
+	}
+}
@@ -37,3 +37,37 @@ func TestPathActions_Index(t *testing.T) {
 	ht.Assert.PageOf(3, w.Body)
 
 }
+
+func TestPathActions_StrictSend(t *testing.T) {
+	ht := StartHTTPTest(t, "paths")
+	defer ht.Finish()
+
+	// no query args
+	w := ht.Get("/paths/strict-send")
+	ht.Assert.Equal(400, w.Code)
+
+	// happy path
+	var q = make(url.Values)
+
+	q.Add(
+		"destination_account",
+		"GAEDTJ4PPEFVW5XV2S7LUXBEHNQMX5Q2GM562RJGOQG7GVCE5H3HIB4V",
+	)
+	q.Add(
+		"source_asset_issuer",
+		"GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN",
+	)
+	q.Add("source_asset_type", "credit_alphanum4")
+	q.Add("source_asset_code", "USD")
+	q.Add("source_amount", "10")
+
+	// 4 paths to EUR and 1 to the native asset, CCC can't be reached from USD
+	w = ht.Get("/paths/strict-send?" + q.Encode())
+	ht.Assert.Equal(200, w.Code)
+	ht.Assert.PageOf(5, w.Body)
+
+	// the source amount is required
+	q.Del("source_amount")
+	w = ht.Get("/paths/strict-send?" + q.Encode())
+	ht.Assert.Equal(400, w.Code)
+}
@@ -4,18 +4,22 @@ import (
 	"errors"
 	"fmt"
 
+	"github.com/cowry-network/go/protocols/horizon/operations"
 	"github.com/cowry-network/go/services/horizon/internal/actions"
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/render/sse"
 	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
 	"github.com/cowry-network/go/support/render/hal"
 	supportProblem "github.com/cowry-network/go/support/render/problem"
+	"github.com/cowry-network/go/xdr"
 )
 
 // Interface verifications
 var _ actions.JSONer = (*PaymentsIndexAction)(nil)
 var _ actions.EventStreamer = (*PaymentsIndexAction)(nil)
+var _ actions.StreamFilterer = (*PaymentsIndexAction)(nil)
 
 // PaymentsIndexAction returns a paged slice of payments based upon the provided
 // filters
@@ -24,6 +28,9 @@ type PaymentsIndexAction struct {
 	LedgerFilter      int32
 	AccountFilter     string
 	TransactionFilter string
+	TypeFilter        []xdr.OperationType
+	Filters           historyFilters
+	MemoFilter        memoFilter
 	PagingParams      db2.PageQuery
 	Records           []history.Operation
 	Ledgers           *history.LedgerCache
@@ -83,11 +90,25 @@ func (action *PaymentsIndexAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// StreamFilter is a method for actions.StreamFilterer
+func (action *PaymentsIndexAction) StreamFilter() ledger.Filter {
+	return action.Filters.streamFilter(action.AccountFilter)
+}
+
 func (action *PaymentsIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
 	action.AccountFilter = action.GetAddress("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
 	action.TransactionFilter = action.GetString("tx_id")
+	action.TypeFilter = action.getOperationTypes("type")
+	for _, typ := range action.TypeFilter {
+		if !history.IsPaymentType(typ) {
+			action.SetInvalidField("type", errors.New("not a payment operation type: "+operations.TypeNames[typ]))
+			return
+		}
+	}
+	action.Filters = action.getHistoryFilters()
+	action.MemoFilter = action.getMemoFilter()
 	action.PagingParams = action.GetPageQuery()
 	action.IncludeFailed = action.GetBool("include_failed")
 
@@ -112,6 +133,12 @@ func (action *PaymentsIndexAction) loadRecords() {
 		ops.ForTransaction(action.TransactionFilter)
 	}
 
+	if len(action.TypeFilter) > 0 {
+		ops.OfType(action.TypeFilter...)
+	}
+	action.Filters.applyToOperations(ops)
+	ops.ForMemo(action.MemoFilter.Type, action.MemoFilter.Memo)
+
 	// When querying operations for transaction return both successful
 	// and failed operations. We asume that because user is querying
 	// this specific transactions, she knows it's status.
@@ -72,3 +72,45 @@ func TestPayment_CreatedAt(t *testing.T) {
 
 	ht.Assert.WithinDuration(l.ClosedAt, records[0].LedgerCloseTime, 1*time.Second)
 }
+
+func TestPaymentActions_Filters(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/payments?type=payment")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	// only payment types are accepted
+	w = ht.Get("/payments?type=manage_offer")
+	ht.Assert.Equal(400, w.Code)
+
+	w = ht.Get("/payments?min_amount=50")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(3, w.Body)
+	}
+
+	w = ht.Get("/payments?asset_type=native&end_time=1550753706000")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+}
+
+func TestPaymentActions_MemoFilter(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	w := ht.Get("/accounts/GA46VRKBCLI2X6DXLX7AIEVRFLH3UA7XBE3NGNP6O74HQ5LXHMGTV2JB/payments?memo_type=text&memo=hello")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/payments?memo_type=id&memo=124")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	w = ht.Get("/payments?memo_type=id&memo=hello")
+	ht.Assert.Equal(400, w.Code)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/cowry-network/go/services/horizon/internal/actions"
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/render/sse"
 	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
 	"github.com/cowry-network/go/support/errors"
@@ -19,6 +20,7 @@ import (
 // Interface verifications
 var _ actions.JSONer = (*TradeIndexAction)(nil)
 var _ actions.EventStreamer = (*TradeIndexAction)(nil)
+var _ actions.StreamFilterer = (*TradeIndexAction)(nil)
 
 type TradeIndexAction struct {
 	Action
@@ -71,6 +73,22 @@ func (action *TradeIndexAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// StreamFilter is a method for actions.StreamFilterer
+func (action *TradeIndexAction) StreamFilter() ledger.Filter {
+	if action.AccountFilter != "" {
+		return accountStreamFilter(action.AccountFilter)
+	}
+
+	var filter ledger.Filter
+	if action.HasBaseAssetFilter {
+		filter.Assets = append(filter.Assets, action.BaseAssetFilter)
+	}
+	if action.HasCounterAssetFilter {
+		filter.Assets = append(filter.Assets, action.CounterAssetFilter)
+	}
+	return filter
+}
+
 // loadParams sets action.Query from the request params
 func (action *TradeIndexAction) loadParams() {
 	action.PagingParams = action.GetPageQuery()
@@ -139,6 +157,8 @@ func (action *TradeIndexAction) loadPage() {
 
 // Interface verification
 var _ actions.JSONer = (*TradeAggregateIndexAction)(nil)
+var _ actions.EventStreamer = (*TradeAggregateIndexAction)(nil)
+var _ actions.StreamFilterer = (*TradeAggregateIndexAction)(nil)
 
 type TradeAggregateIndexAction struct {
 	Action
@@ -151,6 +171,9 @@ type TradeAggregateIndexAction struct {
 	PagingParams       db2.PageQuery
 	Records            []history.TradeAggregation
 	Page               hal.Page
+
+	// lastStreamed is the latest bucket sent to the stream, if any
+	lastStreamed *horizon.TradeAggregation
 }
 
 // JSON is a method for actions.JSON
@@ -165,6 +188,50 @@ func (action *TradeAggregateIndexAction) JSON() error {
 	return action.Err
 }
 
+// SSE is a method for actions.SSE. The most recent aggregation bucket in the
+// requested time range is streamed every time it changes.
+func (action *TradeAggregateIndexAction) SSE(stream *sse.Stream) error {
+	action.Setup(
+		action.EnsureHistoryFreshness,
+		action.loadParams,
+		func() {
+			action.PagingParams.Order = db2.OrderDescending
+			action.PagingParams.Limit = 1
+		},
+	)
+	action.Do(
+		action.loadRecords,
+		func() {
+			if len(action.Records) == 0 {
+				return
+			}
+
+			var res horizon.TradeAggregation
+			action.Err = resourceadapter.PopulateTradeAggregation(action.R.Context(), &res, action.Records[0])
+			if action.Err != nil {
+				return
+			}
+
+			if action.lastStreamed != nil && *action.lastStreamed == res {
+				return
+			}
+
+			action.lastStreamed = &res
+			stream.Send(sse.Event{
+				ID:   strconv.FormatInt(res.Timestamp, 10),
+				Data: res,
+			})
+		},
+	)
+
+	return action.Err
+}
+
+// StreamFilter is a method for actions.StreamFilterer
+func (action *TradeAggregateIndexAction) StreamFilter() ledger.Filter {
+	return ledger.Filter{Assets: []xdr.Asset{action.BaseAssetFilter, action.CounterAssetFilter}}
+}
+
 func (action *TradeAggregateIndexAction) loadParams() {
 	action.PagingParams = action.GetPageQuery()
 	action.BaseAssetFilter = action.GetAsset("base_")
@@ -176,18 +243,16 @@ func (action *TradeAggregateIndexAction) loadParams() {
 
 	//check if resolution is legal
 	resolutionDuration := gTime.Duration(action.ResolutionFilter) * gTime.Millisecond
-	if history.StrictResolutionFiltering {
-		if _, ok := history.AllowedResolutions[resolutionDuration]; !ok {
-			action.SetInvalidField("resolution", errors.New("illegal or missing resolution. "+
-				"allowed resolutions are: 1 minute (60000), 5 minutes (300000), 15 minutes (900000), 1 hour (3600000), "+
-				"1 day (86400000) and 1 week (604800000)"))
-		}
+	if !history.ResolutionAllowed(resolutionDuration) {
+		action.SetInvalidField("resolution", errors.New("illegal or missing resolution. "+
+			"resolution must be a multiple of 1 minute (60000), e.g. 4 hours (14400000) or 30 days (2592000000), "+
+			"and at most 1 year (31536000000)"))
 	}
 	// check if offset is legal
 	offsetDuration := gTime.Duration(action.OffsetFilter) * gTime.Millisecond
-	if offsetDuration%gTime.Hour != 0 || offsetDuration >= gTime.Hour*24 || offsetDuration > resolutionDuration {
-		action.SetInvalidField("offset", errors.New("illegal or missing offset. offset must be a multiple of an"+
-			" hour, less than or equal to the resolution, and less than 24 hours"))
+	if !history.OffsetAllowed(offsetDuration, resolutionDuration) {
+		action.SetInvalidField("offset", errors.New("illegal or missing offset. offset must be a multiple of 15"+
+			" minutes (900000), less than or equal to the resolution, and less than 24 hours"))
 	}
 }
 
@@ -195,13 +260,19 @@ func (action *TradeAggregateIndexAction) loadParams() {
 func (action *TradeAggregateIndexAction) loadRecords() {
 	historyQ := action.HistoryQ()
 
-	//get asset ids
-	baseAssetId, err := historyQ.GetCreateAssetID(action.BaseAssetFilter)
+	//get asset ids, assets that are not in the history database have no trades
+	baseAssetId, err := historyQ.GetAssetID(action.BaseAssetFilter)
+	if historyQ.NoRows(err) {
+		return
+	}
 	if err != nil {
 		action.Err = err
 		return
 	}
-	counterAssetId, err := historyQ.GetCreateAssetID(action.CounterAssetFilter)
+	counterAssetId, err := historyQ.GetAssetID(action.CounterAssetFilter)
+	if historyQ.NoRows(err) {
+		return
+	}
 	if err != nil {
 		action.Err = err
 		return
@@ -212,7 +212,7 @@ func TestTradeActions_Aggregation(t *testing.T) {
 
 	//test illegal resolution
 	if history.StrictResolutionFiltering {
-		q.Add("resolution", strconv.FormatInt(hour/2, 10))
+		q.Add("resolution", strconv.FormatInt(minute*3/2, 10))
 		w = ht.GetWithParams(aggregationPath, q)
 		ht.Assert.Equal(400, w.Code)
 	}
@@ -310,6 +310,40 @@ func TestTradeActions_Aggregation(t *testing.T) {
 	}
 }
 
+func TestTradeActions_AggregationStream(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	const start = int64(1510693200000)
+
+	dbQ := &Q{ht.HorizonSession()}
+	ass1, ass2, err := PopulateTestTrades(dbQ, start, 10, minute, 0)
+	ht.Require.NoError(err)
+
+	q := make(url.Values)
+	setAssetQuery(&q, "base_", ass1)
+	setAssetQuery(&q, "counter_", ass2)
+	q.Add("start_time", strconv.FormatInt(start, 10))
+	q.Add("end_time", strconv.FormatInt(start+hour, 10))
+	q.Add("resolution", strconv.FormatInt(minute, 10))
+
+	// only the latest bucket is streamed
+	w := ht.GetWithParams(aggregationPath, q, RequestHelperStreamOnce)
+	if ht.Assert.Equal(200, w.Code) {
+		body := w.Body.String()
+		ht.Assert.Equal(1, strings.Count(body, "id: "))
+		ht.Assert.Contains(body, fmt.Sprintf("id: %d\n", start+9*minute))
+	}
+
+	// no event is sent when there are no trades in the time range
+	q.Set("start_time", strconv.FormatInt(start+hour, 10))
+	q.Set("end_time", strconv.FormatInt(start+2*hour, 10))
+	w = ht.GetWithParams(aggregationPath, q, RequestHelperStreamOnce)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.NotContains(w.Body.String(), "id: ")
+	}
+}
+
 func TestTradeActions_IndexRegressions(t *testing.T) {
 	t.Run("Regression:  https://github.com/cowry-network/go/services/horizon/internal/issues/318", func(t *testing.T) {
 		ht := StartHTTPTest(t, "trades")
@@ -433,7 +467,7 @@ func TestTradeActions_AggregationInvalidOffset(t *testing.T) {
 		startTime  int64
 		endTime    int64
 	}{
-		{offset: minute, resolution: hour},                                            // Test invalid offset value that's not hour aligned
+		{offset: minute, resolution: hour},                                            // Test invalid offset value that's not aligned to 15 minutes
 		{offset: 25 * hour, resolution: week},                                         // Test invalid offset value that's greater than 24 hours
 		{offset: 3 * hour, resolution: hour},                                          // Test invalid offset value that's greater than the resolution
 		{offset: 3 * hour, startTime: 28 * hour, endTime: 26 * hour, resolution: day}, // Test invalid end time that's less than the start time
@@ -511,3 +545,71 @@ func TestTradeActions_AggregationOffset(t *testing.T) {
 		})
 	}
 }
+
+func TestTradeActions_AggregationRollups(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+	dbQ := &Q{ht.HorizonSession()}
+	// One trade every hour, with prices 1 to 100
+	ass1, ass2, err := PopulateTestTrades(dbQ, 0, 100, hour, 1)
+	ht.Require.NoError(err)
+
+	q := make(url.Values)
+	setAssetQuery(&q, "base_", ass1)
+	setAssetQuery(&q, "counter_", ass2)
+	q.Add("order", "asc")
+	q.Add("start_time", "0")
+
+	var records []horizon.TradeAggregation
+
+	// 4 hours buckets, shifted by half an hour
+	q.Set("resolution", strconv.FormatInt(4*hour, 10))
+	q.Set("offset", strconv.FormatInt(30*minute, 10))
+	w := ht.GetWithParams(aggregationPath, q)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.NotEmpty(records) {
+			ht.Assert.Equal(30*minute, records[0].Timestamp)
+			ht.Assert.Equal(int64(4), records[0].TradeCount)
+			ht.Assert.Equal("0.0001400", records[0].BaseVolume)
+			ht.Assert.Equal("2.0000000", records[0].Open)
+			ht.Assert.Equal("5.0000000", records[0].High)
+			ht.Assert.Equal("2.0000000", records[0].Low)
+			ht.Assert.Equal("5.0000000", records[0].Close)
+		}
+	}
+
+	// reversed assets
+	rq := make(url.Values)
+	setAssetQuery(&rq, "base_", ass2)
+	setAssetQuery(&rq, "counter_", ass1)
+	rq.Add("order", "asc")
+	rq.Add("start_time", "0")
+	rq.Set("resolution", strconv.FormatInt(4*hour, 10))
+	rq.Set("offset", strconv.FormatInt(30*minute, 10))
+	w = ht.GetWithParams(aggregationPath, rq)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.NotEmpty(records) {
+			ht.Assert.Equal(int64(4), records[0].TradeCount)
+			ht.Assert.Equal("0.0001400", records[0].CounterVolume)
+			ht.Assert.Equal("0.5000000", records[0].Open)
+			ht.Assert.Equal("0.5000000", records[0].High)
+			ht.Assert.Equal("0.2000000", records[0].Low)
+			ht.Assert.Equal("0.2000000", records[0].Close)
+		}
+	}
+
+	// 30 days buckets
+	q.Set("resolution", strconv.FormatInt(30*day, 10))
+	q.Set("offset", "0")
+	w = ht.GetWithParams(aggregationPath, q)
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+		ht.UnmarshalPage(w.Body, &records)
+		ht.Assert.Equal(int64(0), records[0].Timestamp)
+		ht.Assert.Equal(int64(100), records[0].TradeCount)
+		ht.Assert.Equal("1.0000000", records[0].Open)
+		ht.Assert.Equal("100.0000000", records[0].Close)
+	}
+}
@@ -1,36 +1,44 @@
 package horizon
 
 import (
+	"encoding/hex"
 	"net/http"
 
 	"github.com/cowry-network/go/protocols/horizon"
 	"github.com/cowry-network/go/services/horizon/internal/actions"
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	hProblem "github.com/cowry-network/go/services/horizon/internal/render/problem"
 	"github.com/cowry-network/go/services/horizon/internal/render/sse"
 	"github.com/cowry-network/go/services/horizon/internal/resourceadapter"
+	"github.com/cowry-network/go/services/horizon/internal/txsim"
 	"github.com/cowry-network/go/services/horizon/internal/txsub"
 	"github.com/cowry-network/go/support/errors"
 	"github.com/cowry-network/go/support/render/hal"
 	"github.com/cowry-network/go/support/render/problem"
+	"github.com/cowry-network/go/xdr"
 )
 
 // This file contains the actions:
 //
 // TransactionIndexAction: pages of transactions
 // TransactionShowAction: single transaction by sequence, by hash or id
+// TransactionStatusAction: status of a submitted transaction
+// TransactionSimulateAction: predicted result of a transaction
 
 // Interface verifications
 var _ actions.JSONer = (*TransactionIndexAction)(nil)
 var _ actions.EventStreamer = (*TransactionIndexAction)(nil)
+var _ actions.StreamFilterer = (*TransactionIndexAction)(nil)
 
 // TransactionIndexAction renders a page of ledger resources, identified by
-// a normal page query.
+// a normal page query, optionally filtered by memo type and memo.
 type TransactionIndexAction struct {
 	Action
 	LedgerFilter  int32
 	AccountFilter string
+	MemoFilter    memoFilter
 	PagingParams  db2.PageQuery
 	Records       []history.Transaction
 	Page          hal.Page
@@ -74,10 +82,16 @@ func (action *TransactionIndexAction) SSE(stream *sse.Stream) error {
 	return action.Err
 }
 
+// StreamFilter is a method for actions.StreamFilterer
+func (action *TransactionIndexAction) StreamFilter() ledger.Filter {
+	return accountStreamFilter(action.AccountFilter)
+}
+
 func (action *TransactionIndexAction) loadParams() {
 	action.ValidateCursorAsDefault()
 	action.AccountFilter = action.GetAddress("account_id")
 	action.LedgerFilter = action.GetInt32("ledger_id")
+	action.MemoFilter = action.getMemoFilter()
 	action.PagingParams = action.GetPageQuery()
 	action.IncludeFailed = action.GetBool("include_failed")
 
@@ -100,6 +114,8 @@ func (action *TransactionIndexAction) loadRecords() {
 		txs.ForLedger(action.LedgerFilter)
 	}
 
+	txs.ForMemo(action.MemoFilter.Type, action.MemoFilter.Memo)
+
 	if !action.IncludeFailed {
 		txs.SuccessfulOnly()
 	}
@@ -160,12 +176,16 @@ func (action *TransactionShowAction) JSON() error {
 var _ actions.JSONer = (*TransactionCreateAction)(nil)
 
 // TransactionCreateAction submits a transaction to the stellar-core network
-// on behalf of the requesting client.
+// on behalf of the requesting client.  With the `async` parameter, it responds
+// with `202 Accepted` as soon as stellar-core accepts the transaction instead
+// of waiting for it to be included in a ledger.
 type TransactionCreateAction struct {
 	Action
-	TX       string
-	Result   txsub.Result
-	Resource horizon.TransactionSuccess
+	TX              string
+	Async           bool
+	Result          txsub.Result
+	Resource        horizon.TransactionSuccess
+	PendingResource horizon.TransactionStatus
 }
 
 // JSON format action handler
@@ -174,7 +194,13 @@ func (action *TransactionCreateAction) JSON() error {
 		action.loadTX,
 		action.loadResult,
 		action.loadResource,
-		func() { hal.Render(action.W, action.Resource) },
+		func() {
+			if action.PendingResource.Hash != "" {
+				hal.RenderStatus(action.W, http.StatusAccepted, action.PendingResource)
+				return
+			}
+			hal.Render(action.W, action.Resource)
+		},
 	)
 	return action.Err
 }
@@ -182,10 +208,15 @@ func (action *TransactionCreateAction) JSON() error {
 func (action *TransactionCreateAction) loadTX() {
 	action.ValidateBodyType()
 	action.TX = action.GetString("tx")
+	action.Async = action.GetBool("async")
 }
 
 func (action *TransactionCreateAction) loadResult() {
-	submission := action.App.submitter.Submit(action.R.Context(), action.TX)
+	submit := action.App.submitter.Submit
+	if action.Async {
+		submit = action.App.submitter.SubmitAsync
+	}
+	submission := submit(action.R.Context(), action.TX)
 
 	select {
 	case result := <-submission:
@@ -201,6 +232,22 @@ func (action *TransactionCreateAction) loadResource() {
 		return
 	}
 
+	if action.Result.Err == txsub.ErrPending {
+		resourceadapter.PopulateTransactionStatus(
+			action.R.Context(), &action.PendingResource, txsub.StatusPending, action.Result,
+		)
+		return
+	}
+
+	// the transaction was stuck because of its fee, and replaced by one with a
+	// higher fee that the client can follow instead
+	if err, ok := action.Result.Err.(*txsub.RebroadcastError); ok {
+		resourceadapter.PopulateTransactionStatus(
+			action.R.Context(), &action.PendingResource, txsub.StatusPending, txsub.Result{Hash: err.Hash},
+		)
+		return
+	}
+
 	if action.Result.Err == txsub.ErrTimeout {
 		action.Err = &hProblem.Timeout
 		return
@@ -231,20 +278,134 @@ func (action *TransactionCreateAction) loadResource() {
 			},
 		}
 	case *txsub.MalformedTransactionError:
-		action.Err = &problem.P{
-			Type:   "transaction_malformed",
-			Title:  "Transaction Malformed",
-			Status: http.StatusBadRequest,
-			Detail: "Horizon could not decode the transaction envelope in this " +
-				"request. A transaction should be an XDR TransactionEnvelope struct " +
-				"encoded using base64.  The envelope read from this request is " +
-				"echoed in the `extras.envelope_xdr` field of this response for your " +
-				"convenience.",
-			Extras: map[string]interface{}{
-				"envelope_xdr": err.EnvelopeXDR,
-			},
-		}
+		action.Err = transactionMalformedProblem(err.EnvelopeXDR)
 	default:
 		action.Err = err
 	}
 }
+
+// Interface verification
+var _ actions.JSONer = (*TransactionStatusAction)(nil)
+
+// TransactionStatusAction renders the status of a transaction submitted to
+// this server, found by its hash: pending while stellar-core has not
+// included it in a ledger, success or failed once it has, and dropped when
+// it is not expected to be included anymore.
+type TransactionStatusAction struct {
+	Action
+	Hash      string
+	Status    txsub.TransactionStatus
+	Result    txsub.Result
+	FeeReport *txsub.FeeReport
+	Resource  horizon.TransactionStatus
+}
+
+// JSON is a method for actions.JSON
+func (action *TransactionStatusAction) JSON() error {
+	action.Do(
+		action.loadParams,
+		action.loadStatus,
+		action.loadResource,
+		func() { hal.Render(action.W, action.Resource) },
+	)
+	return action.Err
+}
+
+func (action *TransactionStatusAction) loadParams() {
+	action.Hash = action.GetString("tx_id")
+	if action.Err != nil {
+		return
+	}
+
+	if _, err := hex.DecodeString(action.Hash); err != nil || len(action.Hash) != 64 {
+		action.SetInvalidField("tx_id", errors.New("must be a transaction hash of 64 hex characters"))
+	}
+}
+
+func (action *TransactionStatusAction) loadStatus() {
+	action.Status, action.Result, action.Err = action.App.submitter.Status(action.R.Context(), action.Hash)
+	if action.Err != nil {
+		return
+	}
+
+	if report, ok := action.App.submitter.FeeEscalation.Report(action.Hash); ok {
+		action.FeeReport = &report
+	}
+}
+
+func (action *TransactionStatusAction) loadResource() {
+	resourceadapter.PopulateTransactionStatus(action.R.Context(), &action.Resource, action.Status, action.Result)
+
+	if action.FeeReport != nil {
+		action.Resource.FeeReport = &horizon.TransactionFeeReport{}
+		resourceadapter.PopulateTransactionFeeReport(action.R.Context(), action.Resource.FeeReport, *action.FeeReport)
+	}
+}
+
+// transactionMalformedProblem returns the problem rendered when the envelope
+// `env` of a request cannot be decoded.
+func transactionMalformedProblem(env string) *problem.P {
+	return &problem.P{
+		Type:   "transaction_malformed",
+		Title:  "Transaction Malformed",
+		Status: http.StatusBadRequest,
+		Detail: "Horizon could not decode the transaction envelope in this " +
+			"request. A transaction should be an XDR TransactionEnvelope struct " +
+			"encoded using base64.  The envelope read from this request is " +
+			"echoed in the `extras.envelope_xdr` field of this response for your " +
+			"convenience.",
+		Extras: map[string]interface{}{
+			"envelope_xdr": env,
+		},
+	}
+}
+
+// Interface verification
+var _ actions.JSONer = (*TransactionSimulateAction)(nil)
+
+// TransactionSimulateAction predicts the result of a transaction against the
+// current state of the ledger, as recorded by stellar-core, without submitting
+// it.
+type TransactionSimulateAction struct {
+	Action
+	TX       string
+	Envelope xdr.TransactionEnvelope
+	Result   txsim.Result
+	Resource horizon.TransactionSimulation
+}
+
+// JSON format action handler
+func (action *TransactionSimulateAction) JSON() error {
+	action.Do(
+		action.loadTX,
+		action.loadResult,
+		action.loadResource,
+		func() { hal.Render(action.W, action.Resource) },
+	)
+	return action.Err
+}
+
+func (action *TransactionSimulateAction) loadTX() {
+	action.ValidateBodyType()
+	action.TX = action.GetString("tx")
+	if action.Err != nil {
+		return
+	}
+
+	if err := xdr.SafeUnmarshalBase64(action.TX, &action.Envelope); err != nil {
+		action.Err = transactionMalformedProblem(action.TX)
+	}
+}
+
+func (action *TransactionSimulateAction) loadResult() {
+	simulator := &txsim.Simulator{
+		State:             &txsim.CoreState{Q: action.CoreQ()},
+		NetworkPassphrase: action.App.config.NetworkPassphrase,
+	}
+
+	action.Result, action.Err = simulator.Simulate(action.Envelope)
+}
+
+func (action *TransactionSimulateAction) loadResource() {
+	action.Err = resourceadapter.PopulateTransactionSimulation(action.R.Context(), &action.Resource, action.Result)
+}
@@ -173,6 +173,63 @@ func TestTransactionActions_Index(t *testing.T) {
 
 }
 
+func TestTransactionActions_IndexMemo(t *testing.T) {
+	ht := StartHTTPTest(t, "kahuna")
+	defer ht.Finish()
+
+	w := ht.Get("/accounts/GA46VRKBCLI2X6DXLX7AIEVRFLH3UA7XBE3NGNP6O74HQ5LXHMGTV2JB/transactions?memo_type=id&memo=123")
+	if ht.Assert.Equal(200, w.Code) {
+		records := []horizon.Transaction{}
+		ht.UnmarshalPage(w.Body, &records)
+		if ht.Assert.Len(records, 1) {
+			ht.Assert.Equal("dd74eee27a59843b28a05ad08abf65eaa231b7debe4d05550c0a7a424cca5929", records[0].Hash)
+			ht.Assert.Equal("123", records[0].Memo)
+		}
+	}
+
+	// id memos are matched by value
+	w = ht.Get("/transactions?memo_type=id&memo=0123")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/transactions?memo=hello")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/transactions?memo_type=text&memo=123")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(0, w.Body)
+	}
+
+	// hash memos are accepted in base64 and hex
+	w = ht.Get("/transactions?memo_type=hash&memo=" + url.QueryEscape("AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE="))
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/transactions?memo_type=hash&memo=0101010101010101010101010101010101010101010101010101010101010101")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	w = ht.Get("/transactions?memo_type=id")
+	if ht.Assert.Equal(200, w.Code) {
+		ht.Assert.PageOf(1, w.Body)
+	}
+
+	// invalid filters
+	w = ht.Get("/transactions?memo_type=none&memo=hello")
+	ht.Assert.Equal(400, w.Code)
+	w = ht.Get("/transactions?memo_type=id&memo=hello")
+	ht.Assert.Equal(400, w.Code)
+	w = ht.Get("/transactions?memo_type=hash&memo=hello")
+	ht.Assert.Equal(400, w.Code)
+	w = ht.Get("/transactions?memo_type=unknown")
+	ht.Assert.Equal(400, w.Code)
+}
+
 func TestTransactionActions_Post(t *testing.T) {
 	ht := StartHTTPTest(t, "base")
 	defer ht.Finish()
@@ -193,6 +250,28 @@ func TestTransactionActions_Post(t *testing.T) {
 	ht.Assert.Equal(503, w.Code)
 }
 
+func TestTransactionActions_Simulate(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	// already included in a ledger: its sequence number is used
+	form := url.Values{"tx": []string{"AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAAZAAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAECDzqvkQBQoNAJifPRXDoLhvtycT3lFPCQ51gkdsFHaBNWw05S/VhW0Xgkr0CBPE4NaFV2Kmcs3ZwLmib4TRrML"}}
+
+	w := ht.Post("/transactions/simulate", form)
+	if ht.Assert.Equal(200, w.Code) {
+		var result horizon.TransactionSimulation
+		err := json.Unmarshal(w.Body.Bytes(), &result)
+		ht.Require.NoError(err)
+		ht.Assert.False(result.Successful)
+		ht.Assert.Equal("tx_bad_seq", result.ResultCodes.TransactionCode)
+		ht.Assert.Empty(result.ResultCodes.OperationCodes)
+	}
+
+	w = ht.Post("/transactions/simulate", url.Values{"tx": []string{"not_an_envelope"}})
+	ht.Assert.Equal(400, w.Code)
+	ht.Assert.Contains(w.Body.String(), "transaction_malformed")
+}
+
 func TestTransactionActions_PostSuccessful(t *testing.T) {
 	ht := StartHTTPTest(t, "failed_transactions")
 	defer ht.Finish()
@@ -217,3 +296,70 @@ func TestTransactionActions_PostFailed(t *testing.T) {
 	ht.Assert.Contains(string(w.Body.Bytes()), "op_underfunded")
 	ht.Assert.Contains(string(w.Body.Bytes()), `"result_xdr": "AAAAAAAAAGT/////AAAAAQAAAAAAAAAB/////gAAAAA="`)
 }
+
+func TestTransactionActions_PostAsync(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	form := url.Values{
+		"tx":    []string{"AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAAZAAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAECDzqvkQBQoNAJifPRXDoLhvtycT3lFPCQ51gkdsFHaBNWw05S/VhW0Xgkr0CBPE4NaFV2Kmcs3ZwLmib4TRrML"},
+		"async": []string{"true"},
+	}
+
+	// existing transaction
+	w := ht.Post("/transactions", form)
+	ht.Assert.Equal(200, w.Code)
+
+	// accepted by stellar-core
+	address := "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	sequences := &txsub.MockSequenceProvider{}
+	sequences.On("Get", []string{address}).Return(map[string]uint64{address: 0}, nil)
+	ht.App.submitter.Results = &txsub.MockResultProvider{}
+	ht.App.submitter.Sequences = sequences
+	ht.App.submitter.Submitter = &txsub.MockSubmitter{}
+	w = ht.Post("/transactions", form)
+	if ht.Assert.Equal(202, w.Code) {
+		var actual horizon.TransactionStatus
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal("2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d", actual.Hash)
+		ht.Assert.Equal("pending", actual.Status)
+		ht.Assert.Equal(int32(0), actual.Ledger)
+	}
+
+	w = ht.Get("/transactions/2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d/status")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual horizon.TransactionStatus
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal("pending", actual.Status)
+	}
+}
+
+func TestTransactionActions_Status(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+
+	w := ht.Get("/transactions/2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d/status")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual horizon.TransactionStatus
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal("success", actual.Status)
+		ht.Assert.Equal(int32(2), actual.Ledger)
+		ht.Assert.NotEmpty(actual.Result)
+	}
+
+	// unknown transaction
+	w = ht.Get("/transactions/0000000000000000000000000000000000000000000000000000000000000000/status")
+	if ht.Assert.Equal(200, w.Code) {
+		var actual horizon.TransactionStatus
+		err := json.Unmarshal(w.Body.Bytes(), &actual)
+		ht.Require.NoError(err)
+		ht.Assert.Equal("dropped", actual.Status)
+	}
+
+	// malformed hash
+	w = ht.Get("/transactions/not_real/status")
+	ht.Assert.Equal(400, w.Code)
+}
@@ -0,0 +1,77 @@
+package horizon
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/pprof"
+	"time"
+
+	"github.com/cowry-network/go/support/log"
+	"github.com/go-chi/chi"
+	chimiddleware "github.com/go-chi/chi/middleware"
+)
+
+// initAdmin installs the router of the admin server onto the provided app,
+// provided that an admin port is configured.  The admin server exposes
+// metrics, profiling data and runtime controls that must not be reachable by
+// the public, which is why it is served on its own listener rather than
+// through the main router.
+func initAdmin(app *App) {
+	if app.config.AdminPort == 0 {
+		return
+	}
+
+	r := chi.NewRouter()
+	r.Use(chimiddleware.StripSlashes)
+	r.Use(app.middleware)
+	r.Use(chimiddleware.RequestID)
+	r.Use(contextMiddleware)
+	r.Use(loggerMiddleware)
+	r.Use(recoverMiddleware)
+
+	r.Get("/metrics", MetricsAction{}.Handle)
+	r.Get("/ledger_state", AdminLedgerStateAction{}.Handle)
+	r.Post("/reap", AdminReapAction{}.Handle)
+
+	r.Route("/ingestion", func(r chi.Router) {
+		r.Get("/", AdminIngestionStatusAction{}.Handle)
+		r.Post("/pause", AdminIngestionPauseAction{}.Handle)
+		r.Post("/resume", AdminIngestionResumeAction{}.Handle)
+	})
+
+	r.Route("/debug/pprof", func(r chi.Router) {
+		r.Get("/", pprof.Index)
+		r.Get("/cmdline", pprof.Cmdline)
+		r.Get("/profile", pprof.Profile)
+		r.Get("/symbol", pprof.Symbol)
+		r.Post("/symbol", pprof.Symbol)
+		r.Get("/trace", pprof.Trace)
+		// heap, goroutine, block, etc.
+		r.Get("/{profile}", pprof.Index)
+	})
+
+	app.admin = r
+}
+
+// serveAdmin runs the admin server until the app is closed.
+func (a *App) serveAdmin() {
+	addr := fmt.Sprintf("%s:%d", a.config.AdminHost, a.config.AdminPort)
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           a.admin,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	go func() {
+		<-a.ctx.Done()
+		srv.Close()
+	}()
+
+	log.Infof("Starting admin server on %s", addr)
+
+	err := srv.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		log.WithField("err", err.Error()).Error("admin server failed")
+	}
+}
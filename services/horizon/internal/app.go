@@ -8,8 +8,10 @@ import (
 	"os"
 	"runtime"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/go-chi/chi"
 	"github.com/gomodule/redigo/redis"
 	metrics "github.com/rcrowley/go-metrics"
 	"github.com/cowry-network/go/clients/stellarcore"
@@ -20,9 +22,9 @@ import (
 	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/logmetrics"
 	"github.com/cowry-network/go/services/horizon/internal/operationfeestats"
+	"github.com/cowry-network/go/services/horizon/internal/orderbook"
 	"github.com/cowry-network/go/services/horizon/internal/paths"
 	"github.com/cowry-network/go/services/horizon/internal/reap"
-	"github.com/cowry-network/go/services/horizon/internal/simplepath"
 	"github.com/cowry-network/go/services/horizon/internal/txsub"
 	"github.com/cowry-network/go/support/app"
 	"github.com/cowry-network/go/support/db"
@@ -37,7 +39,10 @@ import (
 type App struct {
 	config                       Config
 	web                          *Web
+	admin                        *chi.Mux
 	historyQ                     *history.Q
+	historyReplicas              []*historyReplica
+	nextHistoryReplica           uint32
 	coreQ                        *core.Q
 	ctx                          context.Context
 	cancel                       func()
@@ -48,7 +53,9 @@ type App struct {
 	coreSupportedProtocolVersion int32
 	submitter                    *txsub.System
 	paths                        paths.Finder
+	orderBookGraph               *orderbook.OrderBookGraph
 	ingester                     *ingest.System
+	ledgerEvents                 *ledger.Bus
 	reaper                       *reap.System
 	ticks                        *time.Ticker
 
@@ -62,6 +69,14 @@ type App struct {
 	goroutineGauge           metrics.Gauge
 }
 
+// historyReplica is a read-only replica of the horizon database.
+type historyReplica struct {
+	q *history.Q
+
+	// latest is the latest ledger in the replica, it is 0 when unknown.
+	latest int32
+}
+
 // NewApp constructs an new App instance from the provided config.
 func NewApp(config Config) *App {
 	a := &App{
@@ -77,8 +92,6 @@ func NewApp(config Config) *App {
 // Serve starts the horizon web server, binding it to a socket, setting up
 // the shutdown signals.
 func (a *App) Serve() {
-	http.Handle("/", a.web.router)
-
 	addr := fmt.Sprintf(":%d", a.config.Port)
 
 	srv := &graceful.Server{
@@ -86,7 +99,7 @@ func (a *App) Serve() {
 
 		Server: &http.Server{
 			Addr:              addr,
-			Handler:           http.DefaultServeMux,
+			Handler:           a.web.router,
 			ReadHeaderTimeout: 5 * time.Second,
 		},
 
@@ -102,6 +115,10 @@ func (a *App) Serve() {
 
 	go a.run()
 
+	if a.admin != nil {
+		go a.serveAdmin()
+	}
+
 	var err error
 	if a.config.TLSCert != "" {
 		err = srv.ListenAndServeTLS(a.config.TLSCert, a.config.TLSKey)
@@ -129,6 +146,9 @@ func (a *App) Close() {
 // closed" errors.
 func (a *App) CloseDB() {
 	a.historyQ.Session.DB.Close()
+	for _, replica := range a.historyReplicas {
+		replica.q.Session.DB.Close()
+	}
 	a.coreQ.Session.DB.Close()
 }
 
@@ -144,6 +164,37 @@ func (a *App) HorizonSession(ctx context.Context) *db.Session {
 	return &db.Session{DB: a.historyQ.Session.DB, Ctx: ctx}
 }
 
+// HorizonReadSession returns a new session that loads data from one of the
+// read-only replicas of the horizon database, or from the horizon database
+// itself if every replica lags more than `StaleThreshold` ledgers behind it.
+// The returned session is bound to `ctx`.
+func (a *App) HorizonReadSession(ctx context.Context) *db.Session {
+	return a.HorizonReadSessionAt(ctx, 0)
+}
+
+// HorizonReadSessionAt is like HorizonReadSession but only loads data from a
+// replica whose latest ledger is at least `seq`, so that the data of ledger
+// `seq` is visible to the session.
+func (a *App) HorizonReadSessionAt(ctx context.Context, seq int32) *db.Session {
+	n := uint32(len(a.historyReplicas))
+	if n == 0 {
+		return a.HorizonSession(ctx)
+	}
+
+	latest := ledger.CurrentState().HistoryLatest
+	next := atomic.AddUint32(&a.nextHistoryReplica, 1)
+	for i := uint32(0); i < n; i++ {
+		replica := a.historyReplicas[(next+i)%n]
+		replicaLatest := atomic.LoadInt32(&replica.latest)
+		if replicaLatest > 0 && replicaLatest >= seq &&
+			latest-replicaLatest <= int32(a.config.StaleThreshold) {
+			return &db.Session{DB: replica.q.Session.DB, Ctx: ctx}
+		}
+	}
+
+	return a.HorizonSession(ctx)
+}
+
 // CoreSession returns a new session that loads data from the stellar core
 // database. The returned session is bound to `ctx`.
 func (a *App) CoreSession(ctx context.Context) *db.Session {
@@ -197,6 +248,22 @@ func (a *App) UpdateLedgerState() {
 	ledger.SetState(next)
 }
 
+// UpdateHistoryReplicaState refreshes the latest ledger of each read-only
+// replica of the horizon database.  Replicas whose latest ledger cannot be
+// loaded are not read from until it can.
+func (a *App) UpdateHistoryReplicaState() {
+	for _, replica := range a.historyReplicas {
+		var latest int32
+		err := replica.q.LatestLedger(&latest)
+		if err != nil {
+			log.WithStack(err).WithField("err", err.Error()).Error("failed to load the latest ledger from history DB replica")
+			latest = 0
+		}
+
+		atomic.StoreInt32(&replica.latest, latest)
+	}
+}
+
 // UpdateOperationFeeStatsState triggers a refresh of several operation fee metrics.
 func (a *App) UpdateOperationFeeStatsState() {
 	var (
@@ -280,6 +347,25 @@ func (a *App) UpdateOperationFeeStatsState() {
 	operationfeestats.SetState(next)
 }
 
+// UpdateOrderBookGraph refreshes the in-memory order book graph used for path
+// finding if stellar-core closed a new ledger since the last refresh. It is a
+// no-op unless the orderbook path finder is enabled.
+func (a *App) UpdateOrderBookGraph() {
+	if a.orderBookGraph == nil {
+		return
+	}
+
+	coreLatest := ledger.CurrentState().CoreLatest
+	if coreLatest == a.orderBookGraph.LastLedger() {
+		return
+	}
+
+	err := a.orderBookGraph.Refresh(a.CoreQ(), coreLatest)
+	if err != nil {
+		log.WithStack(err).WithField("err", err.Error()).Error("failed to refresh the order book graph")
+	}
+}
+
 // UpdateStellarCoreInfo updates the value of coreVersion,
 // currentProtocolVersion, and coreSupportedProtocolVersion from the Stellar
 // core API.
@@ -339,8 +425,9 @@ func (a *App) Tick() {
 	var wg sync.WaitGroup
 	log.Debug("ticking app")
 	// update ledger state, operation fee state, and stellar-core info in parallel
-	wg.Add(3)
+	wg.Add(4)
 	go func() { a.UpdateLedgerState(); wg.Done() }()
+	go func() { a.UpdateHistoryReplicaState(); wg.Done() }()
 	go func() { a.UpdateOperationFeeStatsState(); wg.Done() }()
 	go func() { a.UpdateStellarCoreInfo(); wg.Done() }()
 	wg.Wait()
@@ -349,9 +436,10 @@ func (a *App) Tick() {
 		go a.ingester.Tick()
 	}
 
-	wg.Add(2)
+	wg.Add(3)
 	go func() { a.reaper.Tick(); wg.Done() }()
 	go func() { a.submitter.Tick(a.ctx); wg.Done() }()
+	go func() { a.UpdateOrderBookGraph(); wg.Done() }()
 	wg.Wait()
 
 	// finally, update metrics
@@ -389,7 +477,7 @@ func (a *App) init() {
 	initSubmissionSystem(a)
 
 	// path-finder
-	a.paths = &simplepath.Finder{a.CoreQ()}
+	initPathFinder(a)
 
 	// reaper
 	a.reaper = reap.New(a.config.HistoryRetentionCount, a.HorizonSession(nil))
@@ -397,6 +485,9 @@ func (a *App) init() {
 	// web.init
 	initWeb(a)
 
+	// redis
+	initRedis(a)
+
 	// web.rate-limiter
 	initWebRateLimiter(a)
 
@@ -406,6 +497,9 @@ func (a *App) init() {
 	// web.actions
 	initWebActions(a)
 
+	// admin
+	initAdmin(a)
+
 	// metrics and log.metrics
 	a.metrics = metrics.NewRegistry()
 	for level, meter := range *logmetrics.DefaultMetrics {
@@ -423,18 +517,22 @@ func (a *App) init() {
 
 	// ingester.metrics
 	initIngesterMetrics(a)
-
-	// redis
-	initRedis(a)
 }
 
 // run is the function that runs in the background that triggers Tick each
-// second
+// second, and refreshes the state depending on the history database as soon as
+// the ingester commits a ledger.
 func (a *App) run() {
+	events := a.ledgerEvents.Subscribe()
+	defer events.Close()
+
 	for {
 		select {
 		case <-a.ticks.C:
 			a.Tick()
+		case <-events.C:
+			a.UpdateLedgerState()
+			a.submitter.Tick(a.ctx)
 		case <-a.ctx.Done():
 			log.Info("finished background ticker")
 			return
@@ -447,9 +545,10 @@ func (a *App) Context(ctx context.Context) context.Context {
 	return context.WithValue(ctx, &horizonContext.AppContextKey, a)
 }
 
-// GetRateLimiter returns the HTTPRateLimiter of the App.
-func (a *App) GetRateLimiter() *throttled.HTTPRateLimiter {
-	return a.web.rateLimiter
+// GetRateLimiter returns the HTTPRateLimiter of the App that applies to `r`, or
+// nil if `r` is not rate limited.
+func (a *App) GetRateLimiter(r *http.Request) *throttled.HTTPRateLimiter {
+	return a.web.rateLimiterFor(r)
 }
 
 // AppFromContext returns the set app, if one has been set, from the
@@ -4,6 +4,7 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/test"
 )
 
@@ -66,3 +67,39 @@ func TestMetrics(t *testing.T) {
 	ht.Require.EqualValues(1, he.Value())
 	ht.Require.EqualValues(3, cl.Value())
 }
+
+func TestHorizonReadSession(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+
+	config := NewTestConfig()
+	config.HistoryReplicaURLs = []string{test.DatabaseURL()}
+	config.StaleThreshold = 1
+	app := NewApp(config)
+	defer app.Close()
+	defer app.CloseDB()
+
+	primary := app.historyQ.Session.DB
+	replica := app.historyReplicas[0].q.Session.DB
+
+	// replicas are not read from until their latest ledger is known
+	app.UpdateLedgerState()
+	tt.Assert.True(app.HorizonReadSession(nil).DB == primary)
+
+	app.UpdateHistoryReplicaState()
+	tt.Assert.Equal(int32(3), app.historyReplicas[0].latest)
+	tt.Assert.True(app.HorizonReadSession(nil).DB == replica)
+
+	// a lagging replica is not read from
+	ledger.SetState(ledger.State{CoreLatest: 5, HistoryLatest: 5, HistoryElder: 1})
+	tt.Assert.True(app.HorizonReadSession(nil).DB == primary)
+	ledger.SetState(ledger.State{CoreLatest: 4, HistoryLatest: 4, HistoryElder: 1})
+	tt.Assert.True(app.HorizonReadSession(nil).DB == replica)
+
+	// streams woken up by a ledger read it from a replica that ingested it
+	tt.Assert.True(app.HorizonReadSessionAt(nil, 3).DB == replica)
+	tt.Assert.True(app.HorizonReadSessionAt(nil, 4).DB == primary)
+
+	// writes always go to the primary
+	tt.Assert.True(app.HorizonSession(nil).DB == primary)
+}
@@ -4,28 +4,47 @@ import (
 	"net/url"
 	"time"
 
+	"github.com/cowry-network/go/keypair"
+	"github.com/cowry-network/go/services/horizon/internal/ratelimit"
 	"github.com/sirupsen/logrus"
 	"github.com/throttled/throttled"
 )
 
+const (
+	// PathFinderSimple is the path finder querying stellar-core's database
+	// for every hop of a path (see simplepath package).
+	PathFinderSimple = "simple"
+	// PathFinderOrderBook is the path finder keeping an in-memory graph of
+	// the order book, refreshed on every ledger close (see orderbook package).
+	PathFinderOrderBook = "orderbook"
+)
+
 // Config is the configuration for horizon.  It gets populated by the
 // app's main function and is provided to NewApp.
 type Config struct {
 	DatabaseURL            string
+	HistoryReplicaURLs     []string
 	StellarCoreDatabaseURL string
 	StellarCoreURL         string
 	Port                   uint
+	AdminPort              uint
+	AdminHost              string
 	MaxDBConnections       int
 	SSEUpdateFrequency     time.Duration
 	ConnectionTimeout      time.Duration
 	RateLimit              *throttled.RateQuota
+	RateLimitRules         []ratelimit.Rule
+	RateLimitAllowlist     []string
 	RateLimitRedisKey      string
 	RedisURL               string
 	FriendbotURL           *url.URL
 	LogLevel               logrus.Level
 	LogFile                string
 	// MaxPathLength is the maximum length of the path returned by `/paths` endpoint.
-	MaxPathLength     uint
+	MaxPathLength uint
+	// PathFinder selects the path finding engine used by `/paths` endpoint, see
+	// PathFinderSimple and PathFinderOrderBook.
+	PathFinder        string
 	NetworkPassphrase string
 	SentryDSN         string
 	LogglyToken       string
@@ -38,6 +57,9 @@ type Config struct {
 	Ingest bool
 	// IngestFailedTransactions toggles whether to ingest failed transactions
 	IngestFailedTransactions bool
+	// HistoryArchiveURL is the url of a history archive to ingest ledgers from
+	// instead of the stellar-core database, see ingest.ArchiveLedgerSource.
+	HistoryArchiveURL string
 	// HistoryRetentionCount represents the minimum number of ledgers worth of
 	// history data to retain in the horizon database. For the purposes of
 	// determining a "retention duration", each ledger roughly corresponds to 10
@@ -45,7 +67,9 @@ type Config struct {
 	HistoryRetentionCount uint
 	// StaleThreshold represents the number of ledgers a history database may be
 	// out-of-date by before horizon begins to respond with an error to history
-	// requests.
+	// requests.  It is also the number of ledgers a replica of the history
+	// database may lag behind it before history requests stop being served
+	// from that replica.
 	StaleThreshold uint
 	// SkipCursorUpdate causes the ingestor to skip reporting the "last imported
 	// ledger" state to stellar-core.
@@ -55,4 +79,16 @@ type Config struct {
 	// Enabling it has a negative impact on CPU when ingesting ledgers full of
 	// many different assets related operations.
 	EnableAssetStats bool
+	// TxSubFeeSource is the key of the fee source account that enables the
+	// monitoring of the fees of pending transaction submissions, and the
+	// rebroadcast of the stuck ones sent from that account, see
+	// txsub.FeeEscalation.
+	TxSubFeeSource *keypair.Full
+	// TxSubMaxOperationFee caps the fee per operation of the transactions
+	// rebroadcast with a higher fee.
+	TxSubMaxOperationFee uint
+	// TxSubSharedSubmissions shares the open transaction submissions with the
+	// other horizon servers using the same database, so that a transaction
+	// submitted to several of them is submitted to stellar-core once.
+	TxSubSharedSubmissions bool
 }
@@ -7,14 +7,17 @@ import (
 
 // AssetStatsR is the result from the AssetStatsQ query
 type AssetStatsR struct {
-	SortKey     string `db:"sort_key"`
-	Type        string `db:"asset_type"`
-	Code        string `db:"asset_code"`
-	Issuer      string `db:"asset_issuer"`
-	Amount      string `db:"amount"`
-	NumAccounts int32  `db:"num_accounts"`
-	Flags       int8   `db:"flags"`
-	Toml        string `db:"toml"`
+	SortKey                 string `db:"sort_key"`
+	Type                    string `db:"asset_type"`
+	Code                    string `db:"asset_code"`
+	Issuer                  string `db:"asset_issuer"`
+	Amount                  string `db:"amount"`
+	NumAccounts             int32  `db:"num_accounts"`
+	NumUnauthorizedAccounts int32  `db:"num_unauthorized_accounts"`
+	Flags                   int8   `db:"flags"`
+	Toml                    string `db:"toml"`
+	BidDepth                string `db:"bid_depth"`
+	AskDepth                string `db:"ask_depth"`
 }
 
 // PagingToken implementation for hal.Pageable
@@ -65,8 +68,11 @@ var selectQuery = sq.
 		"hist.asset_issuer",
 		"stats.amount",
 		"stats.num_accounts",
+		"stats.num_unauthorized_accounts",
 		"stats.flags",
 		"stats.toml",
+		"stats.bid_depth",
+		"stats.ask_depth",
 	).
 	From("history_assets hist").
 	Join("asset_stats stats ON hist.id = stats.id")
@@ -19,6 +19,8 @@ func TestAssetsStatsQExec(t *testing.T) {
 		NumAccounts: 1,
 		Flags:       1,
 		Toml:        "https://test.com/.well-known/stellar.toml",
+		BidDepth:    "0",
+		AskDepth:    "0",
 	}
 
 	item1 := AssetStatsR{
@@ -30,6 +32,8 @@ func TestAssetsStatsQExec(t *testing.T) {
 		NumAccounts: 1,
 		Flags:       2,
 		Toml:        "",
+		BidDepth:    "0",
+		AskDepth:    "0",
 	}
 
 	item2 := AssetStatsR{
@@ -41,6 +45,8 @@ func TestAssetsStatsQExec(t *testing.T) {
 		NumAccounts: 2,
 		Flags:       1,
 		Toml:        "https://test.com/.well-known/stellar.toml",
+		BidDepth:    "0",
+		AskDepth:    "0",
 	}
 
 	testCases := []struct {
@@ -4,6 +4,8 @@ import (
 	"encoding/base64"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/support/errors"
 	"github.com/cowry-network/go/xdr"
 )
@@ -51,6 +53,178 @@ func (q *Q) AccountByAddress(dest *Account, addy string) error {
 	return nil
 }
 
+// maxSignerScan is the maximum number of accounts whose signers are decoded
+// by a single query of accounts filtered by signer, since schema version 9.
+const maxSignerScan = 1000
+
+// signerScanBatch is the number of accounts whose signers are decoded at once
+// when filtering accounts by signer, since schema version 9.
+const signerScanBatch = 200
+
+// Accounts loads a page of accounts matching `query`, ordered by account id.
+// It returns the cursor of the next page, which is past the accounts loaded
+// when filtering by signer stopped after examining maxSignerScan accounts.
+func (q *Q) Accounts(dest *[]Account, query AccountsQuery) (string, error) {
+	schemaVersion, err := q.SchemaVersion()
+	if err != nil {
+		return "", err
+	}
+
+	pq := query.PageQuery
+	sql := selectAccount
+
+	// every account can hold the native asset
+	if query.Asset != nil && query.Asset.Type != xdr.AssetTypeAssetTypeNative {
+		filter, err := assetFilter("tl.", *query.Asset)
+		if err != nil {
+			return "", err
+		}
+
+		sub, args, err := sq.Select("tl.accountid").From("trustlines tl").Where(filter).ToSql()
+		if err != nil {
+			return "", err
+		}
+		sql = sql.Where("a.accountid IN ("+sub+")", args...)
+	}
+
+	if query.Signer != "" && schemaVersion >= 9 {
+		// Since schema version 9 signers are stored as XDR in the accounts
+		// table, so they can't be filtered in SQL.
+		return q.accountsBySigner(dest, sql, query.Signer, pq)
+	}
+
+	if query.Signer != "" {
+		sql = sql.Where(sq.Or{
+			sq.Eq{"a.accountid": query.Signer},
+			sq.Expr(
+				"a.accountid IN (SELECT si.accountid FROM signers si WHERE si.publickey = ?)",
+				query.Signer,
+			),
+		})
+	}
+
+	sql = accountsPage(sql, pq).Limit(pq.Limit)
+	err = q.Select(dest, sql)
+	if err != nil {
+		return "", err
+	}
+
+	cursor := pq.Cursor
+	if len(*dest) > 0 {
+		cursor = (*dest)[len(*dest)-1].Accountid
+	}
+
+	return cursor, decodeHomeDomains(*dest, schemaVersion)
+}
+
+// accountsBySigner loads a page of accounts matching `sql` that `signer` can
+// sign for, decoding the signers of candidate accounts in batches. It stops
+// once the page is full or maxSignerScan accounts have been examined, and
+// returns the id of the last account examined as the cursor of the next page.
+// Only used since schema version 9.
+func (q *Q) accountsBySigner(
+	dest *[]Account,
+	sql sq.SelectBuilder,
+	signer string,
+	pq db2.PageQuery,
+) (string, error) {
+	sql = sql.Column("a.signers").Where(sq.Or{
+		sq.Eq{"a.accountid": signer},
+		sq.NotEq{"a.signers": nil},
+	})
+
+	results := []Account{}
+	cursor := pq.Cursor
+	scanned := 0
+
+scan:
+	for scanned < maxSignerScan {
+		var rows []struct {
+			Account
+			Signers null.String `db:"signers"`
+		}
+
+		batch := signerScanBatch
+		if maxSignerScan-scanned < batch {
+			batch = maxSignerScan - scanned
+		}
+
+		pq.Cursor = cursor
+		err := q.Select(&rows, accountsPage(sql, pq).Limit(uint64(batch)))
+		if err != nil {
+			return "", err
+		}
+
+		for _, row := range rows {
+			scanned++
+			cursor = row.Accountid
+			found := row.Accountid == signer
+
+			if !found && row.Signers.Valid {
+				var signersXDR []xdr.Signer
+				err = xdr.SafeUnmarshalBase64(row.Signers.String, &signersXDR)
+				if err != nil {
+					return "", errors.Wrap(err, "Error decoding []xdr.Signer")
+				}
+
+				for _, s := range signersXDR {
+					if s.Key.Address() == signer {
+						found = true
+						break
+					}
+				}
+			}
+
+			if found {
+				results = append(results, row.Account)
+				if uint64(len(results)) == pq.Limit {
+					break scan
+				}
+			}
+		}
+
+		if len(rows) < batch {
+			break
+		}
+	}
+
+	*dest = results
+	return cursor, decodeHomeDomains(results, 9)
+}
+
+// accountsPage applies the cursor and order of `pq` to `sql`, using account
+// ids as cursors.
+func accountsPage(sql sq.SelectBuilder, pq db2.PageQuery) sq.SelectBuilder {
+	switch pq.Order {
+	case "asc":
+		sql = sql.Where("a.accountid > ?", pq.Cursor).OrderBy("a.accountid asc")
+	case "desc":
+		if pq.Cursor != "" {
+			sql = sql.Where("a.accountid < ?", pq.Cursor)
+		}
+		sql = sql.OrderBy("a.accountid desc")
+	}
+	return sql
+}
+
+// decodeHomeDomains decodes the home domains of `accounts` in place, since
+// schema version 9 they are base64 encoded.
+func decodeHomeDomains(accounts []Account, schemaVersion int) error {
+	if schemaVersion < 9 {
+		return nil
+	}
+
+	for i := range accounts {
+		decoded, err := base64.StdEncoding.DecodeString(accounts[i].HomeDomain.String)
+		if err != nil {
+			return errors.Wrap(err, "Unable to base64 decode HomeDomain")
+		}
+		accounts[i].HomeDomain.String = string(decoded)
+	}
+
+	return nil
+}
+
 // SequencesForAddresses loads the current sequence number for every accountid
 // specified in `addys`
 func (q *Q) SequencesForAddresses(dest interface{}, addys []string) error {
@@ -74,6 +74,33 @@ func (q *Q) AllDataByAddress(dest interface{}, addy string) error {
 	return nil
 }
 
+// AllDataByAddresses loads all data for every account in `addys`
+func (q *Q) AllDataByAddresses(dest *[]AccountData, addys []string) error {
+	schemaVersion, err := q.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	sql := selectAccountData.Where(sq.Eq{"accountid": addys})
+	err = q.Select(dest, sql)
+	if err != nil {
+		return err
+	}
+
+	if schemaVersion >= 9 {
+		// Since schema version 9, keys are base64 encoded.
+		for i, val := range *dest {
+			decoded, err := base64.StdEncoding.DecodeString(val.Key)
+			if err != nil {
+				return errors.Wrap(err, fmt.Sprintf("Error decoding data entry: %s", val.Key))
+			}
+			(*dest)[i].Key = string(decoded)
+		}
+	}
+
+	return nil
+}
+
 var selectAccountData = sq.Select(
 	"ad.accountid",
 	"ad.dataname",
@@ -0,0 +1,106 @@
+package core
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/services/horizon/internal/db2"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/cowry-network/go/xdr"
+)
+
+func TestAccounts(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	q := &Q{tt.CoreSession()}
+
+	var accounts []Account
+
+	load := func(query AccountsQuery) bool {
+		accounts = []Account{}
+		_, err := q.Accounts(&accounts, query)
+		return tt.Assert.NoError(err)
+	}
+
+	pq := db2.MustPageQuery("", false, "asc", db2.DefaultPageSize)
+
+	// accounts with an additional signer
+	if load(AccountsQuery{PageQuery: pq, Signer: "GD3E7HKMRNT6HGBGHBT6I6JE4N2S4W5KZ246TGJ4KQSXJ2P4BXCUPQMP"}) {
+		if tt.Assert.Len(accounts, 1) {
+			tt.Assert.Equal("GDXFAGJCSCI4CK2YHK6YRLA6TKEXFRX7BMGVMQOBMLIEUJRJ5YQNLMIB", accounts[0].Accountid)
+		}
+	}
+
+	// the master key of an account is one of its signers
+	if load(AccountsQuery{PageQuery: pq, Signer: "GAXI33UCLQTCKM2NMRBS7XYBR535LLEVAHL5YBN4FTCB4HZHT7ZA5CVK"}) {
+		if tt.Assert.Len(accounts, 1) {
+			tt.Assert.Equal("GAXI33UCLQTCKM2NMRBS7XYBR535LLEVAHL5YBN4FTCB4HZHT7ZA5CVK", accounts[0].Accountid)
+		}
+	}
+
+	usd := xdr.MustNewCreditAsset("USD", "GAXMF43TGZHW3QN3REOUA2U5PW5BTARXGGYJ3JIFHW3YT6QRKRL3CPPU")
+	if load(AccountsQuery{PageQuery: pq, Asset: &usd}) {
+		if tt.Assert.Len(accounts, 1) {
+			tt.Assert.Equal("GDRW375MAYR46ODGF2WGANQC2RRZL7O246DYHHCGWTV2RE7IHE2QUQLD", accounts[0].Accountid)
+		}
+	}
+
+	// filters are combined
+	if load(AccountsQuery{
+		PageQuery: pq,
+		Signer:    "GD3E7HKMRNT6HGBGHBT6I6JE4N2S4W5KZ246TGJ4KQSXJ2P4BXCUPQMP",
+		Asset:     &usd,
+	}) {
+		tt.Assert.Len(accounts, 0)
+	}
+
+	// every account can hold the native asset
+	native := xdr.MustNewNativeAsset()
+	pq = db2.MustPageQuery("", false, "asc", db2.MaxPageSize)
+	if load(AccountsQuery{PageQuery: pq, Asset: &native}) {
+		tt.Assert.Len(accounts, 24)
+	}
+
+	// paging
+	pq = db2.MustPageQuery("", false, "desc", 2)
+	if load(AccountsQuery{PageQuery: pq, Asset: &native}) {
+		if tt.Assert.Len(accounts, 2) {
+			tt.Assert.True(accounts[0].Accountid > accounts[1].Accountid, "Results are not in order")
+		}
+	}
+
+	pq = db2.MustPageQuery(accounts[1].Accountid, false, "desc", 2)
+	last := accounts[1].Accountid
+	if load(AccountsQuery{PageQuery: pq, Asset: &native}) {
+		if tt.Assert.Len(accounts, 2) {
+			tt.Assert.True(last > accounts[0].Accountid, "Results are not in order")
+		}
+	}
+}
+
+func TestAccounts_SchemaVersion9(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("core_database_schema_version_9")
+	defer tt.Finish()
+	q := &Q{tt.CoreSession()}
+
+	var accounts []Account
+	pq := db2.MustPageQuery("", false, "asc", db2.DefaultPageSize)
+
+	_, err := q.Accounts(&accounts, AccountsQuery{
+		PageQuery: pq,
+		Signer:    "GC7BWB2ME4LII3TVWTHUIT7KGJXU4D5M6JUNLQ57WA7JERDNSAEXLOAN",
+	})
+	if tt.Assert.NoError(err) && tt.Assert.Len(accounts, 1) {
+		tt.Assert.Equal("GDZOBPTVEECUYFCHSQ5NCEUVAV4JKRZI6KO5HFOM7HGQT22E3XIGRHNU", accounts[0].Accountid)
+		tt.Assert.Equal("lobstr.co", accounts[0].HomeDomain.String)
+	}
+
+	cursor, err := q.Accounts(&accounts, AccountsQuery{
+		PageQuery: pq,
+		Signer:    "GAXI33UCLQTCKM2NMRBS7XYBR535LLEVAHL5YBN4FTCB4HZHT7ZA5CVK",
+	})
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(accounts, 0)
+		// the next page continues after the accounts examined
+		tt.Assert.NotEqual("", cursor)
+	}
+}
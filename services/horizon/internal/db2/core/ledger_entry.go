@@ -0,0 +1,93 @@
+package core
+
+import (
+	"encoding/base64"
+	"strconv"
+
+	"github.com/cowry-network/go/xdr"
+	"github.com/guregu/null"
+)
+
+// The functions in this file build rows from raw ledger entries, such as
+// those recorded in the history database, so that they can be used in place of
+// rows loaded from stellar-core's tables.
+
+// AccountFromLedgerEntry builds an Account from an account ledger entry.
+func AccountFromLedgerEntry(entry xdr.LedgerEntry) Account {
+	ae := entry.Data.MustAccount()
+
+	account := Account{
+		Accountid:     ae.AccountId.Address(),
+		Balance:       ae.Balance,
+		Seqnum:        strconv.FormatInt(int64(ae.SeqNum), 10),
+		Numsubentries: int32(ae.NumSubEntries),
+		HomeDomain:    null.StringFrom(string(ae.HomeDomain)),
+		Thresholds:    ae.Thresholds,
+		Flags:         xdr.AccountFlags(ae.Flags),
+		LastModified:  uint32(entry.LastModifiedLedgerSeq),
+	}
+
+	if ae.InflationDest != nil {
+		account.Inflationdest = null.StringFrom(ae.InflationDest.Address())
+	}
+
+	if v1, ok := ae.Ext.GetV1(); ok {
+		account.BuyingLiabilities = v1.Liabilities.Buying
+		account.SellingLiabilities = v1.Liabilities.Selling
+	}
+
+	return account
+}
+
+// AccountDataFromLedgerEntry builds an AccountData from a data ledger entry.
+func AccountDataFromLedgerEntry(entry xdr.LedgerEntry) AccountData {
+	de := entry.Data.MustData()
+
+	return AccountData{
+		Accountid: de.AccountId.Address(),
+		Key:       string(de.DataName),
+		Value:     base64.StdEncoding.EncodeToString(de.DataValue),
+	}
+}
+
+// SignersFromLedgerEntry builds the additional signers of an account from its
+// ledger entry.
+func SignersFromLedgerEntry(entry xdr.LedgerEntry) []Signer {
+	ae := entry.Data.MustAccount()
+
+	signers := make([]Signer, 0, len(ae.Signers))
+	for _, signer := range ae.Signers {
+		signers = append(signers, Signer{
+			Accountid: ae.AccountId.Address(),
+			Publickey: signer.Key.Address(),
+			Weight:    int32(signer.Weight),
+		})
+	}
+
+	return signers
+}
+
+// TrustlineFromLedgerEntry builds a Trustline from a trustline ledger entry.
+func TrustlineFromLedgerEntry(entry xdr.LedgerEntry) (Trustline, error) {
+	tle := entry.Data.MustTrustLine()
+
+	trustline := Trustline{
+		Accountid:    tle.AccountId.Address(),
+		Tlimit:       tle.Limit,
+		Balance:      tle.Balance,
+		Flags:        int32(tle.Flags),
+		LastModified: uint32(entry.LastModifiedLedgerSeq),
+	}
+
+	err := tle.Asset.Extract(&trustline.Assettype, &trustline.Assetcode, &trustline.Issuer)
+	if err != nil {
+		return Trustline{}, err
+	}
+
+	if v1, ok := tle.Ext.GetV1(); ok {
+		trustline.BuyingLiabilities = v1.Liabilities.Buying
+		trustline.SellingLiabilities = v1.Liabilities.Selling
+	}
+
+	return trustline, nil
+}
@@ -6,6 +6,7 @@ import (
 	"strconv"
 
 	"github.com/guregu/null"
+	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/strkey"
 	"github.com/cowry-network/go/support/db"
 	"github.com/cowry-network/go/support/errors"
@@ -65,6 +66,26 @@ type Offer struct {
 	Lastmodified int32     `db:"lastmodified"`
 }
 
+// OffersQuery is a helper struct to configure queries to the `offers` table.
+// Any filter left at its zero value is not applied.
+type OffersQuery struct {
+	PageQuery db2.PageQuery
+	SellerID  string
+	Selling   *xdr.Asset
+	Buying    *xdr.Asset
+}
+
+// AccountsQuery is a helper struct to configure queries to the `accounts`
+// table, see Q.Accounts. Any filter left at its zero value is not applied.
+type AccountsQuery struct {
+	PageQuery db2.PageQuery
+	// Signer matches accounts that `Signer` can sign for, including the
+	// account whose master key is `Signer`.
+	Signer string
+	// Asset matches accounts holding a trustline to `Asset`.
+	Asset *xdr.Asset
+}
+
 // OrderBookSummaryPriceLevel is a collapsed view of multiple offers at the same price that
 // contains the summed amount from all the member offers. Used by OrderBookSummary
 type OrderBookSummaryPriceLevel struct {
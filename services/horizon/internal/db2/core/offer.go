@@ -24,6 +24,19 @@ func (r Offer) PriceAsString() string {
 // finding.  Given the input asset type, a list of xdr.Assets is returned that
 // each have some available trades for the input asset.
 func (q *Q) ConnectedAssets(dest interface{}, selling xdr.Asset) error {
+	return q.connectedAssets(dest, selling, "selling", "buying")
+}
+
+// ConnectedBuyingAssets loads xdr.Asset records for the purposes of strict
+// send path finding.  Given the input asset type, a list of xdr.Assets is
+// returned that each have some available offers buying the input asset.
+func (q *Q) ConnectedBuyingAssets(dest interface{}, buying xdr.Asset) error {
+	return q.connectedAssets(dest, buying, "buying", "selling")
+}
+
+// connectedAssets loads the `to` side assets of every offer whose `from` side
+// asset is `asset`, where `from` and `to` are either "selling" or "buying".
+func (q *Q) connectedAssets(dest interface{}, asset xdr.Asset, from, to string) error {
 
 	assets, ok := dest.(*[]xdr.Asset)
 	if !ok {
@@ -36,21 +49,21 @@ func (q *Q) ConnectedAssets(dest interface{}, selling xdr.Asset) error {
 		i string
 	)
 
-	err := selling.Extract(&t, &c, &i)
+	err := asset.Extract(&t, &c, &i)
 	if err != nil {
 		return err
 	}
 
 	sql := sq.Select(
-		"buyingassettype AS type",
-		"coalesce(buyingassetcode, '') AS code",
-		"coalesce(buyingissuer, '') AS issuer").
+		to+"assettype AS type",
+		"coalesce("+to+"assetcode, '') AS code",
+		"coalesce("+to+"issuer, '') AS issuer").
 		From("offers").
-		Where(sq.Eq{"sellingassettype": t}).
-		GroupBy("buyingassettype", "buyingassetcode", "buyingissuer")
+		Where(sq.Eq{from + "assettype": t}).
+		GroupBy(to+"assettype", to+"assetcode", to+"issuer")
 
 	if t != xdr.AssetTypeAssetTypeNative {
-		sql = sql.Where(sq.Eq{"sellingassetcode": c, "sellingissuer": i})
+		sql = sql.Where(sq.Eq{from + "assetcode": c, from + "issuer": i})
 	}
 
 	var rows []struct {
@@ -78,13 +91,49 @@ func (q *Q) ConnectedAssets(dest interface{}, selling xdr.Asset) error {
 	return nil
 }
 
+// AllOffers loads every active offer in the order book.
+func (q *Q) AllOffers(dest interface{}) error {
+	return q.Select(dest, selectOffer)
+}
+
+// OfferByID loads a single active offer identified by `id`.
+func (q *Q) OfferByID(dest interface{}, id int64) error {
+	sql := selectOffer.Where("co.offerid = ?", id).Limit(1)
+	return q.Get(dest, sql)
+}
+
 // OffersByAddress loads a page of active offers for the given
 // address.
 func (q *Q) OffersByAddress(dest interface{}, addy string, pq db2.PageQuery) error {
-	sql := sq.Select("co.*").
-		From("offers co").
-		Where("co.sellerid = ?", addy).
-		Limit(uint64(pq.Limit))
+	return q.Offers(dest, OffersQuery{PageQuery: pq, SellerID: addy})
+}
+
+// Offers loads a page of active offers matching the provided query. Empty
+// filters are ignored, so a zero OffersQuery (aside from the page query)
+// returns every offer in the order book.
+func (q *Q) Offers(dest interface{}, query OffersQuery) error {
+	pq := query.PageQuery
+	sql := selectOffer.Limit(uint64(pq.Limit))
+
+	if query.SellerID != "" {
+		sql = sql.Where("co.sellerid = ?", query.SellerID)
+	}
+
+	if query.Selling != nil {
+		filter, err := assetFilter("co.selling", *query.Selling)
+		if err != nil {
+			return err
+		}
+		sql = sql.Where(filter)
+	}
+
+	if query.Buying != nil {
+		filter, err := assetFilter("co.buying", *query.Buying)
+		if err != nil {
+			return err
+		}
+		sql = sql.Where(filter)
+	}
 
 	cursor, err := pq.CursorInt64()
 	if err != nil {
@@ -100,3 +149,57 @@ func (q *Q) OffersByAddress(dest interface{}, addy string, pq db2.PageQuery) err
 
 	return q.Select(dest, sql)
 }
+
+// OfferDepthsForAsset returns the depths of the order book of `asset`: the
+// amount of the asset that open offers buy (bid) and the amount they sell
+// (ask), as integer strings of stroops.
+func (q *Q) OfferDepthsForAsset(asset xdr.Asset) (bid string, ask string, err error) {
+	buying, err := assetFilter("co.buying", asset)
+	if err != nil {
+		return
+	}
+	selling, err := assetFilter("co.selling", asset)
+	if err != nil {
+		return
+	}
+
+	sql := sq.Select("COALESCE(SUM(trunc(co.amount::numeric * co.pricen / co.priced)), 0)").
+		From("offers co").
+		Where(buying)
+	err = q.Get(&bid, sql)
+	if err != nil {
+		return
+	}
+
+	sql = sq.Select("COALESCE(SUM(co.amount), 0)").
+		From("offers co").
+		Where(selling)
+	err = q.Get(&ask, sql)
+	return
+}
+
+// assetFilter returns a where clause matching the asset columns that begin
+// with `prefix` (i.e. "co.selling" or "co.buying" in the offers table, "tl."
+// in the trustlines table).
+func assetFilter(prefix string, asset xdr.Asset) (sq.Eq, error) {
+	var (
+		t xdr.AssetType
+		c string
+		i string
+	)
+
+	err := asset.Extract(&t, &c, &i)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := sq.Eq{prefix + "assettype": t}
+	if t != xdr.AssetTypeAssetTypeNative {
+		filter[prefix+"assetcode"] = c
+		filter[prefix+"issuer"] = i
+	}
+
+	return filter, nil
+}
+
+var selectOffer = sq.Select("co.*").From("offers co")
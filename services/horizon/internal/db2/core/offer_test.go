@@ -5,6 +5,7 @@ import (
 
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/cowry-network/go/xdr"
 )
 
 func TestOffersByAddress(t *testing.T) {
@@ -69,3 +70,71 @@ func TestOffersByAddress(t *testing.T) {
 		tt.Assert.Equal(int64(2), offers[0].OfferID)
 	}
 }
+
+func TestOfferByID(t *testing.T) {
+	tt := test.Start(t).Scenario("trades")
+	defer tt.Finish()
+	q := &Q{tt.CoreSession()}
+
+	var offer Offer
+	err := q.OfferByID(&offer, 4)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(int64(4), offer.OfferID)
+		tt.Assert.Equal("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU", offer.SellerID)
+	}
+
+	err = q.OfferByID(&offer, 100)
+	tt.Assert.True(q.NoRows(err))
+}
+
+func TestOffers(t *testing.T) {
+	tt := test.Start(t).Scenario("trades")
+	defer tt.Finish()
+	q := &Q{tt.CoreSession()}
+
+	var offers []Offer
+
+	load := func(query OffersQuery) bool {
+		offers = []Offer{}
+		err := q.Offers(&offers, query)
+		return tt.Assert.NoError(err)
+	}
+
+	pq, err := db2.NewPageQuery("", true, "asc", db2.DefaultPageSize)
+	tt.Require.NoError(err)
+
+	eur := xdr.MustNewCreditAsset("EUR", "GCQPYGH4K57XBDENKKX55KDTWOTK5WDWRQOH2LHEDX3EKVIQRLMESGBG")
+	usd := xdr.MustNewCreditAsset("USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4")
+	native := xdr.MustNewNativeAsset()
+
+	// no filters returns every offer
+	if load(OffersQuery{PageQuery: pq}) {
+		tt.Assert.Len(offers, 4)
+	}
+
+	// filters by seller
+	if load(OffersQuery{PageQuery: pq, SellerID: "GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU"}) {
+		tt.Assert.Len(offers, 1)
+	}
+
+	// filters by selling asset
+	if load(OffersQuery{PageQuery: pq, Selling: &eur}) {
+		tt.Assert.Len(offers, 3)
+	}
+
+	if load(OffersQuery{PageQuery: pq, Selling: &usd}) {
+		tt.Assert.Len(offers, 1)
+		tt.Assert.Equal(int64(4), offers[0].OfferID)
+	}
+
+	// filters by native buying asset
+	if load(OffersQuery{PageQuery: pq, Buying: &native}) {
+		tt.Assert.Len(offers, 1)
+		tt.Assert.Equal(int64(4), offers[0].OfferID)
+	}
+
+	// combines filters
+	if load(OffersQuery{PageQuery: pq, Selling: &eur, Buying: &native}) {
+		tt.Assert.Len(offers, 0)
+	}
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	sq "github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
 	"github.com/cowry-network/go/support/errors"
 	"github.com/cowry-network/go/xdr"
 )
@@ -44,6 +45,53 @@ func (q *Q) SignersByAddress(dest interface{}, addy string) error {
 	return nil
 }
 
+// SignersByAddresses loads all signer rows for every account in `addys`
+func (q *Q) SignersByAddresses(dest *[]Signer, addys []string) error {
+	schemaVersion, err := q.SchemaVersion()
+	if err != nil {
+		return err
+	}
+
+	if schemaVersion < 9 {
+		sql := selectSigner.Where(sq.Eq{"accountid": addys})
+		return q.Select(dest, sql)
+	}
+
+	var rows []struct {
+		Accountid string
+		Signers   null.String
+	}
+	sql := selectSignerVersion9.Column("a.accountid").Where(sq.Eq{"a.accountid": addys})
+	err = q.Select(&rows, sql)
+	if err != nil {
+		return err
+	}
+
+	signers := []Signer{}
+	for _, row := range rows {
+		if !row.Signers.Valid {
+			continue
+		}
+
+		var signersXDR []xdr.Signer
+		err = xdr.SafeUnmarshalBase64(row.Signers.String, &signersXDR)
+		if err != nil {
+			return errors.Wrap(err, "Error decoding []xdr.Signer")
+		}
+
+		for _, signer := range signersXDR {
+			signers = append(signers, Signer{
+				Accountid: row.Accountid,
+				Publickey: signer.Key.Address(),
+				Weight:    int32(signer.Weight),
+			})
+		}
+	}
+
+	*dest = signers
+	return nil
+}
+
 var selectSigner = sq.Select(
 	"si.accountid",
 	"si.publickey",
@@ -75,6 +75,23 @@ func (q *Q) TrustlinesByAddress(dest interface{}, addy string) error {
 	return q.Select(dest, sql)
 }
 
+// TrustlinesByAddresses loads all trustlines for every account in `addys`
+func (q *Q) TrustlinesByAddresses(dest *[]Trustline, addys []string) error {
+	sql := selectTrustline.Where(sq.Eq{"accountid": addys})
+	return q.Select(dest, sql)
+}
+
+// TrustlineByAddressAndAsset loads the trustline of `addy` for `asset`
+func (q *Q) TrustlineByAddressAndAsset(dest *Trustline, addy string, asset xdr.Asset) error {
+	filter, err := assetFilter("tl.", asset)
+	if err != nil {
+		return err
+	}
+
+	sql := selectTrustline.Limit(1).Where("tl.accountid = ?", addy).Where(filter)
+	return q.Get(dest, sql)
+}
+
 // BalancesForAsset returns all the balances by asset type, code, issuer
 func (q *Q) BalancesForAsset(
 	assetType int32,
@@ -95,6 +112,39 @@ func (q *Q) BalancesForAsset(
 	return result.Count, result.Sum, err
 }
 
+// UnauthorizedAccountsForAsset returns the number of trustlines to the asset
+// that are not authorized
+func (q *Q) UnauthorizedAccountsForAsset(
+	assetType int32,
+	assetCode string,
+	assetIssuer string,
+) (int32, error) {
+	sql := sq.Select("COUNT(*)").From("trustlines").Where(sq.Eq{
+		"assettype": assetType,
+		"assetcode": assetCode,
+		"issuer":    assetIssuer,
+	}).Where("flags & ? = 0", int32(xdr.TrustLineFlagsAuthorizedFlag))
+
+	var count int32
+	err := q.Get(&count, sql)
+	return count, err
+}
+
+// TopTrustlinesForAsset loads the `limit` trustlines to `asset` holding the
+// largest balances into `dest`
+func (q *Q) TopTrustlinesForAsset(dest interface{}, asset xdr.Asset, limit uint64) error {
+	filter, err := assetFilter("tl.", asset)
+	if err != nil {
+		return err
+	}
+
+	sql := selectTrustline.
+		Where(filter).
+		OrderBy("tl.balance desc", "tl.accountid asc").
+		Limit(limit)
+	return q.Select(dest, sql)
+}
+
 var selectTrustline = sq.Select(
 	"tl.accountid",
 	"tl.assettype",
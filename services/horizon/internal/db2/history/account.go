@@ -62,7 +62,9 @@ func (q *Q) CreateAccounts(dest interface{}, addresses []string) error {
 	for _, address := range addresses {
 		sql = sql.Values(address)
 	}
-	sql = sql.Suffix("RETURNING *")
+	// Concurrent ingestion sessions may create the same account, in which case
+	// the existing row is returned.
+	sql = sql.Suffix("ON CONFLICT (address) DO UPDATE SET address = EXCLUDED.address RETURNING *")
 
 	return q.Select(dest, sql)
 }
@@ -90,7 +92,9 @@ func (q *Q) GetCreateAccountID(
 	//insert account and return id
 	err = q.GetRaw(
 		&result,
-		`INSERT INTO history_accounts (address) VALUES (?) RETURNING id`,
+		`INSERT INTO history_accounts (address) VALUES (?)
+		ON CONFLICT (address) DO UPDATE SET address = EXCLUDED.address
+		RETURNING id`,
 		aid.Address(),
 	)
 
@@ -0,0 +1,58 @@
+package history
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AddAssetStatSnapshot records the supply of the asset with id `id` on `day`,
+// replacing the one recorded earlier that day, if any.
+func (q *Q) AddAssetStatSnapshot(id int64, day time.Time, amount string, numAccounts int32) error {
+	sql := sq.Insert("asset_stats_snapshots").
+		Columns("id", "day", "amount", "num_accounts").
+		Values(id, day.UTC().Format("2006-01-02"), amount, numAccounts).
+		Suffix("ON CONFLICT (id, day) DO UPDATE SET amount = excluded.amount, num_accounts = excluded.num_accounts")
+
+	_, err := q.Exec(sql)
+	return err
+}
+
+// AssetStatSnapshots loads the supply of the asset with id `id` on each day
+// from `since` to `until`, oldest first.  Snapshots are only recorded on the
+// days the stats of the asset are updated, so a day without a snapshot gets the
+// last one recorded before it.  Days before the first snapshot are skipped.
+func (q *Q) AssetStatSnapshots(dest interface{}, id int64, since, until time.Time) error {
+	return q.SelectRaw(dest, `
+		SELECT ass.id, d.day::date AS day, ass.amount, ass.num_accounts
+		FROM generate_series($2::date, $3::date, '1 day') AS d(day)
+		JOIN LATERAL (
+			SELECT * FROM asset_stats_snapshots s
+			WHERE s.id = $1 AND s.day <= d.day
+			ORDER BY s.day DESC
+			LIMIT 1
+		) ass ON true
+		ORDER BY d.day ASC
+	`, id, since.UTC().Format("2006-01-02"), until.UTC().Format("2006-01-02"))
+}
+
+// TradeVolumeForAsset returns the number of trades of the asset with id `id`
+// since `since`, and the amount of the asset they exchanged as an integer
+// string of stroops.
+func (q *Q) TradeVolumeForAsset(id int64, since time.Time) (int32, string, error) {
+	sql := sq.Select("COUNT(*) as count").
+		Column(
+			"COALESCE(SUM(CASE WHEN htrd.base_asset_id = ? THEN htrd.base_amount ELSE htrd.counter_amount END), 0) as volume",
+			id,
+		).
+		From("history_trades htrd").
+		Where("(htrd.base_asset_id = ? OR htrd.counter_asset_id = ?)", id, id).
+		Where("htrd.ledger_closed_at >= ?", since.UTC())
+
+	result := struct {
+		Count  int32  `db:"count"`
+		Volume string `db:"volume"`
+	}{}
+	err := q.Get(&result, sql)
+	return result.Count, result.Volume, err
+}
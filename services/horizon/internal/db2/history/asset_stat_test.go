@@ -0,0 +1,50 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/cowry-network/go/xdr"
+)
+
+func TestAssetStatSnapshots(t *testing.T) {
+	tt := test.Start(t).Scenario("ingest_asset_stats")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	id, err := q.GetAssetID(xdr.MustNewCreditAsset("USD", "GC23QF2HUE52AMXUFUH3AYJAXXGXXV2VHXYYR6EYXETPKDXZSAW67XO4"))
+	tt.Require.NoError(err)
+
+	today := time.Now().UTC()
+	tt.Require.NoError(q.AddAssetStatSnapshot(id, today.AddDate(0, 0, -10), "1000", 1))
+	tt.Require.NoError(q.AddAssetStatSnapshot(id, today.AddDate(0, 0, -1), "2000", 1))
+	tt.Require.NoError(q.AddAssetStatSnapshot(id, today, "2500", 2))
+	// a later snapshot of the same day replaces the earlier one
+	tt.Require.NoError(q.AddAssetStatSnapshot(id, today, "3000", 2))
+
+	var snapshots []AssetStatSnapshot
+	tt.Require.NoError(q.AssetStatSnapshots(&snapshots, id, today.AddDate(0, 0, -1), today))
+	if tt.Assert.Len(snapshots, 2) {
+		tt.Assert.Equal("2000", snapshots[0].Amount)
+		tt.Assert.Equal("3000", snapshots[1].Amount)
+		tt.Assert.Equal(int32(2), snapshots[1].NumAccounts)
+	}
+
+	// days without a snapshot get the last one recorded before them, days
+	// before the first snapshot are skipped
+	var carried []AssetStatSnapshot
+	tt.Require.NoError(q.AssetStatSnapshots(&carried, id, today.AddDate(0, 0, -12), today.AddDate(0, 0, -8)))
+	if tt.Assert.Len(carried, 3) {
+		for i, snapshot := range carried {
+			tt.Assert.Equal("1000", snapshot.Amount)
+			tt.Assert.Equal(today.AddDate(0, 0, i-10).Format("2006-01-02"), snapshot.Day.Format("2006-01-02"))
+		}
+	}
+
+	count, volume, err := q.TradeVolumeForAsset(id, today.Add(-24*time.Hour))
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int32(0), count)
+	tt.Assert.Equal("0", volume)
+}
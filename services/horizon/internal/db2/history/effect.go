@@ -6,9 +6,11 @@ import (
 	"math"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/cowry-network/go/amount"
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/toid"
 	"github.com/cowry-network/go/support/errors"
+	strtime "github.com/cowry-network/go/support/time"
 	"github.com/cowry-network/go/xdr"
 )
 
@@ -131,9 +133,67 @@ func (q *EffectsQ) ForTransaction(hash string) *EffectsQ {
 	return q
 }
 
-// OfType filters the query to only effects of the given type.
-func (q *EffectsQ) OfType(typ EffectType) *EffectsQ {
-	q.sql = q.sql.Where("heff.type = ?", typ)
+// OfType filters the query to only effects of the given types.
+func (q *EffectsQ) OfType(types ...EffectType) *EffectsQ {
+	q.sql = q.sql.Where(sq.Eq{"heff.type": types})
+	return q
+}
+
+// ForAsset filters the query to only effects whose details indicate that the
+// effect is for a specific asset, including either asset of trades.  Account
+// creations, which only ever fund accounts with lumens, are included for the
+// native asset.
+func (q *EffectsQ) ForAsset(asset xdr.Asset) *EffectsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	filter, err := assetDetailsFilter("heff", []string{"", "sold_", "bought_"}, asset)
+	if err != nil {
+		q.Err = err
+		return q
+	}
+
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		filter = sq.Or{filter, sq.Eq{"heff.type": EffectAccountCreated}}
+	}
+
+	q.sql = q.sql.Where(filter)
+	return q
+}
+
+// ForAmountRange filters the query to only effects whose amount (or starting
+// balance, for account creations) is between `min` and `max`.  A bound of 0
+// is ignored.
+func (q *EffectsQ) ForAmountRange(min, max xdr.Int64) *EffectsQ {
+	col := amountDetailsColumn("heff")
+	if min > 0 {
+		q.sql = q.sql.Where(col+" >= ?::numeric", amount.String(min))
+	}
+	if max > 0 {
+		q.sql = q.sql.Where(col+" <= ?::numeric", amount.String(max))
+	}
+	return q
+}
+
+// ForTimeRange filters the query to only effects in ledgers closed from
+// `start` included to `end` excluded.  A nil time is ignored.
+func (q *EffectsQ) ForTimeRange(start, end strtime.Millis) *EffectsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	var from, to int64
+	from, to, q.Err = q.parent.idRangeForTime(start, end)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where(
+		"heff.history_operation_id >= ? AND heff.history_operation_id < ?",
+		from,
+		to,
+	)
 	return q
 }
 
@@ -0,0 +1,93 @@
+package history
+
+import (
+	"fmt"
+	"math"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cowry-network/go/services/horizon/internal/toid"
+	strtime "github.com/cowry-network/go/support/time"
+	"github.com/cowry-network/go/xdr"
+)
+
+// assetDetailsFilter returns the condition selecting the rows of `table`
+// whose details reference `a` in their asset_type, asset_code and
+// asset_issuer fields, under any of `prefixes` (such as "source_" for the
+// source asset of path payments).
+func assetDetailsFilter(table string, prefixes []string, a xdr.Asset) (sq.Sqlizer, error) {
+	var typ, code, iss string
+	err := a.Extract(&typ, &code, &iss)
+	if err != nil {
+		return nil, err
+	}
+
+	var filter sq.Or
+	for _, prefix := range prefixes {
+		field := func(name string) string {
+			return fmt.Sprintf("%s.details->>'%s%s'", table, prefix, name)
+		}
+
+		if a.Type == xdr.AssetTypeAssetTypeNative {
+			filter = append(filter, sq.Expr(field("asset_type")+" = ?", typ))
+			continue
+		}
+
+		filter = append(filter, sq.Expr(fmt.Sprintf(
+			"(%s = ? AND %s = ? AND %s = ?)",
+			field("asset_type"), field("asset_code"), field("asset_issuer"),
+		), typ, code, iss))
+	}
+	return filter, nil
+}
+
+// amountDetailsColumn returns the expression of the amount (or starting
+// balance, for account creations) in the details of the rows of `table`.
+func amountDetailsColumn(table string) string {
+	return fmt.Sprintf(
+		"COALESCE(%s.details->>'amount', %s.details->>'starting_balance')::numeric",
+		table, table,
+	)
+}
+
+// idRangeForTime returns the range of total order ids, from `from` included
+// to `to` excluded, of the ledgers closed from `start` included to `end`
+// excluded.  Either time is ignored when nil.
+func (q *Q) idRangeForTime(start, end strtime.Millis) (from, to int64, err error) {
+	from, to = 0, math.MaxInt64
+
+	if !start.IsNil() {
+		var seq int32
+		err = q.Get(&seq, sq.Select("hl.sequence").
+			From("history_ledgers hl").
+			Where("hl.closed_at >= ?", start.ToTime()).
+			OrderBy("hl.closed_at asc").
+			Limit(1))
+		if q.NoRows(err) {
+			// no ledger was closed since `start`
+			return 0, 0, nil
+		}
+		if err != nil {
+			return
+		}
+		from = toid.New(seq, 0, 0).ToInt64()
+	}
+
+	if !end.IsNil() {
+		var seq int32
+		err = q.Get(&seq, sq.Select("hl.sequence").
+			From("history_ledgers hl").
+			Where("hl.closed_at < ?", end.ToTime()).
+			OrderBy("hl.closed_at desc").
+			Limit(1))
+		if q.NoRows(err) {
+			// no ledger was closed before `end`
+			return 0, 0, nil
+		}
+		if err != nil {
+			return
+		}
+		to = toid.New(seq+1, 0, 0).ToInt64()
+	}
+
+	return
+}
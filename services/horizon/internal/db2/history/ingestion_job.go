@@ -0,0 +1,80 @@
+package history
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/guregu/null"
+)
+
+// CreateIngestionJob inserts a running ingestion job of `kind` over the
+// ledgers from `first` to `last` and loads the new row into `dest`.
+func (q *Q) CreateIngestionJob(dest interface{}, kind string, first, last int32) error {
+	now := time.Now().UTC()
+	sql := sq.Insert("ingestion_jobs").
+		Columns("kind", "first_ledger", "last_ledger", "status", "created_at", "updated_at").
+		Values(kind, first, last, IngestionJobRunning, now, now).
+		Suffix("RETURNING *")
+
+	return q.Get(dest, sql)
+}
+
+// IngestionJobs loads the `limit` most recently created ingestion jobs, newest
+// first.
+func (q *Q) IngestionJobs(dest interface{}, limit uint64) error {
+	sql := selectIngestionJob.OrderBy("ij.id desc").Limit(limit)
+	return q.Select(dest, sql)
+}
+
+// UnfinishedIngestionJob loads the most recently created ingestion job of
+// `kind` that did not complete.
+func (q *Q) UnfinishedIngestionJob(dest interface{}, kind string) error {
+	sql := selectIngestionJob.
+		Where("ij.kind = ?", kind).
+		Where("ij.status <> ?", IngestionJobComplete).
+		OrderBy("ij.id desc").
+		Limit(1)
+
+	return q.Get(dest, sql)
+}
+
+// UnfinishedIngestionJobForRange loads the most recently created ingestion
+// job of `kind` over the ledgers from `first` to `last` that did not complete.
+func (q *Q) UnfinishedIngestionJobForRange(dest interface{}, kind string, first, last int32) error {
+	sql := selectIngestionJob.
+		Where("ij.kind = ?", kind).
+		Where("ij.first_ledger = ?", first).
+		Where("ij.last_ledger = ?", last).
+		Where("ij.status <> ?", IngestionJobComplete).
+		OrderBy("ij.id desc").
+		Limit(1)
+
+	return q.Get(dest, sql)
+}
+
+// UpdateIngestionJobProgress records `seq` as the last ledger ingested by the
+// job `id`.
+func (q *Q) UpdateIngestionJobProgress(id int64, seq int32) error {
+	sql := sq.Update("ingestion_jobs").
+		Set("last_ingested_ledger", seq).
+		Set("updated_at", time.Now().UTC()).
+		Where("id = ?", id)
+
+	_, err := q.Exec(sql)
+	return err
+}
+
+// UpdateIngestionJobStatus records the status of the job `id`, along with the
+// error that made it fail, if any.
+func (q *Q) UpdateIngestionJobStatus(id int64, status string, jobErr null.String) error {
+	sql := sq.Update("ingestion_jobs").
+		Set("status", status).
+		Set("error", jobErr).
+		Set("updated_at", time.Now().UTC()).
+		Where("id = ?", id)
+
+	_, err := q.Exec(sql)
+	return err
+}
+
+var selectIngestionJob = sq.Select("ij.*").From("ingestion_jobs ij")
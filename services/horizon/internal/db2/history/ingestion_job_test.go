@@ -0,0 +1,51 @@
+package history
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/guregu/null"
+)
+
+func TestIngestionJobQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	var job IngestionJob
+	err := q.CreateIngestionJob(&job, "reingest", 10, 1)
+	tt.Require.NoError(err)
+	tt.Assert.Equal("reingest", job.Kind)
+	tt.Assert.Equal(int32(10), job.FirstLedger)
+	tt.Assert.Equal(int32(1), job.LastLedger)
+	tt.Assert.Equal(IngestionJobRunning, job.Status)
+	tt.Assert.False(job.LastIngestedLedger.Valid)
+
+	tt.Require.NoError(q.UpdateIngestionJobProgress(job.ID, 5))
+	tt.Require.NoError(q.UpdateIngestionJobStatus(job.ID, IngestionJobFailed, null.StringFrom("boom")))
+
+	var found IngestionJob
+	err = q.UnfinishedIngestionJobForRange(&found, "reingest", 10, 1)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Equal(job.ID, found.ID)
+		tt.Assert.Equal(int64(5), found.LastIngestedLedger.Int64)
+		tt.Assert.Equal(IngestionJobFailed, found.Status)
+		tt.Assert.Equal("boom", found.Error.String)
+	}
+
+	err = q.UnfinishedIngestionJobForRange(&found, "reingest", 1, 10)
+	tt.Assert.True(q.NoRows(err))
+
+	err = q.UnfinishedIngestionJob(&found, "backfill")
+	tt.Assert.True(q.NoRows(err))
+
+	tt.Require.NoError(q.UpdateIngestionJobStatus(job.ID, IngestionJobComplete, null.String{}))
+	err = q.UnfinishedIngestionJob(&found, "reingest")
+	tt.Assert.True(q.NoRows(err))
+
+	var jobs []IngestionJob
+	err = q.IngestionJobs(&jobs, 10)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(jobs, 1)
+	}
+}
@@ -8,6 +8,24 @@ import (
 	"github.com/cowry-network/go/support/errors"
 )
 
+// WithoutMetaImporterVersion is the importer version the ledgers ingested
+// without the meta of their transactions are tagged with.  Being negative, it
+// never matches a version of the ingestion algorithm and is older than all of
+// them.
+const WithoutMetaImporterVersion = -1
+
+// LedgersWithoutMeta counts the ledgers from `start` to `end`, inclusive, that
+// were ingested without the meta of their transactions.
+func (q *Q) LedgersWithoutMeta(dest *int, start, end int32) error {
+	sql := sq.Select("COUNT(*)").
+		From("history_ledgers").
+		Where(sq.GtOrEq{"sequence": start}).
+		Where(sq.LtOrEq{"sequence": end}).
+		Where(sq.Eq{"importer_version": WithoutMetaImporterVersion})
+
+	return q.Get(dest, sql)
+}
+
 // LedgerBySequence loads the single ledger at `seq` into `dest`
 func (q *Q) LedgerBySequence(dest interface{}, seq int32) error {
 	sql := selectLedger.
@@ -0,0 +1,155 @@
+package history
+
+import (
+	"fmt"
+	"math"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cowry-network/go/services/horizon/internal/db2"
+	"github.com/cowry-network/go/services/horizon/internal/toid"
+	"github.com/cowry-network/go/xdr"
+)
+
+// ID returns a lexically ordered id for this ledger entry change record
+func (r *LedgerEntryChange) ID() string {
+	return fmt.Sprintf("%019d-%010d", r.HistoryOperationID, r.Order)
+}
+
+// LedgerSequence return the ledger in which the change occurred.
+func (r *LedgerEntryChange) LedgerSequence() int32 {
+	id := toid.Parse(r.HistoryOperationID)
+	return id.LedgerSequence
+}
+
+// PagingToken returns a cursor for this ledger entry change
+func (r *LedgerEntryChange) PagingToken() string {
+	return fmt.Sprintf("%d-%d", r.HistoryOperationID, r.Order)
+}
+
+// AccountEntriesAt loads the latest recorded change to each account, trustline
+// and data entry owned by `aid` as of the close of ledger `seq`.  Entries that
+// had been removed by then are included, with a null EntryAfter.
+func (q *Q) AccountEntriesAt(dest interface{}, aid string, seq int32) error {
+	var account Account
+	err := q.AccountByAddress(&account, aid)
+	if err != nil {
+		return err
+	}
+
+	sql := sq.
+		Select("DISTINCT ON (hlec.ledger_key) hlec.*, hacc.address").
+		From("history_ledger_entry_changes hlec").
+		LeftJoin("history_accounts hacc ON hacc.id = hlec.history_account_id").
+		Where("hlec.history_account_id = ?", account.ID).
+		Where("hlec.history_operation_id < ?", toid.New(seq+1, 0, 0).ToInt64()).
+		Where("hlec.entry_type <> ?", xdr.LedgerEntryTypeOffer).
+		OrderBy("hlec.ledger_key, hlec.history_operation_id desc, hlec.order desc")
+
+	return q.Select(dest, sql)
+}
+
+// ReapLedgerEntryChanges deletes the changes made before the operation `end`
+// that no longer describe the state of their ledger entry: the changes followed
+// by a later change to the same entry, and the removals.  The state of every
+// entry as of `end` is kept, so that AccountEntriesAt can still reconstruct
+// the accounts untouched since.
+func (q *Q) ReapLedgerEntryChanges(end int64) error {
+	_, err := q.ExecRaw(`
+		DELETE FROM history_ledger_entry_changes hlec
+		WHERE hlec.history_operation_id < $1
+		AND (hlec.entry_after IS NULL OR EXISTS (
+			SELECT 1 FROM history_ledger_entry_changes later
+			WHERE later.history_account_id = hlec.history_account_id
+			AND later.ledger_key = hlec.ledger_key
+			AND (later.history_operation_id, later."order") > (hlec.history_operation_id, hlec."order")
+		))
+	`, end)
+	return err
+}
+
+// LedgerEntryChanges provides a helper to filter rows from the
+// `history_ledger_entry_changes` table with pre-defined filters.  See
+// `LedgerEntryChangesQ` methods for the available filters.
+func (q *Q) LedgerEntryChanges() *LedgerEntryChangesQ {
+	return &LedgerEntryChangesQ{
+		parent: q,
+		sql:    selectLedgerEntryChange,
+	}
+}
+
+// ForAccount filters the query to only changes to ledger entries owned by a
+// specific account.
+func (q *LedgerEntryChangesQ) ForAccount(aid string) *LedgerEntryChangesQ {
+	var account Account
+	q.Err = q.parent.AccountByAddress(&account, aid)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where("hlec.history_account_id = ?", account.ID)
+
+	return q
+}
+
+// ForOperation filters the query to only changes made by a specific
+// operation, specified by its id.
+func (q *LedgerEntryChangesQ) ForOperation(id int64) *LedgerEntryChangesQ {
+	q.sql = q.sql.Where("hlec.history_operation_id = ?", id)
+	return q
+}
+
+// Page specifies the paging constraints for the query being built by `q`.
+func (q *LedgerEntryChangesQ) Page(page db2.PageQuery) *LedgerEntryChangesQ {
+	if q.Err != nil {
+		return q
+	}
+
+	op, idx, err := page.CursorInt64Pair(db2.DefaultPairSep)
+	if err != nil {
+		q.Err = err
+		return q
+	}
+
+	if idx > math.MaxInt32 {
+		idx = math.MaxInt32
+	}
+
+	switch page.Order {
+	case "asc":
+		q.sql = q.sql.
+			Where(`(
+					 hlec.history_operation_id >= ?
+				AND (
+					 hlec.history_operation_id > ? OR
+					(hlec.history_operation_id = ? AND hlec.order > ?)
+				))`, op, op, op, idx).
+			OrderBy("hlec.history_operation_id asc, hlec.order asc")
+	case "desc":
+		q.sql = q.sql.
+			Where(`(
+					 hlec.history_operation_id <= ?
+				AND (
+					 hlec.history_operation_id < ? OR
+					(hlec.history_operation_id = ? AND hlec.order < ?)
+				))`, op, op, op, idx).
+			OrderBy("hlec.history_operation_id desc, hlec.order desc")
+	}
+
+	q.sql = q.sql.Limit(page.Limit)
+	return q
+}
+
+// Select loads the results of the query specified by `q` into `dest`.
+func (q *LedgerEntryChangesQ) Select(dest interface{}) error {
+	if q.Err != nil {
+		return q.Err
+	}
+
+	q.Err = q.parent.Select(dest, q.sql)
+	return q.Err
+}
+
+var selectLedgerEntryChange = sq.
+	Select("hlec.*, hacc.address").
+	From("history_ledger_entry_changes hlec").
+	LeftJoin("history_accounts hacc ON hacc.id = hlec.history_account_id")
@@ -0,0 +1,72 @@
+package history_test
+
+import (
+	"testing"
+
+	sq "github.com/Masterminds/squirrel"
+	. "github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/cowry-network/go/services/horizon/internal/toid"
+	"github.com/cowry-network/go/xdr"
+	"github.com/guregu/null"
+)
+
+func TestReapLedgerEntryChanges(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	const address = "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"
+	var account Account
+	tt.Require.NoError(q.AccountByAddress(&account, address))
+
+	insert := sq.Insert("history_ledger_entry_changes").Columns(
+		"history_account_id",
+		"history_operation_id",
+		"\"order\"",
+		"entry_type",
+		"change_type",
+		"entry_after",
+		"ledger_key",
+	)
+	change := func(seq int32, key string, after null.String) {
+		insert = insert.Values(
+			account.ID,
+			toid.New(seq, 1, 1).ToInt64(),
+			len(key),
+			xdr.LedgerEntryTypeTrustline,
+			xdr.LedgerEntryChangeTypeLedgerEntryUpdated,
+			after,
+			key,
+		)
+	}
+	// updated twice before ledger 4
+	change(2, "a", null.StringFrom("a2"))
+	change(3, "a", null.StringFrom("a3"))
+	// removed before ledger 4
+	change(2, "bb", null.StringFrom("b2"))
+	change(3, "bb", null.String{})
+	// updated before and after ledger 4
+	change(2, "ccc", null.StringFrom("c2"))
+	change(5, "ccc", null.StringFrom("c5"))
+	_, err := q.Exec(insert)
+	tt.Require.NoError(err)
+
+	tt.Require.NoError(q.ReapLedgerEntryChanges(toid.New(4, 0, 0).ToInt64()))
+
+	var changes []LedgerEntryChange
+	tt.Require.NoError(q.Select(&changes, sq.Select("*").
+		From("history_ledger_entry_changes").
+		Where("history_account_id = ?", account.ID).
+		OrderBy("ledger_key")))
+	if tt.Assert.Len(changes, 2) {
+		tt.Assert.Equal("a3", changes[0].EntryAfter.String)
+		tt.Assert.Equal("c5", changes[1].EntryAfter.String)
+	}
+
+	// the state at ledger 4 is still known
+	tt.Require.NoError(q.AccountEntriesAt(&changes, address, 4))
+	if tt.Assert.Len(changes, 1) {
+		tt.Assert.Equal("a3", changes[0].EntryAfter.String)
+	}
+}
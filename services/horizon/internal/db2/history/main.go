@@ -106,6 +106,20 @@ const (
 
 )
 
+const (
+	// IngestionJobRunning is the status of an ingestion job in progress, or
+	// interrupted before it could record its outcome.
+	IngestionJobRunning = "running"
+
+	// IngestionJobFailed is the status of an ingestion job that stopped because
+	// of an error.
+	IngestionJobFailed = "failed"
+
+	// IngestionJobComplete is the status of an ingestion job that ingested
+	// every ledger of its range.
+	IngestionJobComplete = "complete"
+)
+
 // Account is a row of data from the `history_accounts` table
 type Account struct {
 	ID      int64
@@ -130,11 +144,23 @@ type Asset struct {
 
 // AssetStat is a row in the asset_stats table representing the stats per Asset
 type AssetStat struct {
-	ID          int64  `db:"id"`
-	Amount      string `db:"amount"`
-	NumAccounts int32  `db:"num_accounts"`
-	Flags       int8   `db:"flags"`
-	Toml        string `db:"toml"`
+	ID                      int64  `db:"id"`
+	Amount                  string `db:"amount"`
+	NumAccounts             int32  `db:"num_accounts"`
+	NumUnauthorizedAccounts int32  `db:"num_unauthorized_accounts"`
+	Flags                   int8   `db:"flags"`
+	Toml                    string `db:"toml"`
+	BidDepth                string `db:"bid_depth"`
+	AskDepth                string `db:"ask_depth"`
+}
+
+// AssetStatSnapshot is a row in the asset_stats_snapshots table representing
+// the supply of an asset at the end of a day
+type AssetStatSnapshot struct {
+	ID          int64     `db:"id"`
+	Day         time.Time `db:"day"`
+	Amount      string    `db:"amount"`
+	NumAccounts int32     `db:"num_accounts"`
 }
 
 // Effect is a row of data from the `history_effects` table
@@ -177,6 +203,22 @@ type FeeStats struct {
 	P99  null.Int `db:"p99"`
 }
 
+// IngestionJob is a row of data from the `ingestion_jobs` table, recording the
+// progress of a reingestion or backfill over a range of ledgers.
+type IngestionJob struct {
+	ID          int64  `db:"id"`
+	Kind        string `db:"kind"`
+	FirstLedger int32  `db:"first_ledger"`
+	LastLedger  int32  `db:"last_ledger"`
+	// LastIngestedLedger is the last ledger of the range whose data has been
+	// committed, null until the job reaches its first checkpoint.
+	LastIngestedLedger null.Int    `db:"last_ingested_ledger"`
+	Status             string      `db:"status"`
+	Error              null.String `db:"error"`
+	CreatedAt          time.Time   `db:"created_at"`
+	UpdatedAt          time.Time   `db:"updated_at"`
+}
+
 // LatestLedger represents a response from the raw LatestLedgerBaseFeeAndSequence
 // query.
 type LatestLedger struct {
@@ -221,6 +263,31 @@ type LedgerCache struct {
 	queued map[int32]struct{}
 }
 
+// LedgerEntryChange is a row of data from the `history_ledger_entry_changes`
+// table. It records the state of a single ledger entry before and after the
+// application of an operation, both as base64-encoded xdr.LedgerEntry values.
+// Changes made outside of operations, when charging fees and bumping sequence
+// numbers, are recorded against the ledger or transaction id instead.
+type LedgerEntryChange struct {
+	HistoryAccountID   int64                     `db:"history_account_id"`
+	Account            string                    `db:"address"`
+	HistoryOperationID int64                     `db:"history_operation_id"`
+	Order              int32                     `db:"order"`
+	EntryType          xdr.LedgerEntryType       `db:"entry_type"`
+	ChangeType         xdr.LedgerEntryChangeType `db:"change_type"`
+	EntryBefore        null.String               `db:"entry_before"`
+	EntryAfter         null.String               `db:"entry_after"`
+	LedgerKey          null.String               `db:"ledger_key"`
+}
+
+// LedgerEntryChangesQ is a helper struct to aid in configuring queries that
+// loads slices of LedgerEntryChange structs.
+type LedgerEntryChangesQ struct {
+	Err    error
+	parent *Q
+	sql    sq.SelectBuilder
+}
+
 // LedgersQ is a helper struct to aid in configuring queries that loads
 // slices of Ledger structs.
 type LedgersQ struct {
@@ -327,6 +394,13 @@ type Transaction struct {
 	Successful *bool `db:"successful"`
 }
 
+// TransactionEnvelope is a row of data from the `history_transactions` table
+// holding the envelope of a transaction.
+type TransactionEnvelope struct {
+	ID       int64                   `db:"id"`
+	Envelope xdr.TransactionEnvelope `db:"tx_envelope"`
+}
+
 // TransactionsQ is a helper struct to aid in configuring queries that loads
 // slices of transaction structs.
 // WARNING: returns successful and failed transactions! Use `SuccessfulOnly`
@@ -0,0 +1,163 @@
+package history
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cowry-network/go/services/horizon/internal/db2"
+	"github.com/cowry-network/go/support/errors"
+	strtime "github.com/cowry-network/go/support/time"
+	"github.com/cowry-network/go/xdr"
+)
+
+// MarketSummary represents a row of the `history_markets` table, i.e. a pair
+// of assets that have been traded against each other, along with the
+// aggregation of its trades over a window of time.
+type MarketSummary struct {
+	BaseAssetID        int64     `db:"base_asset_id"`
+	BaseAssetType      string    `db:"base_asset_type"`
+	BaseAssetCode      string    `db:"base_asset_code"`
+	BaseAssetIssuer    string    `db:"base_asset_issuer"`
+	CounterAssetID     int64     `db:"counter_asset_id"`
+	CounterAssetType   string    `db:"counter_asset_type"`
+	CounterAssetCode   string    `db:"counter_asset_code"`
+	CounterAssetIssuer string    `db:"counter_asset_issuer"`
+	FirstTradeAt       time.Time `db:"first_trade_at"`
+	LastTradeAt        time.Time `db:"last_trade_at"`
+	TradeAggregation
+}
+
+// PagingToken returns a cursor for this market
+func (r *MarketSummary) PagingToken() string {
+	return fmt.Sprintf("%d-%d", r.BaseAssetID, r.CounterAssetID)
+}
+
+// BaseAsset returns the base asset of this market
+func (r *MarketSummary) BaseAsset() (xdr.Asset, error) {
+	return newAsset(r.BaseAssetType, r.BaseAssetCode, r.BaseAssetIssuer)
+}
+
+// CounterAsset returns the counter asset of this market
+func (r *MarketSummary) CounterAsset() (xdr.Asset, error) {
+	return newAsset(r.CounterAssetType, r.CounterAssetCode, r.CounterAssetIssuer)
+}
+
+func newAsset(typ, code, issuer string) (asset xdr.Asset, err error) {
+	if typ == "native" {
+		err = asset.SetNative()
+		return
+	}
+
+	var issuerID xdr.AccountId
+	err = issuerID.SetAddress(issuer)
+	if err != nil {
+		return
+	}
+	err = asset.SetCredit(code, issuerID)
+	return
+}
+
+// MarketSummariesQ is a helper struct to aid in configuring queries to
+// aggregate the trades of every market traded within a window of time
+type MarketSummariesQ struct {
+	startTime    strtime.Millis
+	endTime      strtime.Millis
+	pagingParams db2.PageQuery
+}
+
+// GetMarketSummariesQ initializes a MarketSummariesQ query builder aggregating
+// the trades closed from `startTime` included to `endTime` excluded.
+func (q Q) GetMarketSummariesQ(startTime, endTime strtime.Millis, pagingParams db2.PageQuery) (*MarketSummariesQ, error) {
+	if startTime.IsNil() || endTime <= startTime {
+		return &MarketSummariesQ{}, errors.New("time window is not allowed")
+	}
+
+	return &MarketSummariesQ{
+		startTime:    startTime,
+		endTime:      endTime,
+		pagingParams: pagingParams,
+	}, nil
+}
+
+// GetSql generates a sql statement loading the markets with trades in the
+// window along with the aggregation of those trades, ordered by asset pair.
+func (q *MarketSummariesQ) GetSql() (sq.SelectBuilder, error) {
+	base, counter, err := q.pagingParams.CursorInt64Pair(db2.DefaultPairSep)
+	if err != nil {
+		return sq.SelectBuilder{}, err
+	}
+
+	// the whole window is aggregated in a single bucket, starting at startTime
+	resolution := int64(q.endTime - q.startTime)
+	bucketSQL := bucketTrades(resolution, int64(q.startTime)).
+		From("history_trades").
+		Where("base_asset_id = hm.base_asset_id AND counter_asset_id = hm.counter_asset_id").
+		Where(sq.GtOrEq{"ledger_closed_at": q.startTime.ToTime()}).
+		Where(sq.Lt{"ledger_closed_at": q.endTime.ToTime()}).
+		OrderBy("history_operation_id ", "\"order\"")
+
+	aggregationSQL, args, err := sq.Select(
+		"timestamp",
+		"count(*) as count",
+		"sum(base_amount) as base_volume",
+		"sum(counter_amount) as counter_volume",
+		"sum(counter_amount)/sum(base_amount) as avg",
+		"max_price(price) as high",
+		"min_price(price) as low",
+		"first(price)  as open",
+		"last(price) as close",
+	).
+		FromSelect(bucketSQL, "htrd").
+		GroupBy("timestamp").
+		ToSql()
+	if err != nil {
+		return sq.SelectBuilder{}, err
+	}
+
+	sql := sq.Select(
+		"hm.base_asset_id",
+		"base_assets.asset_type as base_asset_type",
+		"base_assets.asset_code as base_asset_code",
+		"base_assets.asset_issuer as base_asset_issuer",
+		"hm.counter_asset_id",
+		"counter_assets.asset_type as counter_asset_type",
+		"counter_assets.asset_code as counter_asset_code",
+		"counter_assets.asset_issuer as counter_asset_issuer",
+		"hm.first_trade_at",
+		"hm.last_trade_at",
+		"agg.*",
+	).
+		From("history_markets hm").
+		JoinClause(fmt.Sprintf("JOIN LATERAL (%s) agg ON true", aggregationSQL), args...).
+		Join("history_assets base_assets ON hm.base_asset_id = base_assets.id").
+		Join("history_assets counter_assets ON hm.counter_asset_id = counter_assets.id").
+		Where(sq.GtOrEq{"hm.last_trade_at": q.startTime.ToTime()})
+
+	switch q.pagingParams.Order {
+	case "asc":
+		sql = sql.
+			Where("(hm.base_asset_id, hm.counter_asset_id) > (?, ?)", base, counter).
+			OrderBy("hm.base_asset_id asc, hm.counter_asset_id asc")
+	case "desc":
+		sql = sql.
+			Where("(hm.base_asset_id, hm.counter_asset_id) < (?, ?)", base, counter).
+			OrderBy("hm.base_asset_id desc, hm.counter_asset_id desc")
+	}
+
+	return sql.Limit(q.pagingParams.Limit), nil
+}
+
+// updateMarket records a trade closed at `closedAt` between the assets with
+// ids `baseAssetID` and `counterAssetID` in the history_markets table.
+func (q *Q) updateMarket(baseAssetID, counterAssetID int64, closedAt time.Time) error {
+	sql := sq.Insert("history_markets").
+		Columns("base_asset_id", "counter_asset_id", "first_trade_at", "last_trade_at").
+		Values(baseAssetID, counterAssetID, closedAt, closedAt).
+		Suffix(`ON CONFLICT (base_asset_id, counter_asset_id) DO UPDATE SET
+			first_trade_at = LEAST(history_markets.first_trade_at, excluded.first_trade_at),
+			last_trade_at = GREATEST(history_markets.last_trade_at, excluded.last_trade_at)`)
+
+	_, err := q.Exec(sql)
+	return err
+}
@@ -4,9 +4,11 @@ import (
 	"encoding/json"
 
 	sq "github.com/Masterminds/squirrel"
+	"github.com/cowry-network/go/amount"
 	"github.com/go-errors/errors"
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/toid"
+	strtime "github.com/cowry-network/go/support/time"
 	"github.com/cowry-network/go/xdr"
 )
 
@@ -133,19 +135,106 @@ func (q *OperationsQ) ForTransaction(hash string) *OperationsQ {
 	return q
 }
 
+// OfType filters the query to only operations of the given types.
+func (q *OperationsQ) OfType(types ...xdr.OperationType) *OperationsQ {
+	q.sql = q.sql.Where(sq.Eq{"hop.type": types})
+	return q
+}
+
+// ForAsset filters the query to only operations whose details indicate that
+// the operation is for a specific asset, including the source asset of path
+// payments and either asset of offers.  Account creations, merges and
+// inflation, which only ever move lumens, are included for the native asset.
+func (q *OperationsQ) ForAsset(asset xdr.Asset) *OperationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	filter, err := assetDetailsFilter(
+		"hop",
+		[]string{"", "source_", "selling_", "buying_"},
+		asset,
+	)
+	if err != nil {
+		q.Err = err
+		return q
+	}
+
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		filter = sq.Or{filter, sq.Eq{"hop.type": []xdr.OperationType{
+			xdr.OperationTypeCreateAccount,
+			xdr.OperationTypeAccountMerge,
+			xdr.OperationTypeInflation,
+		}}}
+	}
+
+	q.sql = q.sql.Where(filter)
+	return q
+}
+
+// ForAmountRange filters the query to only operations whose amount (or
+// starting balance, for account creations) is between `min` and `max`.  A
+// bound of 0 is ignored.
+func (q *OperationsQ) ForAmountRange(min, max xdr.Int64) *OperationsQ {
+	col := amountDetailsColumn("hop")
+	if min > 0 {
+		q.sql = q.sql.Where(col+" >= ?::numeric", amount.String(min))
+	}
+	if max > 0 {
+		q.sql = q.sql.Where(col+" <= ?::numeric", amount.String(max))
+	}
+	return q
+}
+
+// ForTimeRange filters the query to only operations in ledgers closed from
+// `start` included to `end` excluded.  A nil time is ignored.
+func (q *OperationsQ) ForTimeRange(start, end strtime.Millis) *OperationsQ {
+	if q.Err != nil {
+		return q
+	}
+
+	var from, to int64
+	from, to, q.Err = q.parent.idRangeForTime(start, end)
+	if q.Err != nil {
+		return q
+	}
+
+	q.sql = q.sql.Where("hop.id >= ? AND hop.id < ?", from, to)
+	return q
+}
+
+// ForMemo filters the query to only operations of transactions with the
+// provided memo type and memo.  Either filter is ignored when blank.
+func (q *OperationsQ) ForMemo(memoType, memo string) *OperationsQ {
+	if memo != "" {
+		q.sql = q.sql.Where("ht.memo = ?", memo)
+	}
+	if memoType != "" {
+		q.sql = q.sql.Where("ht.memo_type = ?", memoType)
+	}
+
+	return q
+}
+
 // OnlyPayments filters the query being built to only include operations that
 // are in the "payment" class of operations:  CreateAccountOps, Payments, and
 // PathPayments.
 func (q *OperationsQ) OnlyPayments() *OperationsQ {
-	q.sql = q.sql.Where(sq.Eq{"hop.type": []xdr.OperationType{
-		xdr.OperationTypeCreateAccount,
-		xdr.OperationTypePayment,
-		xdr.OperationTypePathPayment,
-		xdr.OperationTypeAccountMerge,
-	}})
+	q.sql = q.sql.Where(sq.Eq{"hop.type": paymentTypes})
 	return q
 }
 
+// IsPaymentType returns true if operations of type `typ` are in the "payment"
+// class of operations.
+func IsPaymentType(typ xdr.OperationType) bool {
+	for _, t := range paymentTypes {
+		if t == typ {
+			return true
+		}
+	}
+	return false
+}
+
 // SuccessfulOnly changes the query to include successful operations only.
 func (q *OperationsQ) SuccessfulOnly() *OperationsQ {
 	q.sql = q.sql.
@@ -173,6 +262,13 @@ func (q *OperationsQ) Select(dest interface{}) error {
 	return q.Err
 }
 
+var paymentTypes = []xdr.OperationType{
+	xdr.OperationTypeCreateAccount,
+	xdr.OperationTypePayment,
+	xdr.OperationTypePathPayment,
+	xdr.OperationTypeAccountMerge,
+}
+
 var selectOperation = sq.Select(
 	"hop.id, " +
 		"hop.transaction_id, " +
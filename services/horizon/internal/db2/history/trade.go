@@ -297,6 +297,25 @@ func (q *Q) InsertTrade(
 	if err != nil {
 		return errors.Wrap(err, "failed to exec sql")
 	}
+
+	err = q.updateMarket(baseAssetId, counterAssetId, ledgerClosedAt.ToTime())
+	if err != nil {
+		return errors.Wrap(err, "failed to update market")
+	}
+
+	err = q.updateTradeRollups(
+		opid,
+		order,
+		baseAssetId,
+		baseAmount,
+		counterAssetId,
+		counterAmount,
+		sellPrice,
+		ledgerClosedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "failed to update trade rollups")
+	}
 	return nil
 }
 
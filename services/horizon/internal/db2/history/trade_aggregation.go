@@ -11,21 +11,35 @@ import (
 	"github.com/cowry-network/go/xdr"
 )
 
-// AllowedResolutions is the set of trade aggregation time windows allowed to be used as the
-// `resolution` parameter.
-var AllowedResolutions = map[time.Duration]struct{}{
-	time.Minute:        {}, //1 minute
-	time.Minute * 5:    {}, //5 minutes
-	time.Minute * 15:   {}, //15 minutes
-	time.Hour:          {}, //1 hour
-	time.Hour * 24:     {}, //day
-	time.Hour * 24 * 7: {}, //week
-}
+// MaxResolution is the largest trade aggregation time window allowed to be
+// used as the `resolution` parameter.
+const MaxResolution = time.Hour * 24 * 365
 
 // StrictResolutionFiltering represents a simple feature flag to determine whether only
-// predetermined resolutions of trade aggregations are allowed.
+// resolutions that are multiples of a minute, up to MaxResolution, are allowed.
 var StrictResolutionFiltering = true
 
+// ResolutionAllowed returns true if `resolution` can be used to bucket trades.
+// When StrictResolutionFiltering is set it must be a multiple of a minute, so
+// that the aggregations can be computed from the precomputed trade rollups.
+func ResolutionAllowed(resolution time.Duration) bool {
+	if resolution <= 0 {
+		return false
+	}
+	if StrictResolutionFiltering {
+		return resolution%time.Minute == 0 && resolution <= MaxResolution
+	}
+	return true
+}
+
+// OffsetAllowed returns true if `offset` can be used to shift buckets of
+// `resolution`.  Offset must be 1) a multiple of 15 minutes, so that buckets
+// can be aligned to any time zone 2) less than or equal to the resolution and
+// 3) less than 24 hours
+func OffsetAllowed(offset, resolution time.Duration) bool {
+	return offset >= 0 && offset%(time.Minute*15) == 0 && offset < time.Hour*24 && offset <= resolution
+}
+
 // TradeAggregation represents an aggregation of trades from the trades table
 type TradeAggregation struct {
 	Timestamp     int64     `db:"timestamp"`
@@ -60,14 +74,11 @@ func (q Q) GetTradeAggregationsQ(baseAssetID int64, counterAssetID int64, resolu
 	offsetDuration := time.Duration(offset) * time.Millisecond
 
 	//check if resolution allowed
-	if StrictResolutionFiltering {
-		if _, ok := AllowedResolutions[resolutionDuration]; !ok {
-			return &TradeAggregationsQ{}, errors.New("resolution is not allowed")
-		}
+	if !ResolutionAllowed(resolutionDuration) {
+		return &TradeAggregationsQ{}, errors.New("resolution is not allowed")
 	}
-	// check if offset is allowed. Offset must be 1) a multiple of an hour 2) less than the resolution and 3)
-	// less than 24 hours
-	if offsetDuration%time.Hour != 0 || offsetDuration >= time.Hour*24 || offsetDuration > resolutionDuration {
+	// check if offset is allowed
+	if !OffsetAllowed(offsetDuration, resolutionDuration) {
 		return &TradeAggregationsQ{}, errors.New("offset is not allowed.")
 	}
 
@@ -117,11 +128,17 @@ func (q *TradeAggregationsQ) WithEndTime(endTime strtime.Millis) (*TradeAggregat
 	}
 }
 
-// GetSql generates a sql statement to aggregate Trades based on given parameters
+// GetSql generates a sql statement to aggregate Trades based on given parameters.
+// Trades are aggregated from the coarsest rollup table fitting the buckets, or
+// from the history_trades table if there is none.
 func (q *TradeAggregationsQ) GetSql() sq.SelectBuilder {
 	var orderPreserved bool
 	orderPreserved, q.baseAssetID, q.counterAssetID = getCanonicalAssetOrder(q.baseAssetID, q.counterAssetID)
 
+	if rollup, ok := tradeRollupFor(q.resolution, q.offset); ok {
+		return q.getRollupSql(rollup, orderPreserved)
+	}
+
 	var bucketSQL sq.SelectBuilder
 	if orderPreserved {
 		bucketSQL = bucketTrades(q.resolution, q.offset)
@@ -158,12 +175,56 @@ func (q *TradeAggregationsQ) GetSql() sq.SelectBuilder {
 		OrderBy("timestamp " + q.pagingParams.Order)
 }
 
+// getRollupSql generates a sql statement merging the precomputed aggregations
+// of `rollup` into buckets.  Start and end times are aligned to the buckets,
+// hence to the rollup, so rollup rows can be filtered by their timestamp.
+func (q *TradeAggregationsQ) getRollupSql(rollup tradeRollup, orderPreserved bool) sq.SelectBuilder {
+	var bucketSQL sq.SelectBuilder
+	if orderPreserved {
+		bucketSQL = bucketRollup(q.resolution, q.offset)
+	} else {
+		bucketSQL = reverseBucketRollup(q.resolution, q.offset)
+	}
+
+	bucketSQL = bucketSQL.From(rollup.table).
+		Where(sq.Eq{"base_asset_id": q.baseAssetID, "counter_asset_id": q.counterAssetID}).
+		Where(sq.GtOrEq{"\"timestamp\"": q.startTime.ToInt64()})
+	if !q.endTime.IsNil() {
+		bucketSQL = bucketSQL.Where(sq.Lt{"\"timestamp\"": q.endTime.ToInt64()})
+	}
+
+	return sq.Select(
+		"timestamp",
+		"sum(count) as count",
+		"sum(base_volume) as base_volume",
+		"sum(counter_volume) as counter_volume",
+		"sum(counter_volume)/sum(base_volume) as avg",
+		"max_price(high) as high",
+		"min_price(low) as low",
+		"first(open ORDER BY open_operation_id, open_order) as open",
+		"last(close ORDER BY close_operation_id, close_order) as close",
+	).
+		FromSelect(bucketSQL, "htrd").
+		GroupBy("timestamp").
+		Limit(q.pagingParams.Limit).
+		OrderBy("timestamp " + q.pagingParams.Order)
+}
+
 // formatBucketTimestampSelect formats a sql select clause for a bucketed timestamp, based on given resolution
 // and the offset. Given a time t, it gives it a timestamp defined by
 // f(t) = ((t - offset)/resolution)*resolution + offset.
 func formatBucketTimestampSelect(resolution int64, offset int64) string {
-	return fmt.Sprintf("div((cast((extract(epoch from ledger_closed_at) * 1000 ) as bigint) - %d), %d)*%d + %d as timestamp",
-		offset, resolution, resolution, offset)
+	return formatBucketTimestamp(ledgerClosedAtMillis, resolution, offset) + " as timestamp"
+}
+
+// ledgerClosedAtMillis is a sql expression for the close time of the ledger of
+// a trade in milliseconds.
+const ledgerClosedAtMillis = "cast((extract(epoch from ledger_closed_at) * 1000 ) as bigint)"
+
+// formatBucketTimestamp formats a sql expression bucketing the timestamp in
+// milliseconds `millis`, based on given resolution and offset.
+func formatBucketTimestamp(millis string, resolution int64, offset int64) string {
+	return fmt.Sprintf("div((%s - %d), %d)*%d + %d", millis, offset, resolution, resolution, offset)
 }
 
 // bucketTrades generates a select statement to filter rows from the `history_trades` table in
@@ -195,3 +256,42 @@ func reverseBucketTrades(resolution int64, offset int64) sq.SelectBuilder {
 		"ARRAY[price_d, price_n] as price",
 	)
 }
+
+// bucketRollup generates a select statement to filter rows from a trade rollup
+// table, with a timestamp rounded to resolution.
+func bucketRollup(resolution int64, offset int64) sq.SelectBuilder {
+	return sq.Select(
+		formatBucketTimestamp("\"timestamp\"", resolution, offset)+" as timestamp",
+		"count",
+		"base_volume",
+		"counter_volume",
+		"high",
+		"low",
+		"open",
+		"open_operation_id",
+		"open_order",
+		"close",
+		"close_operation_id",
+		"close_order",
+	)
+}
+
+// reverseBucketRollup generates a select statement to filter rows from a trade
+// rollup table, with a timestamp rounded to resolution and reversed
+// base/counter.
+func reverseBucketRollup(resolution int64, offset int64) sq.SelectBuilder {
+	return sq.Select(
+		formatBucketTimestamp("\"timestamp\"", resolution, offset)+" as timestamp",
+		"count",
+		"counter_volume as base_volume",
+		"base_volume as counter_volume",
+		"ARRAY[low[2], low[1]] as high",
+		"ARRAY[high[2], high[1]] as low",
+		"ARRAY[open[2], open[1]] as open",
+		"open_operation_id",
+		"open_order",
+		"ARRAY[close[2], close[1]] as close",
+		"close_operation_id",
+		"close_order",
+	)
+}
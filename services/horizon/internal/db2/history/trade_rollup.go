@@ -0,0 +1,249 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/cowry-network/go/support/errors"
+	strtime "github.com/cowry-network/go/support/time"
+	"github.com/cowry-network/go/xdr"
+)
+
+// tradeRollup is a table of trade aggregations precomputed at a fixed
+// resolution as trades are ingested.
+type tradeRollup struct {
+	table      string
+	resolution int64
+}
+
+// tradeRollups are the precomputed trade aggregations, coarsest first.
+var tradeRollups = []tradeRollup{
+	{table: "history_trades_1d", resolution: int64(24 * time.Hour / time.Millisecond)},
+	{table: "history_trades_1h", resolution: int64(time.Hour / time.Millisecond)},
+	{table: "history_trades_1m", resolution: int64(time.Minute / time.Millisecond)},
+}
+
+// tradeRollupFor returns the coarsest rollup whose buckets fit into buckets of
+// `resolution` offset by `offset`, if any.
+func tradeRollupFor(resolution, offset int64) (tradeRollup, bool) {
+	for _, rollup := range tradeRollups {
+		if resolution%rollup.resolution == 0 && offset%rollup.resolution == 0 {
+			return rollup, true
+		}
+	}
+	return tradeRollup{}, false
+}
+
+var tradeRollupColumns = []string{
+	"base_asset_id",
+	"counter_asset_id",
+	"\"timestamp\"",
+	"count",
+	"base_volume",
+	"counter_volume",
+	"high",
+	"low",
+	"open",
+	"open_operation_id",
+	"open_order",
+	"close",
+	"close_operation_id",
+	"close_order",
+}
+
+// tradeRollupMerge merges the aggregation of a trade inserted into a rollup
+// table into the existing aggregation of its bucket.
+const tradeRollupMerge = `ON CONFLICT (base_asset_id, counter_asset_id, "timestamp") DO UPDATE SET
+	count = %[1]s.count + excluded.count,
+	base_volume = %[1]s.base_volume + excluded.base_volume,
+	counter_volume = %[1]s.counter_volume + excluded.counter_volume,
+	high = max_price_agg(%[1]s.high, excluded.high),
+	low = min_price_agg(%[1]s.low, excluded.low),
+	open = CASE WHEN %[2]s THEN excluded.open ELSE %[1]s.open END,
+	open_operation_id = CASE WHEN %[2]s THEN excluded.open_operation_id ELSE %[1]s.open_operation_id END,
+	open_order = CASE WHEN %[2]s THEN excluded.open_order ELSE %[1]s.open_order END,
+	close = CASE WHEN %[3]s THEN excluded.close ELSE %[1]s.close END,
+	close_operation_id = CASE WHEN %[3]s THEN excluded.close_operation_id ELSE %[1]s.close_operation_id END,
+	close_order = CASE WHEN %[3]s THEN excluded.close_order ELSE %[1]s.close_order END`
+
+// updateTradeRollups adds a trade to the aggregations of its buckets in every
+// rollup table.
+func (q *Q) updateTradeRollups(
+	opid int64,
+	order int32,
+	baseAssetID int64,
+	baseAmount xdr.Int64,
+	counterAssetID int64,
+	counterAmount xdr.Int64,
+	price xdr.Price,
+	ledgerClosedAt strtime.Millis,
+) error {
+	priceArray := sq.Expr("ARRAY[?, ?]::numeric[]", int64(price.N), int64(price.D))
+
+	for _, rollup := range tradeRollups {
+		merge := fmt.Sprintf(
+			tradeRollupMerge,
+			rollup.table,
+			fmt.Sprintf("(excluded.open_operation_id, excluded.open_order) < (%[1]s.open_operation_id, %[1]s.open_order)", rollup.table),
+			fmt.Sprintf("(excluded.close_operation_id, excluded.close_order) > (%[1]s.close_operation_id, %[1]s.close_order)", rollup.table),
+		)
+
+		sql := sq.Insert(rollup.table).
+			Columns(tradeRollupColumns...).
+			Values(
+				baseAssetID,
+				counterAssetID,
+				ledgerClosedAt.RoundDown(rollup.resolution).ToInt64(),
+				1,
+				baseAmount,
+				counterAmount,
+				priceArray,
+				priceArray,
+				priceArray,
+				opid,
+				order,
+				priceArray,
+				opid,
+				order,
+			).
+			Suffix(merge)
+
+		_, err := q.Exec(sql)
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to update %s", rollup.table))
+		}
+	}
+
+	return nil
+}
+
+// rollupTradesSQL returns the aggregation of the trades of `trades`, a query
+// selecting rows from the history_trades table, into the buckets of `rollup`.
+func rollupTradesSQL(rollup tradeRollup, trades sq.SelectBuilder) sq.SelectBuilder {
+	ordered := func(column string) string {
+		return column + ` ORDER BY history_operation_id, "order"`
+	}
+
+	return sq.Select(
+		"base_asset_id",
+		"counter_asset_id",
+		"timestamp",
+		"count(*)",
+		"sum(base_amount)",
+		"sum(counter_amount)",
+		"max_price(price)",
+		"min_price(price)",
+		"first("+ordered("price")+")",
+		"min(history_operation_id)",
+		"first("+ordered("\"order\"")+")",
+		"last("+ordered("price")+")",
+		"max(history_operation_id)",
+		"last("+ordered("\"order\"")+")",
+	).
+		FromSelect(trades.Columns(formatBucketTimestampSelect(rollup.resolution, 0)), "htrd").
+		GroupBy("base_asset_id", "counter_asset_id", "timestamp")
+}
+
+// selectRollupTrades selects the columns of the history_trades table that are
+// rolled up, in the form expected by rollupTradesSQL.
+var selectRollupTrades = sq.Select(
+	"history_operation_id",
+	"\"order\"",
+	"base_asset_id",
+	"base_amount",
+	"counter_asset_id",
+	"counter_amount",
+	"ARRAY[price_n, price_d] as price",
+).From("history_trades")
+
+// RebuildTradeRollups recomputes the rollups of the trades closed from
+// `start` included to `end` excluded, both extended to whole days.  Each day is
+// rebuilt in a transaction of its own, which locks the rollup tables so that
+// the trades ingested meanwhile are merged into the rebuilt rollups once the
+// transaction commits, rather than between the rollups being cleared and
+// rebuilt.
+func (q *Q) RebuildTradeRollups(start, end time.Time) error {
+	day := tradeRollups[0].resolution
+	from := strtime.MillisFromInt64(start.UnixNano() / int64(time.Millisecond)).RoundDown(day)
+	to := strtime.MillisFromInt64(end.UnixNano() / int64(time.Millisecond)).RoundUp(day)
+
+	for dayStart := from.ToInt64(); dayStart < to.ToInt64(); dayStart += day {
+		err := q.rebuildTradeRollupsOfDay(dayStart, dayStart+day)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// rebuildTradeRollupsOfDay recomputes the rollups of the trades closed from
+// `from` included to `to` excluded, in milliseconds, in a transaction.
+func (q *Q) rebuildTradeRollupsOfDay(from, to int64) error {
+	tx := &Q{q.Clone()}
+	err := tx.Begin()
+	if err != nil {
+		return errors.Wrap(err, "failed to begin transaction")
+	}
+	defer tx.Rollback()
+
+	tables := make([]string, 0, len(tradeRollups))
+	for _, rollup := range tradeRollups {
+		tables = append(tables, rollup.table)
+	}
+	_, err = tx.ExecRaw(fmt.Sprintf("LOCK TABLE %s IN SHARE ROW EXCLUSIVE MODE", strings.Join(tables, ", ")))
+	if err != nil {
+		return errors.Wrap(err, "failed to lock trade rollups")
+	}
+
+	for _, rollup := range tradeRollups {
+		_, err = tx.Exec(sq.Delete(rollup.table).
+			Where(sq.GtOrEq{"\"timestamp\"": from}).
+			Where(sq.Lt{"\"timestamp\"": to}))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to clear %s", rollup.table))
+		}
+
+		trades := selectRollupTrades.
+			Where(sq.GtOrEq{"ledger_closed_at": strtime.MillisFromInt64(from).ToTime()}).
+			Where(sq.Lt{"ledger_closed_at": strtime.MillisFromInt64(to).ToTime()})
+		_, err = tx.Exec(sq.Insert(rollup.table).
+			Columns(tradeRollupColumns...).
+			Select(rollupTradesSQL(rollup, trades)))
+		if err != nil {
+			return errors.Wrap(err, fmt.Sprintf("failed to rebuild %s", rollup.table))
+		}
+	}
+
+	return errors.Wrap(tx.Commit(), "failed to commit trade rollups")
+}
+
+// TradeTimeRange represents the close times of the ledgers of the first and
+// last trades recorded, nil if there are none.
+type TradeTimeRange struct {
+	First *time.Time `db:"first"`
+	Last  *time.Time `db:"last"`
+}
+
+// TradeTimeRange loads the close times of the first and last trades recorded.
+func (q *Q) TradeTimeRange(dest *TradeTimeRange) error {
+	sql := sq.Select("min(ledger_closed_at) as first", "max(ledger_closed_at) as last").
+		From("history_trades")
+	return q.Get(dest, sql)
+}
+
+// LedgerTimeRange loads the close times of the first and last ledgers ingested
+// from `start` to `end`, inclusive and in either order.
+func (q *Q) LedgerTimeRange(dest *TradeTimeRange, start, end int32) error {
+	if start > end {
+		start, end = end, start
+	}
+
+	sql := sq.Select("min(closed_at) as first", "max(closed_at) as last").
+		From("history_ledgers").
+		Where(sq.GtOrEq{"sequence": start}).
+		Where(sq.LtOrEq{"sequence": end})
+	return q.Get(dest, sql)
+}
@@ -0,0 +1,59 @@
+package history_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cowry-network/go/services/horizon/internal/db2"
+	. "github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/cowry-network/go/services/horizon/internal/test/trades"
+	"github.com/cowry-network/go/xdr"
+)
+
+func TestTradeRollups(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	const minute = int64(time.Minute / time.Millisecond)
+	const hour = int64(time.Hour / time.Millisecond)
+
+	// one trade every minute, with prices 1 to 10
+	ass1, ass2, err := trades.PopulateTestTrades(q, 0, 10, minute, 0)
+	tt.Require.NoError(err)
+	baseID, err := q.GetAssetID(ass1)
+	tt.Require.NoError(err)
+	counterID, err := q.GetAssetID(ass2)
+	tt.Require.NoError(err)
+
+	aggregate := func() []TradeAggregation {
+		aggQ, err := q.GetTradeAggregationsQ(baseID, counterID, hour, 0, db2.MustPageQuery("", false, "asc", 10))
+		tt.Require.NoError(err)
+		var aggs []TradeAggregation
+		tt.Require.NoError(q.Select(&aggs, aggQ.GetSql()))
+		return aggs
+	}
+
+	aggs := aggregate()
+	if tt.Assert.Len(aggs, 1) {
+		tt.Assert.Equal(int64(10), aggs[0].TradeCount)
+		tt.Assert.Equal(int64(5500), aggs[0].BaseVolume)
+		tt.Assert.Equal(xdr.Price{N: 1, D: 1}, aggs[0].Open)
+		tt.Assert.Equal(xdr.Price{N: 10, D: 1}, aggs[0].Close)
+		tt.Assert.Equal(xdr.Price{N: 10, D: 1}, aggs[0].High)
+	}
+
+	// rebuilding after deleting the last five trades recomputes their buckets
+	tt.Require.NoError(tt.HorizonSession().DeleteRange(6, 11, "history_trades", "history_operation_id"))
+	tt.Require.NoError(q.RebuildTradeRollups(time.Unix(0, 0), time.Unix(600, 0)))
+
+	aggs = aggregate()
+	if tt.Assert.Len(aggs, 1) {
+		tt.Assert.Equal(int64(5), aggs[0].TradeCount)
+		tt.Assert.Equal(int64(1500), aggs[0].BaseVolume)
+		tt.Assert.Equal(xdr.Price{N: 1, D: 1}, aggs[0].Open)
+		tt.Assert.Equal(xdr.Price{N: 5, D: 1}, aggs[0].Close)
+		tt.Assert.Equal(xdr.Price{N: 5, D: 1}, aggs[0].High)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	sq "github.com/Masterminds/squirrel"
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/toid"
+	"github.com/guregu/null"
 )
 
 // TransactionByHash is a query that loads a single row from the
@@ -61,6 +62,19 @@ func (q *TransactionsQ) ForLedger(seq int32) *TransactionsQ {
 	return q
 }
 
+// ForMemo filters the query to only transactions with the provided memo type
+// and memo.  Either filter is ignored when blank.
+func (q *TransactionsQ) ForMemo(memoType, memo string) *TransactionsQ {
+	if memo != "" {
+		q.sql = q.sql.Where("ht.memo = ?", memo)
+	}
+	if memoType != "" {
+		q.sql = q.sql.Where("ht.memo_type = ?", memoType)
+	}
+
+	return q
+}
+
 // SuccessfulOnly changes the query to include successful transactions only.
 func (q *TransactionsQ) SuccessfulOnly() *TransactionsQ {
 	q.sql = q.sql.
@@ -88,6 +102,30 @@ func (q *TransactionsQ) Select(dest interface{}) error {
 	return q.Err
 }
 
+// TransactionEnvelopesWithoutMemo loads at most `limit` transactions, ordered
+// by id and after the one with id `afterID`, that have no memo recorded.
+func (q *Q) TransactionEnvelopesWithoutMemo(dest interface{}, afterID int64, limit uint64) error {
+	sql := sq.Select("ht.id, ht.tx_envelope").
+		From("history_transactions ht").
+		Where("ht.memo_type = 'none' AND ht.id > ?", afterID).
+		OrderBy("ht.id asc").
+		Limit(limit)
+
+	return q.Select(dest, sql)
+}
+
+// UpdateTransactionMemo records the memo type and memo of the transaction with
+// id `id`.
+func (q *Q) UpdateTransactionMemo(id int64, memoType string, memo null.String) error {
+	sql := sq.Update("history_transactions").
+		Set("memo_type", memoType).
+		Set("memo", memo).
+		Where("id = ?", id)
+
+	_, err := q.Exec(sql)
+	return err
+}
+
 var selectTransaction = sq.Select(
 	"ht.id, " +
 		"ht.transaction_hash, " +
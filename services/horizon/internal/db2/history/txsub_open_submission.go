@@ -0,0 +1,48 @@
+package history
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AddTxSubOpenSubmission records the transaction `hash`, submitted at
+// `submittedAt`, as open.  Recording an open transaction again is a no-op.
+func (q *Q) AddTxSubOpenSubmission(hash string, submittedAt time.Time) error {
+	sql := sq.Insert("txsub_open_submissions").
+		Columns("hash", "submitted_at").
+		Values(hash, submittedAt.UTC()).
+		Suffix("ON CONFLICT (hash) DO NOTHING")
+
+	_, err := q.Exec(sql)
+	return err
+}
+
+// RemoveTxSubOpenSubmission removes the transaction `hash` from the open
+// submissions.
+func (q *Q) RemoveTxSubOpenSubmission(hash string) error {
+	sql := sq.Delete("txsub_open_submissions").Where("hash = ?", hash)
+
+	_, err := q.Exec(sql)
+	return err
+}
+
+// RemoveTxSubOpenSubmissionsBefore removes the open submissions submitted
+// before `t` and returns how many were removed.
+func (q *Q) RemoveTxSubOpenSubmissionsBefore(t time.Time) (int64, error) {
+	sql := sq.Delete("txsub_open_submissions").Where("submitted_at < ?", t.UTC())
+
+	result, err := q.Exec(sql)
+	if err != nil {
+		return 0, err
+	}
+
+	return result.RowsAffected()
+}
+
+// TxSubOpenSubmissionHashes loads the hashes of the open submissions into
+// `dest`.
+func (q *Q) TxSubOpenSubmissionHashes(dest interface{}) error {
+	sql := sq.Select("tos.hash").From("txsub_open_submissions tos")
+	return q.Select(dest, sql)
+}
@@ -0,0 +1,37 @@
+package history
+
+import (
+	"testing"
+	"time"
+
+	"github.com/cowry-network/go/services/horizon/internal/test"
+)
+
+func TestTxSubOpenSubmissionQueries(t *testing.T) {
+	tt := test.Start(t).Scenario("base")
+	defer tt.Finish()
+	q := &Q{tt.HorizonSession()}
+
+	old := "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d"
+	recent := "bcc7a97264dca0a51a63f7ea971b5e7458e334489673078bb2a34eb0cce910ca"
+	now := time.Now()
+
+	tt.Require.NoError(q.AddTxSubOpenSubmission(old, now.Add(-time.Minute)))
+	tt.Require.NoError(q.AddTxSubOpenSubmission(recent, now))
+	// adding an open submission again is a no-op
+	tt.Require.NoError(q.AddTxSubOpenSubmission(recent, now))
+
+	var hashes []string
+	tt.Require.NoError(q.TxSubOpenSubmissionHashes(&hashes))
+	tt.Assert.ElementsMatch([]string{old, recent}, hashes)
+
+	removed, err := q.RemoveTxSubOpenSubmissionsBefore(now.Add(-time.Second))
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int64(1), removed)
+
+	tt.Require.NoError(q.RemoveTxSubOpenSubmission(recent))
+
+	hashes = nil
+	tt.Require.NoError(q.TxSubOpenSubmissionHashes(&hashes))
+	tt.Assert.Empty(hashes)
+}
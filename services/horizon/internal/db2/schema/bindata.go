@@ -8,7 +8,17 @@
 // migrations/14_fix_asset_toml_field.sql
 // migrations/15_ledger_failed_txs.sql
 // migrations/16_ingest_failed_transactions.sql
+// migrations/17_add_ledger_entry_changes.sql
+// migrations/18_add_ledger_entry_changes_key.sql
+// migrations/19_add_ingestion_jobs.sql
 // migrations/1_initial_schema.sql
+// migrations/20_add_txsub_open_submissions.sql
+// migrations/21_add_history_filter_indexes.sql
+// migrations/22_add_transactions_memo_index.sql
+// migrations/23_extend_asset_stats.sql
+// migrations/24_create_history_markets.sql
+// migrations/25_add_trade_rollups.sql
+// migrations/26_add_history_asset_and_amount_indexes.sql
 // migrations/2_index_participants_by_toid.sql
 // migrations/3_use_sequence_in_history_accounts.sql
 // migrations/4_add_protocol_version.sql
@@ -85,7 +95,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x5c\x6d\x6f\xdb\x38\x12\xfe\x9e\x5f\x41\x2c\x0a\xc4\xc6\x39\x3d\xcb\x89\xd3\xbc\x6c\x0b\x78\x1d\x35\x35\xd6\x55\xba\xb6\x7c\xdd\xa2\x28\x08\x5a\xa2\x6d\x5e\x65\x51\x15\xe9\x6e\xb2\x87\xfb\xef\x07\xbd\x5a\x2f\xa4\x28\xd9\x4a\x7b\xdf\x62\x6b\xfc\xcc\x33\xc3\x19\x0e\x39\xa4\x72\x76\x76\x72\x76\x06\x3e\x50\xc6\xd7\x3e\x9e\xff\x31\x05\x36\xe2\x68\x89\x18\x06\xf6\x6e\xeb\x9d\x9c\x9d\x9d\x04\xcf\xef\x76\x5b\x0f\xdb\x60\xe5\xd3\xed\x5e\xe0\x3b\xf6\x19\xa1\x2e\xb8\x7e\x79\xf9\x52\xcb\x48\x2d\x9f\x80\xb7\x86\xc1\xcf\x0b\x22\x27\x73\xdd\x04\x8c\x23\x8e\xb7\xd8\xe5\x90\x93\x2d\xa6\x3b\x0e\x5e\x83\xfe\x6d\xf8\xc8\xa1\xd6\xd7\xf2\xb7\x96\x43\x02\x69\xec\x5a\xd4\x26\xee\x1a\xbc\x06\xa7\x0b\xf3\xed\xd5\xe9\x6d\x02\xe7\xda\xc8\xb7\xa1\x45\xdd\x15\xf5\xb7\xc4\x5d\x43\xc6\x7d\xe2\xae\x19\x78\x0d\xa8\x1b\x63\x6c\xb0\xf5\x15\xae\x76\xae\xc5\x09\x75\xe1\x92\xda\x04\x07\xcf\x57\xc8\x61\x38\xa7\x66\x4b\x5c\xb8\xc5\x8c\xa1\x75\x28\xf0\x17\xf2\x5d\xe2\xae\x6f\x63\xee\x18\xf9\xd6\x06\x7a\x88\x6f\xc0\x6b\xe0\xed\x96\x0e\xb1\x7a\x81\xb1\x16\xe2\xc8\xa1\x81\x58\xe4\x4f\x03\x6d\xf1\x0d\x58\x11\x9f\x71\x88\xd6\xeb\x0e\x72\x9f\xb0\x13\x5a\xdd\x03\xfb\xbf\xbb\xb7\xc0\x7c\xf2\xf0\x0d\x78\xbb\x30\xc6\xe6\xe4\xc1\xb8\x05\x73\x6b\x83\xb7\xe8\x26\xc6\xbe\x05\x0f\x7f\xb9\xd8\xbf\x01\x67\xe1\x40\x8c\x67\xfa\xc8\xd4\x53\x69\x35\x3e\x98\xe9\xe6\x62\x66\xcc\x33\xdf\x9d\x00\x00\xc0\x74\x64\xdc\x2f\x46\xf7\x3a\x60\xdf\x1c\x30\x79\xff\x7e\x61\x8e\x7e\x9b\xea\x60\x6e\xce\x26\x63\x33\x94\x18\xcd\xc1\x0b\xf8\x02\xcc\xf5\xa9\x3e\x36\xc1\x0b\x2d\xf8\x74\x7b\x92\x37\xcf\x41\xcf\x6a\x9d\x0a\xbe\x35\xe3\x06\x22\xe3\xb6\xe8\x11\x7a\x3e\xb1\x70\x48\xc1\xdd\x6d\xb1\x4f\xac\xcf\x5f\x7a\x20\xfd\xf3\x58\xfb\x6a\x68\x48\x4d\x4c\xbf\x3a\xc8\xc2\xce\x09\x00\xe3\xd1\x5c\x07\x1f\xdf\xe9\x06\x78\xa1\x7d\xd6\xbe\xfc\xf3\x85\xf6\x79\xf0\xe5\xcd\x8b\x41\xf8\xf7\xe0\xf3\xe0\x0b\x30\xa3\x87\x40\x9f\xce\xf5\xc0\x29\xba\x71\xd7\x15\x7a\x86\xb8\xcf\xed\x19\xb5\x86\xe7\xf6\xcc\xaf\x87\x78\x26\xcc\xc7\x8e\x20\x03\x46\xf7\xf7\x33\xfd\x7e\x64\xea\xf5\x1c\x91\x8a\x97\x11\x43\xc6\x00\xcc\x03\x5f\x05\xf3\x57\x32\x03\xf4\xa2\xaf\xcd\x4f\x1f\x74\xf0\x3a\x9b\x11\x5d\x51\xd6\xb6\xca\xb1\x08\x58\xa0\x98\xa4\x71\x7d\x86\x69\x62\x74\xca\x11\x75\x30\x4b\x11\x68\x81\x69\x2e\x21\xf3\x74\xf7\x51\x56\x66\x9b\x04\x6b\xab\x6c\x05\xa0\x45\xb6\xd9\x24\xa9\x64\x1b\x54\x2e\x1b\xaf\xd0\xce\xe1\x90\xa3\xa5\x83\x99\x87\x2c\x1c\xd4\xd1\xd3\xdb\xfc\xd3\xbf\x08\xdf\x40\x4a\xec\x4c\x69\xcc\xd9\x8a\x18\xc3\x1c\x06\x15\x9c\x25\x26\x86\x09\x56\xcf\xbc\x28\x17\x33\x18\xb1\x45\xc4\x06\x4b\xb2\x26\x2e\x07\xc6\x83\x09\x8c\xc5\x74\x1a\x99\x83\xb6\x74\xe7\x72\x60\x6d\x90\x8f\x2c\x8e\x7d\xf0\x1d\xf9\x4f\xc1\x0a\x20\x2f\xe6\xee\xb6\x10\x59\x56\x20\xcb\x00\x71\x39\x5e\x63\xbf\x20\xb2\x72\xd0\x9a\x01\xb6\x45\x8e\x53\x56\xc3\xe9\xd6\x29\x2b\xe9\x0c\x86\xc3\x6e\x2a\x59\x1e\xf6\x35\xf5\x3d\xb8\x25\x6b\x1f\x05\x2b\x8a\xc3\xdd\x51\xc0\xd9\xbb\x84\xe3\xc7\x92\x43\x3c\xcf\x21\xd8\x86\x88\x83\x60\x95\xc4\x38\xda\x7a\x20\x18\xb3\xf0\x23\xf8\x9b\xba\xb8\x4c\x74\x43\x18\xa7\xfe\x53\xea\x22\x48\x6c\xc8\xf0\xb7\x84\xf0\x5c\xff\x63\xa1\x1b\xe3\x9a\x9c\x13\x69\x19\x6a\x1c\x86\xa3\x99\x09\x3e\x4e\xcc\x77\x40\x0b\xbf\x98\x18\xe3\x99\xfe\x5e\x37\x4c\xf0\xdb\xa7\xf8\x2b\xe3\x01\xbc\x9f\x18\xff\x1a\x4d\x17\x7a\xfa\x79\xf4\xe7\xfe\xf3\x78\x34\x7e\xa7\x03\x4d\x65\xcc\xc1\x6e\x2f\x02\x95\x42\xf1\x4e\x7f\x3b\x5a\x4c\x4d\xe0\xe2\x47\xfe\x1d\x39\x9d\x53\x89\xc5\xa7\x37\x37\x3e\x5e\x5b\x0e\x62\xac\x5b\x1c\x2e\xdb\xf6\x31\x63\x82\xd8\xba\xbc\xe8\x56\x0c\x54\x90\x20\x2d\x58\x16\xc2\xec\xed\x12\x67\x46\x94\x8d\xfc\xc9\xc3\x62\x9a\x42\x71\x8b\xda\x22\x71\x6d\x20\x16\x27\x8c\xed\xb0\x2f\xf8\xc1\xf0\xb2\x2a\xc3\xf2\x86\xb4\x1c\xb6\x59\xcc\x1f\x16\xb4\x55\x86\x80\x87\x8f\x86\x7e\x07\x7e\xfb\xa4\xb0\x68\x34\x35\xf5\x99\xc2\xa0\x14\xab\xf0\xf8\x25\xb1\x65\xdc\xf0\x6a\x85\xad\x16\xa2\x2e\xc6\x89\xc3\xae\x90\x33\x50\x36\xd3\x27\x72\xd4\xc3\xd1\x3c\x28\x95\xfc\x85\xfa\x36\xf6\x7f\x91\x44\x73\x18\xc7\xe2\x47\x36\xe6\x88\x38\x0c\xfc\x9b\x51\x77\x29\x0f\x36\x07\xdb\x6b\xec\x1f\xef\x87\x18\x27\xf6\x03\xc3\xdf\x76\xd8\xb5\x64\xdc\x22\x61\xb8\x41\x6c\x53\x2b\x0b\x3d\x1f\x7f\x27\x74\xc7\xa0\xf2\x87\xb1\x5b\x7c\xe4\x32\x14\x6d\x7d\xc3\x81\x48\x79\x24\xb3\x5c\xbf\xa0\x61\x3f\x10\xf5\xe4\x2d\x87\x32\x51\x61\x0a\x36\xf2\x69\x6d\x2a\xfe\xc6\xc7\x88\x2b\x7f\x14\xc9\xee\x3c\xbb\xb6\x6c\x1a\x3a\xf1\xc7\xad\x47\x7d\x8e\x7d\x98\xf4\x22\x8a\xb6\x68\xa5\xf5\x00\x47\x0e\xb4\x28\x71\x99\x38\x06\x57\x18\x43\x8f\x52\x47\xfc\x74\x89\x18\x86\x2b\x2c\x1b\xeb\xf0\xb1\x8f\x19\xf6\xbf\xcb\x44\x82\x75\x28\x7f\x84\xe1\x32\x89\xfc\x2d\x93\xf2\x7c\xca\xa9\x45\x1d\xa9\x5d\xc5\x31\x4a\x82\x05\x23\x1b\xfb\xe1\xf2\x22\xfa\x9e\xed\x2c\x0b\x33\xb6\xda\x39\x50\x1a\x28\xb1\xe1\x88\x38\xd8\x96\x4b\xc9\xd3\x6a\x1f\x4f\x1e\xf2\x39\xb1\x88\x87\xda\xa8\xde\x62\x58\x55\xcd\xab\x3f\xdb\xa8\xe7\xaf\xa6\x26\xb7\x5b\xc6\x2a\x75\xfc\xa8\xb2\xd6\xc8\xd0\x23\xcb\x5c\xa5\xae\x72\xd9\x13\x8b\x57\x94\xc1\xf4\x07\x2d\xc6\xa6\x6a\x9b\x93\x4d\x27\xe9\x56\x28\x58\xf9\x5b\x91\x29\x61\x05\x3c\xb2\x00\xc6\x99\x4f\x77\x7e\xb0\x7f\x8c\xa2\x5b\x52\x7a\x92\xe9\xe4\xf4\xf4\xe6\x46\xbe\x15\x93\xe7\x01\xf7\x91\x8d\x8f\x77\x67\x04\x53\x58\x57\x1c\xbb\x5e\x88\xa7\xc4\x43\xaa\x17\x5d\xad\xb0\x2f\x55\x1b\xce\xf2\xaa\x55\x4f\x24\x14\x2d\x91\x2b\x45\xa2\x7d\xb0\x50\x20\xd4\x80\x7d\xa5\xae\x54\xae\x52\x5d\x2a\x55\xa1\x31\xa4\x44\x18\x64\xd8\x71\xb0\x0f\x96\x94\x3a\x18\xb9\x49\x4d\x22\x16\x86\x6e\xae\xfe\x46\xdf\xe5\x6b\x72\x88\x51\xf0\x60\x9e\x81\xf0\xe1\xf8\xc1\x98\x9b\xb3\xd1\xc4\x30\x0b\x61\x01\x33\x7e\x82\x61\xaf\x1f\x8c\xdf\xe9\xe3\xdf\x41\xa7\x93\xf5\xe0\x1b\xd0\xef\x76\x55\x50\xa2\x9f\x27\x4e\xfb\xb5\xe4\xc7\x1a\x78\x39\x9f\x16\xe0\x0b\x0e\x0f\x09\x56\xa6\x52\x3a\x53\xb4\x5a\x47\x65\xc0\x75\x2b\x69\x9d\x29\xec\x98\x5a\x2a\xe3\xd7\x6e\x35\x55\x68\xf9\x51\xf5\xb4\xa1\xb1\x47\x56\x54\x85\xb6\x72\x4d\x95\xfd\xa0\xa2\xaa\x66\x7e\xd2\x6a\xac\x26\xf1\x99\xa5\x54\x7b\x13\x15\xcf\xfd\x8a\xad\x59\xdd\xc2\x5b\x5d\x43\x85\xb2\x7b\xd5\xf2\x5d\x06\x92\xa6\x9e\x6c\x87\xf6\x53\xf6\x58\xfc\x11\x62\xf7\x3b\x76\xa8\x87\x45\x7d\x4b\xfe\x18\xec\x78\x76\x0e\x97\x3c\xdc\x62\x8e\x24\x8f\x02\x2f\xc8\x1e\x33\xb2\x76\x11\xdf\xf9\x58\xd4\x62\xbb\xbe\xec\x7e\xfe\xb2\x5f\xbc\xfc\xe7\xbf\xa2\xe5\xcb\xe7\x2f\xc5\xad\x17\xde\x52\x49\x37\x6c\x8f\xe5\x52\x17\x57\x2e\x86\xf6\x58\x65\x98\xd8\x32\xb2\xc5\x70\x49\x77\xae\x1d\xb6\xac\xaf\x7c\xe4\xae\x71\x71\x3b\x96\xd4\x56\x55\x6b\x0c\x10\x3b\xc9\xaa\x98\x63\xad\xa9\x20\x4a\xab\x07\x63\x5a\x6c\x13\x81\xe8\xf9\xf8\x61\xba\x78\x6f\x04\x43\x3d\xd7\xcd\x8a\x7e\x68\xb6\xf3\x94\xed\x86\x36\xdb\x2f\xb4\x67\x84\x04\xbf\x91\x51\x95\xfb\x8c\x3a\x46\x4a\x2b\x6a\x6b\x66\x4a\x35\x34\x32\x54\x31\xfd\x8b\x4d\xbd\x43\x1c\x81\x15\xf5\x15\xa7\x42\xe0\x6e\x64\x8e\x14\xe6\x49\x20\xab\x4e\x57\xea\xc0\x4e\x8c\xb9\x3e\x33\xc1\xc4\x30\x1f\x4a\x27\x2c\x61\x21\x9e\x83\xce\xa9\x06\x89\x4b\x38\x41\x0e\x64\x21\xd6\x4b\xf6\xcd\x39\xed\x81\xd3\x41\x5f\xbb\x3e\xeb\x0f\xce\x06\x1a\xd0\xce\x6f\x86\x17\x37\xe7\x17\x2f\xfb\xe7\x83\xfe\xe0\xea\x1f\x7d\xed\xb4\x7b\x5b\x0f\x7d\x00\x89\x6b\xe3\xc7\xbc\x57\x97\x4f\x90\x53\x62\x57\x6a\xba\xb8\xbc\xd6\x2e\x9b\x68\x3a\x87\x3b\x86\xd3\x6a\x02\x89\x0b\x8b\x67\x15\x95\xfa\x86\xd7\x97\xaf\x06\x4d\xf4\x5d\x40\x64\xdb\xb0\xd8\x7f\xaa\xd4\xf1\xaa\x3f\xbc\xd2\x9a\xe8\x18\xc2\xa8\x74\x25\xab\xe8\xf0\xdc\xb2\x52\xc5\x95\x76\x31\x6c\xa2\xe1\x32\xd1\x10\x4f\x60\x35\x34\x5c\xf7\xaf\x1a\xa9\x78\x05\xb7\xd4\x26\xab\xa7\xda\x46\x68\xfd\x61\xbf\x51\x90\x5d\xe5\x8c\x88\x72\xb0\x86\x1a\x6d\x38\x7c\x75\xde\x4c\x4f\x30\xe4\x68\xbd\xf6\xf1\x1a\x71\xea\x57\x46\x94\x36\xb8\xb8\x3e\xbf\x68\x02\x7f\x1d\xc2\x47\x9d\x49\xf8\x68\xfb\xd5\xe8\x57\xfd\xeb\x26\xe0\x5a\x3f\x44\x8f\xc7\x20\xdc\x8e\x56\xe2\x9f\x6b\x83\xeb\x66\x0a\xb4\xac\x82\x74\x7f\x13\x64\x7f\xb5\xa2\x8b\xeb\x66\xa3\xa0\x0d\x72\xe3\x1c\xef\x28\xa3\xdb\x6e\x95\x9a\x2e\x86\xfd\x7e\xa3\x01\xd1\xce\x23\x73\xd2\x7d\x78\xf5\x80\x0f\xfb\xda\x55\x33\x97\x5d\xc0\x15\x79\x8c\xad\xe1\x74\xeb\xc0\x15\xc1\x4e\xe5\xbc\xa8\x0d\xb5\x57\xfd\x57\x8d\x94\x0c\x93\x03\x92\xa4\x71\xfd\xa8\x30\xe3\x42\x1b\x34\x33\xe3\x12\x12\x77\x8d\x19\x87\xe5\xd6\xb8\x42\xd5\xf0\xf2\x32\x19\x7b\x49\x0d\xac\x3c\xeb\x6e\x52\x5b\x1b\xdd\x03\x08\x96\x0b\x0a\xdc\xf8\xee\xd4\xfe\xda\xe3\x4b\x86\xab\xcf\xc8\x7b\x40\xeb\x45\x17\x4a\x6a\x98\x5b\x3e\xfe\x3e\xc2\xd8\xca\x23\xd7\x56\x4c\xcd\x2d\x7f\x9b\x18\x2a\x3a\x72\x3d\x62\xc9\x54\x75\x82\xd9\x02\x6c\x8d\x13\x9c\xc3\x87\xa9\xd9\x11\x42\x1b\xc3\x56\xbd\xc0\x6f\x32\x8c\x92\x23\x83\x16\x5c\x2e\xe8\x9c\xb7\x83\xaa\x6e\x22\x1e\x3e\x94\x4d\xbb\x57\x6d\x0c\xa6\x6a\x13\xd3\x64\x38\xa5\xbd\xaa\xe6\x2e\xc9\xde\x74\xcb\xd6\x6d\xef\x2b\x7e\x4a\xa0\xf7\x7d\xe3\xa6\xfb\xc0\x0c\x62\x74\xb1\xf5\xee\x2e\xdb\x85\x2e\x2a\x04\x1f\x66\x93\xf7\xa3\xd9\x27\xf0\xbb\xfe\x09\x74\x88\xad\xba\xd0\x56\xfc\xdc\x12\xeb\x02\xaa\x88\xb9\x48\xb1\x92\x7d\xa1\x83\x51\x98\x9d\xf7\xd7\x96\xe0\xfe\xc2\x13\xcc\xde\x4e\x82\xad\x58\x97\x57\x2b\x32\xee\x20\x62\x60\x61\x4c\xfe\x58\xe8\xa0\xb3\x17\xef\x65\x6e\x6e\xf5\x72\xf7\xac\x1a\xba\xa6\x9d\x61\x6d\x6c\x78\xa3\x41\x95\x74\x74\x14\x73\x79\xbb\x96\x89\x95\x54\x59\x5a\x41\xab\xb6\xe5\xd2\x26\x8f\x72\xea\x6b\xd7\x7a\x99\x9a\x2a\xfb\x2b\xa9\x29\x3d\x10\x85\xf4\xf2\x29\x8c\xf6\xc4\x90\x89\x71\xa7\xff\x59\xef\xd0\x20\x14\xcd\xa3\x80\x07\xa3\x98\x0c\x8b\xf9\xc4\xb8\x07\x4b\xee\x63\x9c\xcd\x2e\x39\x9b\x28\xc7\x8e\xe7\x13\xdf\x89\xac\xc5\x48\x92\xd7\xcb\x74\x9d\x7d\x30\x9d\x3d\x44\x96\x49\xee\x84\x25\xcf\x27\x12\xee\x95\x8e\x30\x44\xe4\x36\x88\x6d\x8e\x61\x16\x9e\xe4\xd4\xa2\x55\x3c\xff\x11\xb1\x89\x96\xc5\xc7\xf0\x89\x10\xea\x31\x2a\x1c\x2e\xf5\xca\xe7\x48\xc2\x94\x87\x38\x88\x8d\xf0\xf9\x01\x4c\xe3\x2a\x11\x11\x2e\xc0\x65\x69\x27\x77\x34\x73\x8c\x45\x57\x2a\x7a\xc9\xf5\x09\x19\xd9\x7d\x33\xfb\x48\x9a\xc4\xae\x4d\x70\x7f\x7e\xdc\x13\xde\x03\x51\x90\xa6\x1e\xf4\xda\xe2\x1d\x63\x65\xa9\x4b\x4a\xd5\x41\x96\x88\x0d\xe0\x8f\xed\x19\x10\x63\x49\x62\xfa\x40\x13\xf2\x97\x01\xca\x46\x50\x2f\x88\xca\x0d\x3d\xc8\x86\x98\xfc\x1e\xe3\x50\xe7\x57\x3b\x3a\xbd\x5a\x1b\x4c\xd5\xc7\xfb\x3a\x0f\x97\xa5\x9c\xdc\x13\xce\x71\x14\x33\xca\xfa\xb5\x2d\x5a\x25\xcc\x7a\xd3\x9b\x88\x20\x8f\x86\x84\x1f\x33\xac\x7b\x8c\xc3\x43\x52\x15\x7e\xdc\xb7\x03\x25\xd9\x1b\x5a\x47\x10\x2e\x83\x15\x98\xdb\xb8\xc0\xb3\x70\x35\xac\x9a\x60\xd8\x7e\x6d\x87\x5e\x08\x55\x8b\x5c\xd2\xf3\x95\x52\x2b\x5c\x3a\x3b\x9a\x5f\x01\x4f\x45\xb2\x7c\xe7\x4d\xc9\xb4\x1d\x3f\xe6\xd0\xea\xb2\x54\x7a\xb3\x1d\x6e\xb5\x38\x55\x73\x49\x18\x3b\x94\x7e\xdd\x79\xc7\x31\xca\x63\xd5\x1e\xd1\xe4\x56\x9d\x90\x9f\x87\x88\x1f\xfe\x7b\x80\x56\x18\x16\xd1\xea\xe5\x6d\x4c\xb0\x57\xba\x08\xd8\x2b\x5d\x26\x95\x18\xd1\xc2\xbc\x1d\xe3\xa8\x18\x37\x5c\x1d\x05\xa8\xad\x79\xb7\x81\x63\x95\x7e\x8b\xce\xd1\x4b\x67\x0b\xd4\x85\xf1\x1b\x76\xc7\x3a\x54\xa9\x20\xb7\x4f\x4b\xde\x18\xcc\xef\x8c\x22\xc1\x06\xdc\x8f\x8f\x83\x2a\x6c\x35\x63\x41\x96\xe5\x01\xe3\x55\x78\x80\xc7\x9f\xbc\xc3\xf7\xe0\x95\xa8\xca\x65\x7f\x20\xa4\x20\x1a\xaf\xa1\x02\xc8\x34\x88\x5a\x62\x2b\x82\x56\x2e\xdf\xea\x46\x72\x06\xbc\xed\x60\xc8\x41\x1f\xb2\xde\x94\xc3\x15\x5e\xa7\x6a\xdf\xd1\xa5\x17\xb6\x94\xf4\x0b\x3f\xa8\x6f\x4c\xe6\xfd\xb9\x67\xf3\x7f\xf6\x1d\x3d\x95\x25\x19\xd9\xfa\x46\x88\xde\x06\x7c\x36\x6b\x84\xaf\x1e\xaa\xcc\x12\xfd\xa8\xbe\x7d\x49\x13\xe5\xd9\x6c\x4a\xef\xe1\xaa\xec\x90\x76\xbb\xf2\xd0\xfb\x13\xc1\xe7\x48\xed\x22\xba\x70\x03\xdc\x34\xc1\xf3\xa0\xf9\x2d\x54\x4b\x19\x5e\xa5\xa2\x8e\x0d\x8a\x7d\x5d\xa5\xb2\xf6\xca\x57\x19\xb8\x16\x77\x75\x11\xcb\x6e\xb6\x9f\x23\x6c\xca\xf8\x07\x6f\xf5\xa3\x9b\x41\x49\x21\x4f\x3a\x8c\x70\x49\xe9\xd7\x83\xbd\x5c\x81\xa9\x5c\x22\x74\x3a\xc9\xbb\x6d\x67\x6f\xde\x80\x53\x46\x1d\x3b\x73\x9a\x76\x7a\x73\xc3\xf1\x23\xef\x76\x7b\x40\x2e\x68\x51\xbb\x9e\x60\xd4\x8b\x97\x8b\x2e\xe9\x6e\xbd\xe1\xb5\xd4\xe7\x44\xab\x09\xe4\x44\x0b\x14\xba\xe0\xe3\x3b\x7d\xa6\x47\x41\x06\x5e\x83\xf3\x73\xc9\xe9\x45\xf9\x20\x9a\xd8\x70\x95\x39\x26\x7a\xfb\xfb\x8f\x39\x8e\x8e\xd5\x82\xb7\x0f\x33\x7d\x72\x6f\xa4\x47\x40\x60\xa6\xbf\xd5\x67\xba\x31\xd6\xe7\x85\x53\x91\xf0\xe9\x83\x01\x16\x1f\xee\x82\x90\x99\xe9\xd1\x3f\x74\x0a\xbe\xba\xd3\xa7\xba\xa9\x83\xf1\x68\x3e\x1e\xdd\xe9\xd5\x2f\x21\x8a\xdf\x1a\x4b\xbb\x08\xed\x39\x23\xaf\x47\x71\x48\x26\x63\x92\xf7\x4f\xb1\x6d\x24\x74\x56\xbc\xd0\x57\x9c\x28\x4a\x3d\x11\x6f\x65\x7f\xba\x1f\xb2\x3c\x44\x5e\x48\xba\x04\xd5\x01\xd3\xcc\x03\xe5\xa6\xd2\x4f\x74\x83\x84\x4c\xde\x17\x82\x36\x58\xbb\x41\x51\x6c\x71\xfc\x3f\x38\x44\x1e\x1a\xa5\x1e\x52\xdd\xe8\x90\xfd\xef\x4b\x60\xd1\xad\xe7\x60\x8e\x43\x1b\xfe\x17\x00\x00\xff\xff\x6c\x88\xa8\x5e\x28\x53\x00\x00")
+var _latestSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x5d\xff\x6f\xdb\x36\x16\xff\xbd\x7f\x05\x51\x14\x88\x83\x73\x7a\xb6\x13\xa7\x49\xba\x15\xf0\x39\x6a\x67\xcc\x75\xba\xd8\xb9\xad\x18\x06\x41\xb6\x68\x5b\x8b\xbe\x78\x92\xdc\x26\x3b\xdc\xff\x7e\x24\x25\x59\xa2\x44\x8a\x94\x44\xb9\x07\x6c\xfb\x61\x8b\xa5\xa7\xcf\xfb\xc2\xc7\xc7\x47\xf2\x91\x3b\x3b\x7b\x71\x76\x06\x3e\x79\x41\xb8\xf1\xe1\xfc\xa7\x29\x30\x8d\xd0\x58\x1a\x01\x04\xe6\xde\xd9\xa1\x77\x2f\xf0\xfb\x5b\xf4\x37\x34\xc1\xda\xf7\x9c\x94\xe0\x0b\xf4\x03\xcb\x73\xc1\xf5\xeb\xcb\xd7\xfd\x0c\xd5\xf2\x19\xec\x36\x3a\xfe\x3c\x47\xf2\x62\xae\x2d\x40\x10\x1a\x21\x74\xa0\x1b\xea\xa1\xe5\x40\x6f\x1f\x82\xef\x41\xef\x2d\x79\x65\x7b\xab\xc7\xe2\xd3\x95\x6d\x61\x6a\xe8\xae\x3c\xd3\x72\x37\xe8\xc5\xc9\xc3\xe2\xfd\xd5\xc9\xdb\x04\xce\x35\x0d\xdf\xd4\x57\x9e\xbb\xf6\x7c\x07\x51\xe8\x41\xe8\xa3\xff\x04\x88\xd2\x73\x63\x8c\x2d\x44\xd0\xeb\xbd\xbb\x0a\x91\x38\xfa\x12\x21\x41\xfc\x7e\x6d\xd8\x01\xa4\xd8\x20\x00\xdd\x81\x41\x60\x6c\x08\xc1\x57\xc3\x77\x11\xd6\xdb\x58\x76\x68\xf8\xab\xad\xbe\x33\xc2\x2d\x7a\xb7\xdb\x2f\x6d\x6b\xd5\xc5\xca\xae\x90\x4d\x6c\x0f\x93\x9d\x11\x7b\xce\x0c\x07\xde\x80\xb5\xe5\x07\xa1\x6e\x6c\x36\x1d\xc3\x7d\x86\x36\xd1\xba\x0b\xd2\xbf\x4f\xdf\x82\xc5\xf3\x0e\x11\xbe\x7f\x98\x8d\x17\x93\xbb\xd9\x5b\x30\x47\x92\x3a\xc6\x4d\x8c\xfd\x16\xdc\x7d\x75\xa1\x7f\x03\xce\x48\x43\x8c\xef\xb5\xd1\x42\x3b\x50\x8b\xf1\xc1\xbd\xb6\x78\xb8\x9f\xcd\x33\xcf\x5e\x00\xf4\xcf\x74\x34\xfb\xf0\x30\xfa\xa0\x81\xe0\x0f\x1b\x4c\x3e\x7e\x7c\x58\x8c\xfe\x35\xd5\xc0\x7c\x71\x3f\x19\x2f\x08\xc5\x68\x0e\x5e\xe9\xaf\xc0\x5c\x9b\x6a\xe3\x05\x78\xd5\xc7\xbf\x90\x76\x94\x7a\xb6\xd1\xaa\x76\x22\x78\x65\xca\x0d\x58\xca\x39\xc6\x93\xbe\xf3\xad\x15\x24\x22\xb8\x7b\x07\xa2\x1f\xbf\xfe\xd6\x05\x87\x3f\x9b\xea\x27\xc1\xe1\xa0\xe2\xe1\x51\x2d\x0d\x3b\xe8\xd9\x78\x34\xd7\xc0\xcf\x3f\x68\x33\xd4\x98\xbf\xf6\x7f\xfb\x27\xfa\xf7\xe0\xb7\x77\xaf\x06\xe4\xef\x01\xfa\x1b\x2c\xa2\x97\x40\x9b\x22\x4a\x64\x14\x6d\x76\x7b\xca\xb4\x0c\xea\x21\x2d\x5b\x46\xcc\xa1\x6d\xcb\x7c\x57\xc7\x32\xa4\x3f\x76\x18\x3d\x60\xf4\xe1\xc3\xbd\xf6\x01\xe9\x28\x67\x88\x03\x79\x11\x91\x48\x0c\xc0\x1c\xdb\x0a\xc7\xaf\x24\x02\x74\xa3\xc7\x8b\xcf\x9f\x34\xf4\x38\xd3\x23\x4e\x59\xbd\x56\xa9\x8c\x79\xc0\x9c\x88\x49\x37\x96\x97\xf0\xd0\x31\x3a\x45\x8f\xaa\x2d\x25\x0b\x34\x27\x29\xd5\x21\x69\x71\x53\x2f\x2b\x4a\x9b\x38\xab\x52\x69\x19\xa0\x79\x69\xb3\x9d\xa4\x54\x5a\x3c\x72\x99\x70\x6d\xec\x6d\x34\xe6\x1a\x4b\x1b\x06\x3b\x63\x05\xf1\x38\x7a\xf2\x96\x7e\xfb\xd5\x0a\xb7\xba\x67\x99\x99\xa1\x91\xd2\xd5\x08\x02\x18\xea\x78\x04\x0f\x12\x15\x49\x07\x93\x53\x2f\xea\x8b\x19\x8c\x58\x23\x0b\xa5\x0c\xd6\xc6\x72\x43\x30\xbb\x5b\x80\xd9\xc3\x74\x1a\xa9\x63\x38\xde\x1e\x3d\x5c\x6d\x0d\xdf\x58\x85\xd0\x07\x5f\x0c\xff\x19\x67\x00\x34\x19\xd2\x56\x37\x56\x2b\x4c\x1b\x00\x84\x02\x37\x88\x94\x26\x59\xdb\x06\x4a\x07\x02\xc7\xb0\xed\x22\x9b\xd0\x73\xec\x22\x93\xce\x60\x38\x3c\x65\x70\xda\xbb\xc6\x3e\xdc\x7a\xbe\xf5\x27\x34\x8b\x6c\x6f\xb5\xf7\xa3\x87\xe9\x02\xf4\x72\x5f\x2e\x2d\x53\x37\xe1\x0e\xa5\x0d\x45\x6d\x92\x6f\x4e\x7a\x27\x37\x37\x22\x65\x8d\xe0\xb1\x29\x50\xd1\x85\x33\x4d\xa2\x07\xae\xb1\x0b\xb6\x9e\x9a\x06\x4e\xd1\x04\x4d\x6d\x1a\xcf\x38\xaf\x84\x2d\x78\x40\x51\xdd\x8d\xe7\xef\x50\x9e\xb7\xf1\x0d\x9c\x0c\xd6\x57\x34\x87\x93\xaa\x18\xc2\xa7\x82\x2f\xef\x76\x28\xbf\x44\x1e\x13\x02\x9c\xe0\x22\xeb\xa0\xec\x18\x77\x37\xf2\x13\xfc\xe9\xb9\xb0\x28\xe8\xd6\x0a\x42\xcf\x7f\x3e\xe8\xa6\x23\x2f\x0a\xe0\x1f\x89\xc0\x73\xed\xa7\x07\x6d\x36\x96\x94\x39\xa1\xe6\xa1\xc6\x11\x64\x74\xbf\x00\x3f\x4f\x16\x3f\x80\x3e\x79\x30\x99\xa1\xcf\x3f\x6a\xb3\x05\xf8\xd7\xe7\xf8\xd1\xec\x0e\x7c\x9c\xcc\xfe\x3d\x9a\x3e\x68\x87\xdf\xa3\x5f\xd2\xdf\xe3\xd1\xf8\x07\x0d\xf4\x45\xca\xd4\x36\x7b\x1e\xa8\xe0\x5a\x49\x3f\x70\x51\x33\x7c\x31\xec\xce\x09\x47\x63\xd4\x4b\x7c\xb8\x59\xa1\x01\x2a\xc8\xf7\x74\xc3\x34\x7d\x34\x09\x60\x84\x85\xcb\x8b\xd3\x92\x86\xc2\xae\xaf\x40\x33\x02\x93\xea\xc5\x0e\x6a\x51\x3f\x0b\x11\x2b\xb6\x98\x4c\x72\x34\x87\x62\x91\xf7\x07\x6c\x72\x2b\x08\xf6\x88\xac\xf8\xc1\xf0\xf2\xb4\xa4\x87\xd1\x8a\x28\x76\xdb\x2c\xe6\xd1\x9c\xb6\x4c\x11\x70\xf7\xf3\x4c\xbb\x45\xbc\x04\x1a\x8d\xa6\x0b\xed\x5e\xa0\xd0\x01\x2b\xf7\xfa\xb5\x65\xf2\x64\x83\xeb\x35\x5c\x29\xf0\xba\x18\x27\x76\xbb\x5c\x9f\xd1\x79\x91\x3b\xa1\xf3\x76\x30\x8a\x83\x5c\xca\x97\x9e\x6f\x42\xff\x25\xc7\x9b\x89\x1f\xb3\x5f\x99\x30\x34\x2c\x3b\x00\xbf\x07\x9e\xbb\xe4\x3b\x9b\x0d\x4d\xf4\xad\x8e\x52\x4a\xf4\x03\x79\xac\x8b\x66\xf0\x8d\x8d\xc2\x02\xfd\x46\x16\x8a\x64\x28\xb1\x53\x24\x5e\x19\x45\x04\xb1\x84\x6b\xcf\x87\x64\x94\xca\x3e\x36\xd6\xb8\x83\xa7\x4f\x63\xd5\x1f\xe1\x33\x79\x28\x32\xbc\x2a\x5b\x27\xe6\x45\x9d\x61\x0f\xdd\x15\x4f\x95\x58\xba\xad\x11\x30\x52\x21\x46\xf8\xdb\xf9\xf0\x8b\xe5\xed\x03\x5d\xf8\x61\xec\x8f\xbe\xe1\x06\x46\xb4\x5c\x44\xda\x57\x98\xe3\xa5\xed\x2b\x47\xbf\xb2\xbd\x80\x95\x11\xe0\xc5\xaf\x43\x52\x90\xff\xc6\x87\x28\x4b\x12\x7d\x14\xd1\xee\x77\xa6\x34\xed\xc1\x23\xe3\x9f\xce\xce\xf3\x91\x59\xf4\x64\xfd\x2e\xaf\x4b\xbf\x90\x43\x87\x86\x8d\xf4\xb6\x50\x1a\xc4\x74\xed\x35\x84\xfa\xce\xf3\x6c\xf6\x5b\xbc\x9c\xa8\x23\x12\x4e\x5b\x93\xd7\x68\x3c\x86\xfe\x17\x1e\x09\x9e\xbb\x85\x4f\x3a\xc9\x3c\x51\x6a\xce\xa1\xda\xf9\x5e\xe8\xad\x3c\x9b\xab\x57\xbe\x8d\x12\x67\x81\x86\x49\xf5\x8d\x60\xbf\x5a\xa1\xfc\x60\xbd\xb7\x75\xae\xa3\xc4\x8a\xa3\xd0\x85\x1a\x81\x4b\xc5\xef\x56\x8e\xe1\x3f\xaa\xc8\x26\x62\x9c\xb8\x5b\x11\x5b\xc6\x83\x3b\x27\x0c\x11\xf9\x90\xd6\xe5\x54\xd1\xd2\x03\xd2\xcb\x84\xd5\x7c\x98\x2c\x08\xd4\xf8\x6e\x7c\x37\x9b\x2f\xee\x47\x13\x34\xac\xe7\x54\xd3\xc9\xf2\x2e\x40\x23\xf8\xf8\x47\xd0\xe9\xd0\x2a\x7e\x57\xd0\xe7\xb4\x24\x83\x4b\x3b\xf1\xce\xf0\x43\x6b\x65\xed\x0c\x15\xb9\x2a\x1b\x56\x94\xe1\xc9\x8f\x1c\xe2\xb1\xa8\xaa\xca\x6a\x93\xb6\x52\x1e\xc7\x4a\xe2\x2a\x29\xda\x30\xa9\x2b\xe5\x55\x4c\xf2\xd8\xe4\x25\x49\xdf\xe1\x03\x85\xbe\x29\x9a\xa4\x67\x63\x18\x77\xcd\x06\xcf\x73\x57\x91\x2a\x24\x9b\x69\x98\xee\xc5\xe1\xd6\xdb\xfb\x78\xa1\x2b\xf2\x6e\xce\x78\x7f\x58\xfe\xa8\xb8\xfa\x91\xd8\x81\x84\xa4\xe6\xe6\x8c\x60\x72\x39\x62\xd3\xdc\x2f\x1e\x87\xea\xa4\x0c\x1e\x4a\xeb\x7d\x2e\xdb\x28\x56\x0a\x32\x58\x89\x31\x23\x22\x89\x96\x6b\xca\x07\x15\x01\x2f\xb9\xc1\xe7\x40\x55\xc2\x91\x88\x64\x05\xa8\xc3\xd9\x36\x32\xe8\x12\x65\x1f\xd0\x70\x93\x44\x00\x2f\x9c\xba\x54\xd2\x13\x3d\xa3\x13\x21\x82\x91\xb3\x20\x2d\x01\xf3\x25\x63\xa8\x8a\xdc\x42\xcf\xd8\x89\x39\x6a\x45\xfa\xbc\x03\xbd\xd3\x53\x11\x54\xd5\x41\x4f\x88\x47\xd9\x34\x07\x9f\x33\x38\x11\x50\xd4\x95\xf4\xbe\xa9\xa8\x37\x21\x24\xd5\xe9\xcb\xcb\x43\x0f\x7a\x59\x02\x53\x96\x90\x7e\xf1\xec\x3d\xea\x72\xf1\x62\x3b\x47\x88\x52\xa2\xad\xb5\xd9\xa6\x8b\xf5\xf9\x2e\xef\x7d\xe5\xbe\x43\xe1\xc4\x2d\x7d\x29\x11\x70\x22\xb2\x92\x10\x4d\x82\x0d\x97\x0b\x79\x2b\xc1\x26\xa6\x63\xf2\x11\x3b\xd0\x56\x99\x03\x6d\xff\x76\xa0\xbf\xa2\x03\x39\xca\x1c\xc8\xf9\xdb\x81\xfe\x62\x0e\x74\x48\x76\x95\x4e\x05\x79\xc0\xb2\x93\x41\x99\x2c\xbc\xc9\x74\x90\x27\x9f\xda\x09\xa1\x80\xcb\xb1\xa6\x84\x15\x95\x6d\x38\x29\x14\x70\x2b\x4e\x0b\x79\x1f\x94\x4c\x0c\x33\x9f\x28\xf5\xd5\xc4\x3f\xb3\x22\x49\x2f\xbe\xc6\xd3\x17\xc1\x92\xae\xec\xdc\xb1\x7c\x1a\xc8\xa4\x4d\x59\xf3\x57\x27\x0d\x6e\xd7\xe3\xad\xec\x7e\x93\xb5\xd9\xf0\x49\x87\xee\x17\x68\x23\xa1\x58\x1b\xcd\xe8\xb5\x0f\x83\xbd\x1d\x72\x5e\x3a\x68\x76\xcd\x79\x85\xad\xc0\x7b\x1d\x58\x1b\xd7\x08\xf7\x08\x9a\x61\xf6\xeb\xcb\x53\x14\x9d\x0f\xf3\xef\xff\xfc\x97\x35\x03\x2f\xc4\x6f\x07\x3a\x1e\x67\xfb\x32\xc5\x72\x91\x19\x24\xca\x22\x30\x56\x11\x26\xd6\x0c\x99\x53\x5f\xa2\x86\x33\x49\x71\xc0\x95\x8f\x77\x49\xf2\xcb\xb8\xc9\xf4\xb0\x18\x17\x2d\xbc\xe5\x43\x1a\xff\x77\x6f\x59\xbf\x4b\xd1\x30\x82\x95\x96\x47\xcb\x35\x19\x76\x3e\x2f\xec\xd2\x46\x8b\xb0\x51\xf7\xe2\x2d\x1d\x18\x52\x14\x91\x7c\xc8\x25\x69\xd2\xd8\x4e\x21\x6a\x7b\x56\xcb\xf7\x2f\xf3\x12\x41\xdf\xf7\xb2\x2b\xe5\x72\xbd\x22\x07\x22\xd7\x3d\x4a\x06\xb3\xf0\x29\xd8\x2f\x75\x92\x60\xa0\x3f\x1c\x2b\x08\x1a\xc5\x43\x36\x5c\xb2\xc6\x23\x1b\x05\xc9\xa7\x61\x23\xbd\x68\x2f\x52\x33\x24\x33\x31\xdb\x1e\x80\xa5\x14\xa9\x39\xdc\x32\xb1\xd3\xc1\x95\x7e\x5d\x32\x94\xc6\x65\x18\xd6\x61\x15\x23\x8e\x4a\x52\xd2\x44\x8e\x73\x37\x9b\xe6\x77\xf2\x41\xf4\x7e\x7c\x37\x7d\xf8\x38\xc3\x11\x00\x17\xe0\xf1\x4b\x56\xb2\xc5\x01\xd9\x82\x95\x6a\x8b\xdc\xea\x94\xe0\xe0\x57\x52\xaa\x74\x71\x5c\x46\x49\x6e\x0e\xad\x4c\x4d\x2e\x87\x4a\x8a\x0a\x12\xbe\x32\x55\x73\xe3\x45\x63\xc5\x72\x78\x52\x6a\x30\x3b\x12\x5b\xe8\x5b\x03\xe5\x0d\x6b\x14\xf9\xcb\x0b\x45\xc1\xed\x68\x31\x12\x88\x2e\x86\xe4\x14\x29\x36\x00\x2f\x2b\x09\x94\x81\x9d\xcc\xe6\x1a\x0a\x95\x93\xd9\xe2\xae\x50\x16\x48\x62\xe1\x1c\x74\x4e\xfa\x68\x90\xb5\x42\xcb\xb0\xf5\x80\x60\xbd\x0e\xfe\xb0\x4f\xba\xe0\x64\xd0\xeb\x5f\x9f\xf5\x06\x67\x83\x3e\xe8\x9f\xdf\x0c\x2f\x6e\xce\x2f\x5e\xf7\xce\x07\xbd\xc1\xd5\x3f\x7a\xfd\x13\x64\x64\x29\xf4\x01\x42\x37\xe1\x13\xed\x67\x4b\xe4\x83\x9e\x65\x96\x72\xba\xb8\xbc\xee\x5f\x56\xe1\x74\xae\xef\xd1\x84\x3a\xc9\xa8\x11\x5b\x3d\x5f\x60\x57\xca\x6f\x78\x7d\xf9\x66\x50\x85\xdf\x85\x6e\x98\xa6\x9e\xdf\xbb\x2f\xe5\xf1\xa6\x37\xbc\xea\x57\xe1\x31\xd4\xa3\x44\x25\x59\xee\x21\x75\xd2\xa5\x2c\xae\xfa\x17\xc3\x2a\x1c\x2e\x13\x0e\x71\x48\x97\xe0\x70\xdd\xbb\xaa\xc4\xe2\x8d\xee\x78\xa6\xb5\x7e\x96\x56\xa2\xdf\x1b\xf6\x2a\x39\xd9\x15\xa5\x44\xdc\x1b\xc5\x6c\xfa\xc3\xe1\x9b\xf3\x6a\x7c\x70\x93\x1b\x9b\x0d\x0a\x35\x06\x72\xad\x52\x8f\xea\x0f\x2e\xae\xcf\x2f\xaa\xc0\x5f\x13\xf8\xa8\xaa\x43\x7f\x32\xfd\x72\xf4\xab\xde\x75\x15\xf0\x7e\x8f\xa0\xc7\x6d\x40\x76\x95\x4a\xf1\xcf\xfb\x83\xeb\x6a\x0c\xfa\x59\x06\x87\x35\x1e\xdc\xfb\xcb\x19\x5d\x5c\x57\x6b\x85\xfe\x80\x6a\xe7\x78\x63\x28\x3a\x5d\x57\xca\xe9\x62\xd8\xeb\x55\x6a\x90\xfe\x79\x5c\x11\x92\x6c\xa7\x95\x37\xf8\xb0\xd7\xbf\xaa\x66\xb2\x0b\x7d\x6d\x3d\xc5\xda\xe0\x82\x7f\xf4\x13\xda\xa5\x71\xb1\x3f\xec\xbf\xe9\xbd\xa9\xc4\x64\x98\x14\x97\x25\x45\x3f\x4f\x02\x35\x2e\x50\xd3\x57\xe2\x70\x19\xcf\xd3\xf4\x62\x59\x91\x80\xd5\xf0\xf2\xb2\x5a\xdb\xbf\x21\x4e\xc6\xaa\x7f\x54\xcc\xe8\x8a\xcb\x08\xd7\x1e\x2a\x66\x16\xf5\xfc\xdc\x14\x40\x29\x8b\x41\xdc\xfd\x99\x53\x46\xc5\xac\xa2\x40\x90\x8c\xbe\x6b\xcb\xc6\x0b\xfe\x24\x10\xa8\x6e\xa5\xc1\x20\x89\x39\x07\x7f\xd3\xc9\x02\x8e\x38\xec\x54\xe7\x75\xae\xa3\x0c\x14\xba\x66\x36\xfa\x28\x66\x71\x91\x0c\x64\xb9\x52\x32\xc5\x6c\x86\x69\xa4\xd6\x7d\xcf\xb6\xf7\x3b\xd5\x1c\x2e\x29\x17\x88\x0c\x66\x60\xd3\x39\xe9\xa8\x50\xc1\x19\x38\xf9\x71\xe9\xe1\x8d\x2a\x79\x77\xa5\x83\x2d\x78\x56\x22\xc0\x8d\xcf\x71\xa6\x47\xb0\x5f\x23\x03\x94\x1e\xfa\xe8\x82\x7e\x37\x3a\xdc\x26\xa1\x6e\xf1\x3c\x47\x03\x65\x4b\xcf\x10\x28\x51\x95\x5a\x2c\xa8\xa2\x28\xeb\x0c\x41\x83\xe9\x94\x74\x49\xbe\x32\x1e\xca\x61\x59\xd5\xb7\x0a\x60\x25\xea\x4b\xeb\x7b\x58\xb5\x02\x47\x15\x1e\x57\xbe\x92\x53\xc5\x03\x39\x05\x8d\x0a\x4c\xce\xa8\xeb\x53\x86\x9a\x2f\x71\x52\x08\xbc\x6d\x0b\xd8\x69\x01\x58\xbc\x55\x5e\xdf\xab\xab\xee\xd1\xaa\xf0\x6b\xd1\xc2\x5d\x15\xcf\xe6\xee\xc8\x36\x30\x7d\xc9\xa6\x54\x03\x54\x89\x5d\x93\xea\xcd\x28\xb7\xd2\xdf\xa4\xd1\xd8\xcb\x94\xcc\x26\x2a\x2c\x25\x52\xcb\x8a\x3b\x34\xdf\x48\x24\x4b\xeb\x24\xab\xae\xb4\x66\x10\xc9\x0e\xc8\xe8\xf6\x36\x5b\x75\x99\x67\x08\x3e\xdd\x4f\x3e\x8e\xee\x3f\x83\x1f\xb5\xcf\xa0\x63\x99\xa2\xe3\xca\xf9\xdf\x8a\xa4\xce\xa1\xb2\x24\x67\x31\x16\x4a\x9f\xdb\xfc\xc8\xa5\x2a\xe9\xa1\xd4\x64\x6e\x8e\xd4\x48\xea\xa6\xc8\xd9\x53\x5d\x89\x76\x34\x5b\x96\x72\xb5\x04\x03\x0f\xb3\x09\x72\x60\xd0\x49\xc9\xbb\x99\x73\xb9\x5d\xea\x14\x6d\x45\xd3\xa8\x69\xd6\xca\x8a\x57\x6a\x54\xce\x66\x90\x20\x3b\x50\xab\x19\x9b\x49\x99\xa6\x25\x62\x49\x6b\xce\xdd\x1f\x12\x8e\x20\x6a\xb5\xe7\xb1\x29\xd3\xbf\x54\x34\xa1\x05\xf2\x1b\x53\x74\xf0\x55\xa3\x1d\x0d\xca\xd2\x85\xc1\x56\x28\x79\xd4\x19\x97\xcf\xa4\x9f\x26\x42\x4e\x66\xb7\xda\x2f\x72\x3b\xe6\x84\x94\x46\x41\xe2\xe6\xbb\xf1\xc3\x7c\x32\xfb\x00\x96\xa1\x0f\x61\x36\x2e\xf0\xa5\x89\xa2\x43\x73\x79\xe2\xb3\xfa\x52\x12\x71\x22\x12\x73\x8f\x0d\x63\xa7\xeb\x0a\xa6\xf1\x5c\x43\xd4\x38\x4e\x66\x25\x16\x71\xc1\x8a\xb0\xaf\x12\xa1\xf4\xb1\xcc\x2e\xbe\x3d\xa4\xa0\xca\xf2\x30\xf3\xaf\x6d\xd9\x14\x22\x6b\x54\xaa\x26\x8e\x36\x6d\x44\xdc\x2d\x14\x9d\xb1\x84\xc3\x55\x23\x4d\x24\x23\x55\x27\x52\x62\xe5\x2b\xf6\x58\xd2\x44\x93\xe8\x26\xf2\xc4\x65\x43\x52\x12\xe5\xca\x01\xbb\xc5\xca\x3f\x66\xdc\xd5\x21\x76\x12\xf2\xbe\xa9\x0b\xe6\xe0\xb2\x62\x27\xd7\x20\x50\x12\xb3\xce\x71\x75\x93\x33\x5b\x3c\x61\xd3\x3d\xfb\x86\x62\x5a\xa6\xb4\x80\x69\xc5\x6f\x97\x79\xf8\x4c\x20\xb4\x0d\x57\xd8\x28\x99\x20\x5e\xd9\x17\x72\x38\x59\xc9\x99\x97\x29\x08\xd5\x48\xef\x21\x68\xa2\x92\x3a\xb7\xc9\x02\xd6\xd3\xae\xa2\xf4\x8a\xfc\x28\x82\x6a\xde\x1e\x35\xb4\xf0\x76\xfa\x4e\x95\x1a\x31\x56\x56\x0f\x4e\x1a\x5a\x4b\x13\xb6\x02\xe1\x93\x3a\x05\x62\x2c\x4e\xa8\xac\xa9\x02\x7d\x2a\xa0\xa8\x84\xf3\x48\x72\x84\xf4\xec\x7d\xfd\xfe\x5d\x80\xca\x6a\x92\x5c\x36\x40\xc7\xfb\xec\x89\x7f\xae\x6c\x3b\xc3\x6a\xde\x41\x33\x58\x42\xb1\xa8\x93\x3c\xdd\xe2\x89\xc9\x82\xa0\xc8\xf5\xf0\xc8\xeb\xd5\x72\x84\x58\xc0\x14\xa3\xae\x07\x97\x7b\xeb\xe1\x82\x13\x9c\x11\x36\x77\x58\x1a\xae\x18\x3c\x0a\xd9\x18\x4b\xa2\xac\x73\xaa\x12\xab\x80\x29\x97\x7a\xb0\x04\x0c\xa3\x26\x09\x9b\x34\x6b\x8a\x51\xbf\x5f\x8b\xfa\x70\xe8\x9b\x7a\xdf\x4c\xdc\x9b\xe4\xc9\xb8\x66\xb8\xb1\x31\x39\xb8\x39\x45\xe2\x63\xb8\x55\x7a\x50\x37\x7b\xc6\x8d\xa3\xcf\xb6\x25\x7d\x8a\xb8\x2c\x7d\xb6\xca\xf5\x71\x5a\xd2\xa7\x88\xcb\xd2\xc7\x51\xad\x0f\x62\x9a\xbd\x22\xa0\x41\x07\x29\x82\x15\x15\x60\x49\x7f\x18\xec\xca\x05\x24\x85\x43\x6a\xc4\x23\x50\x52\xc2\x25\xd5\x4a\x5c\xd1\x72\xb7\x1e\x34\x96\x2f\x87\x27\x12\xb2\x78\xe9\x82\x50\x52\x35\x76\xa4\xd0\x64\xa5\x14\x5a\x53\x8d\x6c\x52\x32\x95\xcb\x92\x48\x6c\x7b\xde\xe3\x7e\xd7\x4c\x22\x1a\x4b\xba\x45\xb9\x49\x0a\xc6\x24\x71\x82\x9c\x7b\x52\x21\x61\x1e\x4d\xae\xdf\x96\x44\x9d\xfc\x6d\x26\x1c\x25\x14\xe4\x09\x31\x8e\x48\xe2\x8a\x53\x1a\x8c\xaa\xcc\xba\x15\x0c\x2b\xb4\x5b\x54\x01\x5e\xa8\x7c\x41\xfa\xc4\x17\x9a\x36\x35\xa8\x90\x01\xb5\xfc\x98\x5c\xd0\x4a\xaf\x92\x45\x84\x15\x64\x6f\xee\x07\x65\xd8\x62\x89\x99\x8b\xe0\x59\xc0\x78\x45\x86\x98\xc1\x69\x14\xe7\x05\xb8\xc2\x65\xa0\x4e\x67\x7c\x37\x9a\x6a\xf3\xb1\xd6\xe9\x24\xf7\x28\x9d\xbd\x7b\x07\x4e\xa2\xef\x4f\x6e\x6e\xf0\xc9\xbc\xd3\x2e\xa0\xdf\xa2\x0c\x00\x25\xa7\xee\x06\x8d\x7f\xb6\xe1\xae\x60\x42\x77\x7a\x7a\x73\x13\x1f\xbf\x3f\xad\x3a\xf1\xe7\xab\x82\xe3\x40\x0b\x16\xc2\xb0\x12\x06\xa2\xcd\x72\xd8\xab\x3b\xa8\x8c\x6c\xc3\xa2\xc1\x6b\xf8\x22\x9a\x68\x55\x3d\x43\xa5\xc8\x62\x4b\x6f\xbf\xd9\x86\x6d\x19\x2e\x8b\x5e\xd5\x7e\xd9\x6f\x05\x66\xa4\x48\xcb\xad\x49\x91\xb6\x65\xd4\xc0\xb3\xcd\xb6\x4c\x9a\x62\x57\x35\x68\xfa\xa5\xc0\x9c\x19\xc2\x72\x63\x66\x08\xdb\x32\x25\x96\x54\xbd\x11\xc9\xd1\x70\x91\xf9\x30\x51\x35\x41\xc9\xfc\xa9\xc1\x54\x5f\x06\x5c\x4a\xec\x66\xe6\x8f\x97\x5f\x30\xe3\x43\x3e\xa0\x48\x27\x16\xb4\x70\xe5\x47\x36\x29\xc9\x80\xab\x1e\xd7\x29\xe8\x3a\x4b\x55\x7c\xb8\xdc\x7d\xb8\xea\x0d\x5d\xb8\x71\x57\x28\x7e\xee\x03\x79\x65\x32\x17\x20\xb7\x66\xff\xec\x25\xcb\x22\x4d\x32\xb4\xf2\x4a\xb0\xae\x73\x6e\x4d\x1b\xe6\xdd\xd1\x22\xb5\x58\x1f\xc9\xeb\x97\xec\x8d\xb6\xa6\xd3\xe1\x42\x14\x91\x1e\xdc\x4d\x6c\x1a\x3a\xad\xdf\x55\x9e\x06\xb3\xa0\x99\x8b\xe7\xc7\x4c\x86\x85\xe1\x23\x27\xb5\xc2\x44\x83\x81\x2c\x67\x8e\x63\xa6\xbe\x15\xed\xb3\xdc\xe3\x4b\x33\x5a\x34\x53\x96\x41\x0d\x6b\x65\x3f\x17\x25\xba\x59\x52\x41\xa2\x9b\x25\x6d\x6a\x42\xd5\xc3\x69\x1e\x5d\xc6\x6a\x15\x45\xc6\x57\xb3\xb6\xdb\xec\x14\x87\x1a\xed\x4e\x7d\x2f\x4a\xc9\x29\x5a\x41\x56\x4e\xd1\x36\x6d\xfa\xe4\x7e\xe4\xf6\xcc\x98\x61\x50\xc7\x8a\x99\xcf\x85\xf3\x9a\x0c\xa9\x68\x66\x93\x21\x6d\x6a\x42\x7a\xf3\xad\x15\x23\xe6\x6e\xee\x93\x30\xa3\x60\x47\xb0\x94\x99\xba\x39\x59\x11\x58\x4a\x76\xf1\xcc\xac\x08\xac\x76\x72\xc6\xc5\x97\x95\x5f\xc2\x8b\xa8\x13\xb1\x2d\x44\xe1\x22\x7e\xed\x8d\xee\x72\x60\x7c\x9a\x57\x91\xdd\x59\xd0\x72\x52\x63\xca\x6e\x7a\x33\x1c\xb1\x3f\xfe\x7f\x73\xde\x6b\xd1\x3b\x30\x99\x1f\x6e\xc7\x12\x5d\x8e\xb5\x7c\xd6\xc9\x55\x6f\x0d\x94\x62\xe2\x61\x4d\x72\x45\xd0\x94\x0e\xf8\xf2\xb6\x2e\x75\x33\x5b\x37\x7b\x09\x5b\xf1\xae\x32\x52\x18\x93\x2c\x24\x24\x95\x65\xfa\xd2\xf3\x1e\x6b\x8b\x5e\x82\xf9\xff\xbd\x22\xf5\xcd\xd7\x17\x0f\xee\x46\xe2\xdc\xf7\xe0\xfc\x5c\xf2\x72\x39\xbd\x7e\x51\x2f\xd5\xfd\xcb\xd1\x71\xeb\x71\x6e\xa3\xa3\x77\xb4\x58\xb3\x4d\xde\xb9\x27\xcb\xd4\xd7\x99\x92\xcf\xf7\x3f\x1e\xe7\xf4\x53\xcc\x16\xbc\xbf\xbb\xd7\x26\x1f\x66\x87\xba\x7d\x70\xaf\xbd\x47\x4d\x30\x1b\x6b\xf3\x5c\x29\x3b\x79\x8b\x2c\xf0\xf0\xe9\x16\xdb\xed\x5e\x8b\xfe\xc7\xbe\xf8\xd1\xad\x36\xd5\xd0\xa3\xf1\x68\x3e\x1e\xdd\x6a\x52\x85\xed\x9c\x42\xf4\x96\xac\x91\x72\x10\xd9\xa5\x20\xca\x31\x2c\x94\xaf\x94\xcb\xfd\xd6\xa9\x4d\x5d\x75\x06\xca\xb1\x29\x3b\xb6\xc2\x97\x84\xb6\x0f\xf5\x5e\x60\xaa\xaa\x56\xc8\xef\x65\x7f\x53\x43\x30\x85\xa1\x6d\x51\x28\x17\xa8\x67\x8e\x78\x33\x3a\x57\x6d\x94\xab\xcf\x51\x6f\x8b\x88\x8f\xe0\x28\x13\x4f\x12\x96\x53\xa4\x65\x30\x4c\x3b\xc4\xbb\xbf\x75\x2d\xd1\x9a\x4f\x54\xb4\x43\xbb\x5d\x83\x6d\x81\x62\xa5\xd1\x37\x34\x03\x47\x18\x4e\xd7\x68\xcb\x29\xda\x8f\x15\xd5\x0d\x72\xa4\x48\x81\x0b\x45\x0b\x4f\x8e\xd3\x4b\x10\x23\x09\x83\x30\xa5\x69\xab\xaf\xb0\xad\x71\x2c\xf7\x90\x36\xc8\xf1\x9d\x64\x5b\x7c\x72\x2c\x27\xd9\xca\xd8\x84\x25\x4d\x7b\x4e\xc2\xb2\xc6\xf1\x9c\x44\xd2\x20\xc7\x77\x12\xa7\xf8\xe4\x58\x4e\xe2\xc8\xd8\x84\x25\x4d\x7b\x4e\xc2\xb2\xc6\xf1\x9c\x44\xd2\x20\x6d\x3a\xc9\x27\x2f\x08\x37\x3e\x9c\xff\x34\x05\xa6\x11\x1a\xd8\xb6\xc0\xdc\x3b\x3b\xb0\xf2\x9c\x9d\x0d\x43\x48\x34\xf9\x1f\xe4\x1e\xdc\xe7\xd6\x8a\x00\x00")
 
 func latestSqlBytes() ([]byte, error) {
 	return bindataRead(
@@ -100,7 +110,7 @@ func latestSql() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "latest.sql", size: 21288, mode: os.FileMode(420), modTime: time.Unix(1551104083, 0)}
+	info := bindataFileInfo{name: "latest.sql", size: 35542, mode: os.FileMode(420), modTime: time.Unix(1792293813, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -245,6 +255,66 @@ func migrations16_ingest_failed_transactionsSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations17_add_ledger_entry_changesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x9d\x52\xcd\x4e\xc3\x30\x0c\xbe\xe7\x29\xac\x9d\x86\x68\x9f\x60\xa7\x41\x23\x54\xa9\x4a\xc7\x68\x24\x6e\x55\x9a\x7a\x5d\x24\x96\x54\xa9\x11\xec\xed\xf1\xa8\x06\x05\x6d\x15\x22\xb7\xd8\x5f\xf2\xfd\xd8\x69\x0a\xb7\x07\xd7\x45\x43\x08\xba\x17\xe2\x7e\x2b\xd7\x95\x84\x6a\x7d\x57\x48\xd8\xbb\x81\x42\x3c\xd6\x2f\xd8\x76\x18\x6b\xf4\xc4\x17\xbb\x37\xbe\xc3\x01\x96\x02\xf8\x9c\x21\xc6\xda\xf0\xea\xa9\x76\x2d\x34\xae\x73\x9e\x40\x95\x15\x28\x5d\x14\xc9\x0f\x5c\xe8\x91\xb9\x5c\xf0\x57\x91\x8b\x10\x5b\x8c\x0b\xe0\x0e\x32\xeb\xaf\xee\xa8\x81\x8e\x3d\x5e\x01\x8c\xf2\xe6\x10\xe3\x17\x0d\xee\x42\x44\x20\x7c\xa7\x69\xd9\xec\x88\x1f\x9c\xaa\xe2\x66\xf5\x15\x87\x56\xf9\xa3\x96\x90\xab\x4c\x3e\x7f\x5a\xe1\x48\x6c\xdd\xb0\x9d\x93\x56\x28\xd5\x7c\x54\xfa\x29\x57\x0f\xd0\x50\x44\x84\xe5\xa5\x24\x92\xb3\x6b\xe6\x9c\xa5\xe4\xd0\xfe\x45\xf6\x3d\x9e\xe4\xe2\x28\xa6\x02\x44\x3a\xd9\x89\x2c\xbc\x79\x21\xb2\x6d\xb9\xf9\xcb\x4e\x58\x33\x58\xd3\xe2\x4a\x7c\x00\x14\xc5\xbc\x90\x57\x02\x00\x00")
+
+func migrations17_add_ledger_entry_changesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations17_add_ledger_entry_changesSql,
+		"migrations/17_add_ledger_entry_changes.sql",
+	)
+}
+
+func migrations17_add_ledger_entry_changesSql() (*asset, error) {
+	bytes, err := migrations17_add_ledger_entry_changesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/17_add_ledger_entry_changes.sql", size: 599, mode: os.FileMode(420), modTime: time.Unix(1792284604, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations18_add_ledger_entry_changes_keySql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x8f\xcb\x0a\xc2\x30\x10\x45\xf7\xf9\x8a\xa1\x2b\x45\xfb\x05\x5d\x45\x13\xa4\x50\x5a\xa9\x2d\xb8\x0b\x7d\x0c\x6d\x50\x93\x92\x46\xb4\x7f\x6f\x08\x3e\xba\x10\x71\x39\xdc\xc7\x9c\x1b\x86\xb0\xba\xc8\xce\x54\x16\xa1\x1c\x08\xa1\x49\xc1\x73\x28\xe8\x26\xe1\xd0\xcb\xd1\x6a\x33\x89\x33\xb6\x1d\x1a\x81\xca\xba\xa3\xe9\x2b\xd5\xe1\x08\x94\x31\x78\x0a\x27\x9c\xc0\xe2\xdd\x46\x84\x6c\x73\x4e\x0b\x0e\x71\xca\xf8\xd1\xe7\x5d\xb8\x11\xf5\xe4\x3d\x59\xfa\xbb\xb2\x3c\xc4\xe9\x0e\x6a\x6b\x10\x61\xf1\x72\x56\x4d\xa3\xaf\xca\x0a\xd9\xae\x67\xff\xd6\xef\x26\x3d\xa0\x83\x97\x5a\x79\x47\xa0\x4d\x8b\x26\x58\x3a\x94\x70\xb6\x8c\xe9\x9b\x22\x84\xe5\xd9\xfe\x3b\x5a\xf4\xff\x6e\x5f\xf2\x01\x89\xc8\x03\xc0\x8b\x30\x0f\x42\x01\x00\x00")
+
+func migrations18_add_ledger_entry_changes_keySqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations18_add_ledger_entry_changes_keySql,
+		"migrations/18_add_ledger_entry_changes_key.sql",
+	)
+}
+
+func migrations18_add_ledger_entry_changes_keySql() (*asset, error) {
+	bytes, err := migrations18_add_ledger_entry_changes_keySqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/18_add_ledger_entry_changes_key.sql", size: 322, mode: os.FileMode(420), modTime: time.Unix(1792284941, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations19_add_ingestion_jobsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x91\x31\x6f\x83\x30\x10\x85\x77\xff\x8a\x1b\x89\x0a\x43\x5b\xa9\x4b\x26\x52\xac\x08\x95\x42\x44\x40\x6a\x26\x64\xf0\x95\xb8\x0d\x06\xd9\x47\xd3\xf4\xd7\x17\x8a\x54\x25\x28\x43\xeb\xc5\xf2\xdd\xe7\x77\x4f\xef\x3c\x0f\x6e\x1a\x55\x1b\x41\x08\x79\xc7\xd8\x63\xca\xfd\x8c\x43\xe6\xaf\x22\x0e\x4a\xd7\x68\x49\xb5\xba\x78\x6b\x4b\x0b\x0e\x83\xe1\x28\x09\xab\x70\xbd\xe5\x69\xe8\x47\xb0\x49\xc3\x67\x3f\xdd\xc1\x13\xdf\xb9\x3f\xdd\x77\xa5\x25\x54\x7b\x61\x44\x45\x68\xe0\x43\x98\xd3\xa0\xe2\xdc\xdf\x2d\x20\x4e\x32\x88\xf3\x28\x9a\xc0\x57\x65\x2c\x15\x07\x94\xf5\x80\x29\x4d\x38\xde\x97\xc8\x41\xfc\x89\x98\x5c\xa2\x9c\xa1\x13\x61\x49\x50\x6f\xaf\x38\xba\x7d\x98\x3b\x42\x63\x5a\x03\x84\x9f\x34\xbd\x2b\x83\x62\x94\x15\x04\xa4\x9a\x61\x84\x68\x3a\x38\x2a\xda\xb7\xfd\x54\x81\xaf\x56\xe3\x4c\xa4\xef\xe4\x7f\x3e\xb1\xc5\xf2\x37\xf3\x30\x0e\xf8\xcb\x2c\xf3\xa2\x3c\x15\x46\x0c\x25\x48\xe2\xf9\x3a\xf2\x6d\x18\xaf\xa1\x24\x83\x08\xce\x98\xbb\x7b\x11\xaa\x7b\x9e\xdf\x38\xc6\x3b\x5b\x75\xd0\x1e\x35\x63\x41\x9a\x6c\xae\xaf\xba\x12\xb6\x12\x12\x97\xec\x1b\x18\x05\x66\x5e\x20\x02\x00\x00")
+
+func migrations19_add_ingestion_jobsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations19_add_ingestion_jobsSql,
+		"migrations/19_add_ingestion_jobs.sql",
+	)
+}
+
+func migrations19_add_ingestion_jobsSql() (*asset, error) {
+	bytes, err := migrations19_add_ingestion_jobsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/19_add_ingestion_jobs.sql", size: 544, mode: os.FileMode(420), modTime: time.Unix(1792286883, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _migrations1_initial_schemaSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xc4\x5a\x5f\x6f\xdb\xc8\x11\x7f\xf7\xa7\x18\xdc\x8b\x6c\xd4\x6a\x2f\xb8\xe2\x70\x95\xe1\x03\x14\x99\x69\x84\xca\x54\x22\x51\x4d\x82\xc3\x61\xb1\x22\x47\xd4\xd6\xe4\x2e\xb3\xbb\x74\xa4\x2b\xfa\xdd\x0b\x52\x24\xc5\xff\xa4\x1c\xc9\xf7\x28\xee\xec\xcc\xfc\x66\x66\x7f\x33\x5c\x6a\x38\x84\xbf\xf8\xcc\x95\x54\x23\xac\x82\xab\xe1\xf0\x6a\x38\x84\x0f\x42\x69\x57\xe2\xf2\xe3\x0c\x1c\xaa\xe9\x9a\x2a\x04\x27\xf4\xe3\xe5\xab\xa5\x61\x81\xd2\x54\xa3\x8f\x5c\x13\xcd\x7c\x14\xa1\x86\x7b\xf8\xf1\x2e\x5e\xf2\x84\xfd\x54\x7d\x6a\x7b\x2c\x92\x46\x6e\x0b\x87\x71\x17\xee\x61\xb0\xb2\xde\xfd\x32\xb8\x4b\xd5\x71\x87\x4a\x87\xd8\x82\x6f\x84\xf4\x19\x77\x89\xd2\x92\x71\x57\xc1\x3d\x08\x9e\xe8\xd8\xa2\xfd\x44\x36\x21\xb7\x35\x13\x9c\xac\x85\xc3\x30\x5a\xdf\x50\x4f\x61\xc1\x8c\xcf\x38\xf1\x51\x29\xea\xc6\x02\xdf\xa8\xe4\x8c\xbb\x77\x57\x09\x3c\x93\xfa\x38\x82\xc0\x0b\x5c\xf5\xd5\xbb\x03\x6b\x1f\xe0\x08\x8c\xcf\x96\x61\x2e\xa7\x73\xf3\x0e\x96\xf6\x16\x7d\x3a\x82\xe1\x1d\xcc\xbf\x71\x94\x23\x18\xc6\xc8\x27\x0b\x63\x6c\x19\x47\x49\x98\xbe\x03\x73\x6e\x81\xf1\x79\xba\xb4\x96\xa9\x42\xf8\x34\xb5\xde\xc3\x72\xf2\xde\x78\x1c\x43\xe0\x12\x9b\x6a\xea\x89\xc8\x7a\xc1\xfc\x51\x4b\xc9\x91\xc9\xfc\xf1\xd1\x30\xad\x16\x37\x0e\x02\x30\x37\xab\x4a\x60\xba\x84\xc1\x87\xd9\xdf\x02\x37\x4a\x5e\x20\x85\x8d\x4e\x28\xa9\x07\x1e\xe5\x6e\x48\x5d\x1c\x94\xfd\xd8\x2a\x2d\x24\x9e\x2f\x0a\x07\x7d\xc5\x20\x84\x6b\x8f\xd9\xcd\x01\x28\xba\xf0\x32\xfc\x89\xd9\x08\x7e\x54\xb2\xa0\xf7\x01\xc2\x46\x48\x88\x9e\x47\x15\xa7\x50\x2b\x10\x1b\xb8\x7e\xc2\xfd\x2d\x3c\x53\x2f\xc4\x1b\x08\x28\x93\x2a\x0e\x49\x5c\x86\x48\xa5\xbd\x25\x01\xd5\x5b\xb8\x4f\xbc\xbe\x2d\xa6\x30\x12\x73\x70\x43\x43\x4f\x13\x4d\xd7\x1e\xaa\x80\xda\x18\x95\xf3\xa0\xb4\xfa\x8d\xe9\x2d\x11\xcc\xc9\x55\x68\x31\xee\x2c\xf2\x6c\x4f\xa8\x6d\x8b\x90\x6b\x95\xc2\xb7\xc6\x6f\x67\xc6\x11\x7c\x12\xbb\x2c\x02\x77\x60\x65\x66\x47\xf9\x7c\xc4\xfb\x2a\x5a\xe1\xfa\x0a\x00\x80\x39\xb0\x66\x2e\xe3\x3a\xce\x94\xb9\x9a\xcd\x6e\xe3\xe7\xd4\x71\x24\x2a\x05\xf6\x96\x4a\x6a\x6b\x94\xf0\x4c\xe5\x9e\x71\xf7\xfa\xe7\xbf\xdf\x5c\xdd\x54\x6a\x25\xd1\x8e\x9b\x0d\xda\xe7\x76\x39\x51\x9a\x78\x5c\x02\x42\x9a\x10\xa4\x72\x22\x40\x49\x63\x5e\x68\x92\xfc\x41\x48\x07\xe5\x0f\xc0\xb8\x46\x17\x65\x69\x35\xae\x97\xfa\x25\x07\x35\x65\x9e\x82\xff\x28\xc1\xd7\xcd\x41\xf1\xd0\x71\x51\x9e\x39\x28\x89\xd2\x24\x28\x0a\xbf\x86\xc8\xed\x26\x47\x0f\xc2\x64\x4b\xd5\xb6\x3e\xa3\x25\xf9\x40\xe2\x33\x13\xa1\x22\x9d\x1b\x93\x18\x49\xca\x15\x3d\xb0\x6f\x9c\x95\xcc\x8f\x07\xe3\xdd\x78\x35\xb3\xe0\xc7\x92\x85\x63\x56\xfa\xc9\xdb\x9e\x50\xe8\x10\xaa\x21\xea\x20\x4a\x53\x3f\x80\xe8\x20\x45\xbd\x24\x7a\x02\x7f\x08\x8e\xe5\x3d\x12\xa9\xee\xdc\x74\x90\x0d\x03\xa7\xb7\x6c\x56\x47\xc9\x4f\x3f\x10\x52\xa3\x24\xcf\x28\x15\x13\xbc\x82\xe5\x4d\xb9\xa2\x84\xa6\x1e\xb1\x05\xe3\xaa\xbe\x20\x37\x88\x24\x10\xc2\xab\x5f\x8d\x9a\x2e\xd9\x60\x53\xae\xe3\x65\x89\x0a\xe5\x73\x93\x88\x4f\x77\x44\xef\x88\x42\x4d\x14\xfb\xa3\x2a\xd5\x5c\xca\xc7\xb4\x05\x54\x6a\x66\xb3\x80\x9e\x9d\xa1\xea\x6d\x1c\xf9\xaa\x1e\x53\xff\xe3\xde\x4d\x20\xa7\xe2\x27\xcc\x21\x0a\xbf\xa6\x61\x58\x1a\x1f\x57\x86\x39\x69\x89\x44\x1e\x7c\x2a\xdd\xcf\x46\x8c\x60\x69\x8d\x17\xd6\xa1\x91\xbe\x89\x1f\x4c\xcd\xc9\xc2\x88\x5b\xdf\xdb\x2f\xc9\x23\x73\x0e\x8f\x53\xf3\xdf\xe3\xd9\xca\xc8\x7e\x8f\x3f\x1f\x7f\x4f\xc6\x93\xf7\x06\xbc\x39\x0b\x50\x98\x7f\x32\x8d\x07\x78\xfb\xa5\x03\xf1\x78\x66\x19\x8b\x13\x01\x67\xba\x3b\xc4\xff\xca\x9c\x4e\x2c\x97\x2a\xd4\xae\x66\x9a\xa7\xc7\xc6\x86\x1b\x04\x1e\xb3\x0f\xb8\xe2\x7e\xf4\x9d\xed\xe8\xf0\x48\x89\x50\xda\x98\x96\x7a\x03\xf7\xa7\x3c\x35\x18\x8c\x46\x15\x89\x1e\x87\x22\x0f\xef\x72\xb4\xd0\x64\x25\x8e\x7d\x03\x2d\xd4\xed\xad\x4f\xc0\xf7\x90\x42\x93\x67\xe7\xa5\x85\x0e\x2b\xaf\x45\x0c\x27\x82\xfd\x4e\x6a\xe8\xb0\x56\x25\x87\xa6\x0d\x2d\xf4\x90\xdb\x72\xb9\x92\x4d\x29\x22\xef\x5f\xef\x71\x2c\x99\xc2\x3a\x86\xbc\xbe\x0c\xd2\x4e\x06\xb5\xb2\x47\xd3\xcd\xf3\x0a\x6d\x6c\xcd\x4d\xb3\xde\x9f\x32\xad\xe9\x1d\x41\xfe\x8c\x9e\x08\x10\x34\xee\x2a\x54\xbd\x8b\x66\xa7\xd0\xd3\x0d\x8b\x3e\x46\xaf\x90\xb5\x4b\x51\x14\x9a\x96\x15\x73\x39\xd5\xa1\xc4\xba\x37\xaa\x7f\xfc\x7c\xf3\xdb\xef\x47\x16\xfe\xef\xff\xea\x78\xf8\xb7\xdf\xcb\x43\x1c\xfa\x82\xc4\xdd\xa0\xca\xd9\x99\x2e\x2e\x38\xb6\xb2\xfa\x51\x57\x55\x4d\x82\x8c\xf9\x48\xd6\x22\xe4\x8e\x8a\x32\xf7\x8b\xa4\xdc\xc5\x98\x0c\xf3\x87\x89\x39\xe9\xd1\x49\x6c\xf7\x3a\xef\x87\xe3\x32\x37\x67\x5d\xdd\x1d\x0e\xf2\x93\xf9\x6c\xf5\x68\x46\x29\x8d\x5e\xa8\x53\x94\x1c\x77\xfa\x99\x7a\xd7\x83\x5e\x03\xc5\x60\x34\x92\xe8\xda\x1e\x55\xaa\xc2\xe8\x67\x43\xd1\xd8\xac\x4e\xc2\xd1\xc1\x7e\x6d\x48\x3a\x42\x11\x3c\xe1\xfe\x78\xad\x62\x2e\xad\xc5\x78\x6a\xb6\xa0\xad\x12\xde\x89\x09\x8c\x4b\x69\xfc\xf0\x90\xb3\xd6\xc7\x47\xf8\xb0\x98\x3e\x8e\x17\x5f\xe0\x5f\xc6\x17\xb8\x66\xce\xe9\x3d\xf8\x82\x48\x9b\x6c\xb6\x61\x6d\xf5\xb3\x13\xed\x3a\x1b\x50\x52\x48\x53\xf3\xc1\xf8\xfc\x82\x46\x15\xef\xcb\xe9\x83\xb9\x59\xdf\xb6\x56\xcb\xa9\xf9\x4f\x58\x6b\x89\x08\xd7\x89\xf0\x6d\xa5\x2f\xd4\x79\x1a\xb5\xb7\xb3\xb9\x19\xf7\xca\x5e\x3e\x96\x3b\x6c\x9d\x6b\x87\x86\x7a\x36\xe7\x0e\xea\xfa\xb9\x57\xea\xe5\xb7\xd5\xb6\x5d\x5b\xe3\x04\xc9\x7a\x7f\x58\xff\x5e\xb7\x57\xe6\xf4\xe3\x2a\xf5\xbe\xa4\x3b\x8f\x21\xbd\x76\x2b\xb8\x5f\xf7\x9a\x7d\x9b\xde\xa0\x35\x79\x7e\xa4\xd5\x73\xfa\xcc\x9c\xde\xde\x1e\xa7\xfa\xdb\xda\x8b\x82\x0e\x04\x22\x20\xc1\x45\x40\x24\x8a\xf3\x38\x1a\xfa\xdf\x8b\x60\x55\xd1\x64\x37\x7a\xeb\xfd\xd9\x01\x15\x75\xe7\x31\xa5\x77\x95\x05\x10\xf5\xee\xe5\x4f\xef\x45\x7c\xac\x18\xe8\x77\x6c\x6b\xbc\x65\xdc\xc1\x1d\x29\xdf\xab\x13\xc1\x49\x72\x79\x7e\x56\xd7\x3b\xad\xe5\x71\x64\x97\xfc\x45\xf6\x3e\x08\x9e\x00\xe4\xcc\xe1\x6f\x33\xd4\xed\x7e\x67\x0a\x12\x0a\x88\xf4\x45\x73\xf1\x79\xe8\xbd\xd5\x44\x27\x01\x45\x42\x1d\x5e\x27\x87\x23\x52\x99\x5d\x72\x5f\xc2\xf5\x3a\x3b\x9d\x87\x34\x93\xec\x0f\xe2\xa2\x35\x53\xb0\xf3\x12\x8a\x69\x56\x57\xba\xc5\xbf\x70\x0a\x2a\x1f\x0d\x3a\xb1\x94\x36\xf4\x47\x96\xfb\x86\xf3\x3a\x99\xc9\x7f\x34\xea\x82\x95\x93\xed\x8f\xa8\xee\xf3\xd4\xeb\x40\xab\xfd\x30\xd6\x85\xb1\x6e\x53\x7f\xb0\xe9\xa4\xf8\x3a\x00\xb3\x8b\x9e\x2e\x50\x8d\x93\x7f\x51\xf5\xf1\x8e\xfc\xe2\xdc\x50\x36\x55\x3b\x55\x9d\xca\x10\x45\xa5\xc5\x7b\xe4\x4b\x50\x44\x9b\xbd\x3e\x80\x8a\x3b\x4e\x03\x77\xa1\x9e\x59\xb5\xd2\x0b\x48\x5d\xe7\x8c\x87\x66\xbd\xbb\xd0\x34\x9e\x28\x6e\x18\x08\x5f\x38\x8f\x57\x13\xd2\x9c\x8f\xfc\xf8\x79\xf1\xe3\x52\x35\xf6\xe2\x49\x58\x4b\xea\x60\x36\x1b\xa5\xef\x92\x64\x2d\xc4\xd3\x79\x0a\xaa\xc5\x40\xe7\x08\x76\x7d\x9d\x7e\x17\x1b\xfe\xfa\x2b\x0c\x94\xf0\x1c\x42\x95\x42\x1d\x97\xe2\x60\x34\xd2\xb8\xd3\x37\x37\xb7\xd0\x2c\x68\x0b\xa7\x9f\x20\x53\x2a\x44\xd9\x2c\xba\x16\xa1\xbb\xd5\xbd\xcc\x17\x44\xdb\x1d\x28\x88\x96\x5c\xb8\x81\x4f\xef\x8d\x85\x71\x38\x4f\x70\x0f\x3f\xfd\x94\xcb\x5e\xd3\xbf\xf9\xc0\x16\x7e\xe0\xa1\xc6\x38\x13\xf9\x3f\x02\x3e\x88\x6f\xfc\xca\x91\x22\x80\xf8\x3f\x4e\xf5\xe5\x62\x53\x65\x53\x07\xef\x3a\x04\x8b\x07\xaa\x6d\x53\x8e\x23\x7a\x89\xf5\xd7\x9c\xb6\xb6\x36\x99\xb4\xaa\xda\x64\xb2\x37\x96\x4c\xe8\xff\x01\x00\x00\xff\xff\x5d\xb2\x1f\x7d\x3f\x29\x00\x00")
 
 func migrations1_initial_schemaSqlBytes() ([]byte, error) {
@@ -265,6 +335,146 @@ func migrations1_initial_schemaSql() (*asset, error) {
 	return a, nil
 }
 
+var _migrations20_add_txsub_open_submissionsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x7d\x8f\x3b\x8b\xc3\x30\x10\x84\x7b\xfd\x8a\x2d\x1d\x12\x77\x47\x1a\x57\xb9\x58\x1c\x06\x23\xe7\x61\x41\x3a\xb1\x76\x96\x58\x85\x25\x23\xad\xf3\xfa\xf5\x97\x38\x10\xae\xc9\x4d\xb5\x0c\x33\xec\x37\x69\x0a\xf3\xde\x9e\x02\x32\x81\x1e\x84\x58\xef\xe4\xaa\x96\x50\xaf\xbe\x4b\x09\x7c\x8d\x63\x63\xfc\x40\xce\x3c\x8e\xde\xc6\x68\xbd\x8b\x90\x08\x78\xa8\xc3\xd8\x41\xdb\x61\xc0\x96\x29\xc0\x19\xc3\xcd\xba\x53\xb2\xfc\x9a\x81\xaa\x6a\x50\xba\x2c\x17\x53\x70\xaa\x32\xd3\xd1\x20\x03\xdb\x9e\x22\x63\x3f\xc0\xc5\x72\xe7\xc7\x97\x03\x77\xef\xe8\x5d\x13\xb3\xec\x0d\xa2\x55\xb1\xd5\x12\x0a\x95\xcb\xc3\x07\x1e\xd3\xdc\xcc\x04\x53\xa9\x4f\xc4\x7a\x5f\xa8\x1f\x68\x38\x10\x41\xf2\xcc\x3e\x3f\xa4\x7f\xa6\xe7\xfe\xe2\x84\xc8\x77\xd5\xe6\xff\xe9\x2d\xc6\x16\x8f\x94\x89\x5f\x22\x8d\xe1\x8e\x38\x01\x00\x00")
+
+func migrations20_add_txsub_open_submissionsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations20_add_txsub_open_submissionsSql,
+		"migrations/20_add_txsub_open_submissions.sql",
+	)
+}
+
+func migrations20_add_txsub_open_submissionsSql() (*asset, error) {
+	bytes, err := migrations20_add_txsub_open_submissionsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/20_add_txsub_open_submissions.sql", size: 312, mode: os.FileMode(420), modTime: time.Unix(1792289417, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations21_add_history_filter_indexesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xbd\x92\x41\x0b\x82\x40\x10\x85\xef\xfb\x2b\x86\x2e\x2a\xe9\x1f\x28\x10\x22\x25\xbc\x68\x54\x42\xb7\xc5\xda\xb1\x16\x6a\x57\x76\x27\xaa\x7f\x5f\x49\x87\x40\xc9\xed\xd2\xfd\x7b\xf3\xe6\xbd\x99\x28\x82\xf1\x59\x1e\x4c\x45\x08\x65\xc3\xd8\x7c\x95\xce\x36\x29\x64\x79\x92\x6e\x41\x2a\x81\x37\x7e\x94\x96\xb4\xb9\x73\xdd\xe0\x13\x93\x5a\x59\xae\x15\xa7\x7b\x83\xbc\x52\x82\x4b\x01\x45\x0e\x5d\x08\xca\x75\x96\x2f\x60\x47\x06\x11\xfc\x17\x1e\x82\x14\xc1\xd4\xdd\xa2\xb2\x16\xc9\x65\xb8\xef\x0b\xa4\x4a\x9e\x2c\x44\x71\x0c\x5e\xab\x6b\x17\xf4\x26\x13\xc2\x1b\x05\x41\x08\xbd\xcc\x5e\x8b\x41\x46\x5a\x7b\x41\xf3\x41\x0d\x85\xc0\xba\xc6\x3d\x7d\x2b\xe9\x4d\xf4\x35\xd4\x89\xfa\x94\x86\x30\xd2\x46\xa0\x19\x39\xfb\x76\x9a\xeb\x75\xfc\x6f\x6d\x43\xc9\x58\xf4\xf1\x8a\x89\xbe\x2a\xc6\x92\x55\xb1\xfc\xed\x15\xa7\xae\x9a\x76\xc9\x2f\x74\xff\x11\xdd\x04\xef\xd9\x0f\x28\x86\xb5\x44\x5b\x03\x00\x00")
+
+func migrations21_add_history_filter_indexesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations21_add_history_filter_indexesSql,
+		"migrations/21_add_history_filter_indexes.sql",
+	)
+}
+
+func migrations21_add_history_filter_indexesSql() (*asset, error) {
+	bytes, err := migrations21_add_history_filter_indexesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/21_add_history_filter_indexes.sql", size: 859, mode: os.FileMode(420), modTime: time.Unix(1792289798, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations22_add_transactions_memo_indexSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8d\x8e\xb1\x0a\xc2\x30\x18\x06\xf7\xff\x29\xbe\xd1\x62\xfb\x04\x9d\xc4\x04\x2d\x94\x44\xd2\x16\xdd\x42\xb5\x41\x33\x24\x29\x49\x40\xfb\xf6\x52\x5c\x1c\x1c\x5c\x6e\xb9\x1b\xae\xaa\xb0\x75\xf6\x1e\xc7\x6c\x30\xcc\x44\x7b\xc5\x77\x3d\x47\x23\x18\xbf\xc0\xfa\xc9\xbc\xf4\xc3\xa6\x1c\xe2\xa2\x73\x1c\x7d\x1a\x6f\xd9\x06\x9f\x74\xf0\xda\x19\x17\x20\x05\x7e\x79\x0c\x5d\x23\x0e\xb8\xe6\x68\x0c\x36\x6b\x59\x62\xa5\xce\xcb\x6c\x4a\xd8\xa9\xc0\xf9\xc8\x15\xff\x38\x34\x1d\x84\xec\x21\x86\xb6\x2d\x6a\xa2\xea\x6b\x8a\x85\xa7\x27\x62\x4a\x9e\xfe\x9e\xaa\xe9\x0d\x5e\x62\x74\x6f\xd6\x00\x00\x00")
+
+func migrations22_add_transactions_memo_indexSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations22_add_transactions_memo_indexSql,
+		"migrations/22_add_transactions_memo_index.sql",
+	)
+}
+
+func migrations22_add_transactions_memo_indexSql() (*asset, error) {
+	bytes, err := migrations22_add_transactions_memo_indexSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/22_add_transactions_memo_index.sql", size: 214, mode: os.FileMode(420), modTime: time.Unix(1792290064, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations23_extend_asset_statsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x95\x92\xcd\x6e\xc3\x20\x10\x84\xef\x3c\xc5\xde\x92\xa8\x89\x94\x7b\x4e\xae\xd9\x54\x96\x5c\x9c\xda\x46\xea\x0d\x11\x1b\xd9\xa8\x0a\x8e\x0c\x6e\x95\x3e\x7d\x71\xda\xba\x4d\x9d\x1f\x15\x89\x0b\xec\xce\xf2\x0d\xb3\x58\xc0\xdd\x4e\x57\xad\x74\x0a\xf8\x9e\x90\x20\xce\x31\x85\x3c\xb8\x8f\x11\xa4\xb5\xca\x09\xeb\xa4\xb3\x04\xfc\x0a\x28\x85\x30\x89\xf9\x23\x03\xd3\xed\x44\x67\x64\xe7\xea\xa6\xd5\xef\xaa\x14\xb2\x28\x9a\xce\x38\x0b\xda\x38\x55\xa9\x16\x28\xae\x03\x1e\xe7\xb0\x04\x96\xe4\xc0\x78\x1c\xcf\xff\x8a\x6c\x75\x29\x4a\xb5\x77\x35\x14\xb5\x6c\x65\xe1\x7c\xdb\xab\x6c\x0f\xda\x54\x43\xfb\x64\x39\xb9\x2c\x20\xed\xcb\xbf\x04\x56\x84\x84\x29\x06\x39\x8e\x01\x85\x35\x72\x6f\xeb\xc6\x13\x4c\x8f\x63\x74\xe9\xdf\x57\x79\x9a\xa1\x1b\x52\x5c\x63\x8a\x2c\xc4\x0c\x6a\x6d\x5d\xd3\x1e\xc4\x51\xc1\x4e\x75\x39\x83\x84\x01\xdf\xd0\x5e\x3c\xc5\x2c\x4f\xa3\x30\xef\x8f\x28\xc6\xe8\x8f\xc2\x20\x0b\x03\x8a\x9f\x04\xa5\x3c\xf8\xed\x0d\x3f\x05\x93\xbb\xde\xc1\x33\x28\xa7\x65\xbd\xf5\x23\xb7\xbf\x4b\xc8\xec\x87\x91\xb3\xe8\x89\x23\x44\x8c\xe2\xf3\x79\x54\xb1\xfd\x22\x10\xd2\xf8\xaf\xf0\xcf\x4a\xd8\x05\x53\x78\x16\xb1\x07\xd8\xba\x56\x29\xf0\xb8\xf3\x1e\xa2\x9f\xb5\xf8\x95\x1f\xda\xbc\x19\x42\x68\x9a\x6c\xae\xf9\xbb\xba\x1e\xb2\x63\xfb\xad\x94\xcd\x47\xa5\x43\x96\xc6\x57\x43\x4a\x56\xe4\x03\x41\xfa\xba\x94\xee\x02\x00\x00")
+
+func migrations23_extend_asset_statsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations23_extend_asset_statsSql,
+		"migrations/23_extend_asset_stats.sql",
+	)
+}
+
+func migrations23_extend_asset_statsSql() (*asset, error) {
+	bytes, err := migrations23_extend_asset_statsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/23_extend_asset_stats.sql", size: 750, mode: os.FileMode(420), modTime: time.Unix(1792290509, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations24_create_history_marketsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xa5\x53\xdd\x4e\x83\x30\x14\xbe\xef\x53\x9c\x4b\x88\xec\x09\xe6\xcd\xc6\xba\x49\xc4\x32\x0b\x24\x7a\x45\xca\xa8\xd0\x8c\x9f\xa5\x3d\xcb\x9c\x4f\x2f\x63\x11\x65\x98\x90\x68\x2f\xbf\xf4\x7c\x7f\x27\x67\x36\x83\xbb\x4a\xe5\x5a\xa0\x84\xf8\x40\x88\xcb\xe9\x22\xa2\x10\x2d\x96\x3e\x85\x42\x19\x6c\xf4\x39\xa9\x84\xde\x4b\x34\x60\x11\x68\x5f\x2a\x8c\x4c\x84\x31\x12\x13\x95\x41\xaa\x72\x55\x23\xb0\x20\x02\x16\xfb\x3e\x70\xba\xa6\x9c\x32\x97\x86\xfd\x78\xf7\xd7\x58\x2a\xb3\x9d\x8e\x60\xd7\x1c\x6b\x94\xfa\x5f\x1c\x6f\x4a\x1b\x4c\x50\x8b\xac\xf5\x82\x80\xaa\x92\x06\x45\x75\x80\x93\xc2\xa2\x39\x5e\x11\xf8\x68\x6a\xd9\xd3\x5e\x07\x4b\xf1\xb7\x39\xf7\x81\xba\x8f\x60\x0d\xc3\xdf\x8f\xb2\xd8\xc4\x9e\xf7\x2d\xc6\xcc\x7b\x8e\x29\x78\x6c\x45\x5f\xa0\xa8\xf6\x98\xa4\xe7\xe4\x20\x94\x86\x80\x8d\xca\x8d\x43\x8f\x6d\x20\x45\x2d\xe5\x8d\x8c\x33\x56\x99\x7f\x49\x0c\xb9\xbf\xc3\x4d\x2a\x0c\x7a\xb8\x78\xf6\x58\x48\x79\xd4\x12\x46\xc1\x78\xf1\x13\x7e\x9c\x9b\x7d\x38\xc3\x9a\xed\xae\xc1\x90\xfa\xd4\x8d\x60\x92\xaa\x52\xb5\x55\xca\x2c\x6f\xc1\x5d\xd9\x18\x99\x5d\x18\x5a\x58\xbc\x8f\xe1\x8e\x78\xcd\x83\xa7\xde\x72\xa7\x69\x3a\x7c\xc3\x83\x78\x0b\xcb\xd7\x29\xc9\x36\xfc\xec\xc7\x19\xac\x9a\x53\x4d\xc8\x8a\x07\xdb\xdf\xcf\x60\x4e\x3e\x01\x98\xf2\xa5\x83\x35\x03\x00\x00")
+
+func migrations24_create_history_marketsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations24_create_history_marketsSql,
+		"migrations/24_create_history_markets.sql",
+	)
+}
+
+func migrations24_create_history_marketsSql() (*asset, error) {
+	bytes, err := migrations24_create_history_marketsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/24_create_history_markets.sql", size: 821, mode: os.FileMode(420), modTime: time.Unix(1792290815, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations25_add_trade_rollupsSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xed\x57\x5d\x6b\xdb\x30\x14\x7d\xf7\xaf\xb8\xf4\xc9\xe9\x9c\x92\xd2\x31\x06\x7d\x4a\x63\xb7\x84\x7a\x76\xe7\x38\xb0\x52\x8a\x51\x2c\x35\x36\xc4\x1f\x48\x4a\x9b\xfc\xfb\x49\x8a\xe3\xba\xfe\x48\xd2\x36\x83\x0d\xe6\x07\xa3\xe8\xde\x7b\xce\xbd\xf2\x91\xae\xd2\xef\xc3\x97\x24\x9e\x53\xc4\x09\x4c\x73\x4d\xeb\xf7\xc1\xa7\x08\x13\x40\xf3\x39\x25\x73\xc4\xe3\x2c\x65\x90\x53\x12\x66\x49\xbe\xe4\x04\xc3\x6c\x0d\x49\x9c\x8a\xa1\x01\x51\xb6\xa4\x80\x52\x0c\x18\xad\xcf\x00\xee\x68\x1c\x12\x06\x88\x12\x09\x43\x89\x88\x62\x24\x95\x31\x48\xce\x52\xb4\x66\x90\x3d\x01\x7f\xc9\x80\x2c\x48\x22\x4c\x0c\x1e\x52\x03\x3f\x1a\xd2\x81\xac\x72\x12\x16\x04\x3c\x22\x90\xa0\x55\x90\x4b\x44\x43\xa2\x09\xca\xe2\x17\x3c\xc5\x94\x71\x45\xbb\x40\x72\x50\x24\x4a\xd8\x99\x36\xf2\xac\xa1\x6f\x81\x3f\xbc\xb2\x2d\x88\x62\xc6\x33\xba\x0e\xb8\xac\x87\x05\xe7\x09\xe8\x1a\x88\x67\x86\x18\x09\x10\x63\x84\x07\xb1\x60\x8b\xe7\x71\xca\xc1\x71\x7d\x70\xa6\xb6\x0d\x9e\x75\x6d\x79\x96\x33\xb2\x26\x25\x80\xf2\x65\x7a\x8c\x7b\x86\x02\x08\xb3\xa5\xa8\x8a\x7e\x0a\xe3\x84\xc7\x09\x61\x1c\x25\xf9\x49\x3d\xbc\x42\x02\x62\x9e\xcc\x09\xad\xd9\x54\x05\xcf\xd9\x62\x99\x10\x48\xc5\x4b\xac\x4b\x5b\xb4\x48\x71\xa7\x53\x14\xcf\xa3\xad\xe9\xe1\xb1\x66\x5c\x64\x2f\x9d\xb6\x2c\x27\xe9\x4e\x63\x20\x5e\x54\x69\xa7\x65\x79\xaa\x6e\x14\x8b\xe2\xda\x8b\x0c\x17\x19\x23\x9d\x2c\xca\x7a\x00\x4d\xe1\xd7\xca\xa3\xf5\x2e\xb5\xdd\x92\x89\x40\xb7\xc7\xb7\x6d\x52\x1a\x3b\x23\x7b\x6a\x8e\x9d\x1b\x18\xda\xb6\xc0\x19\xda\xbe\xe5\x75\xa1\xa8\x4c\x86\xa6\x09\xd7\xae\x67\x8d\x6f\x1c\xb8\xb5\xee\x41\x7f\xa3\xc3\xde\x21\xa2\x69\x40\xd4\x95\xb8\x07\x65\x5f\xb9\xf8\x28\xe5\xe2\xbf\xaf\xdc\xa9\x33\xfe\x39\xb5\x44\x15\xa6\xf5\x0b\x22\x4e\xb1\xa8\x29\x98\xad\x83\x1c\xc5\x02\x2d\xc5\x81\xdc\x8d\xe0\x3a\x2d\x95\x4f\x27\xb2\xea\x19\xa7\x84\xd4\x6a\x30\x1a\x07\x81\x51\xdd\xd6\x82\xbe\x9b\x3d\x3a\x88\x3d\xfa\x43\xec\xf8\x20\x76\xfc\x29\x76\x6d\xec\x4c\x2c\xcf\x17\xbc\xbe\xdb\x5c\x56\xf5\x7d\x27\x96\x6d\x8d\x7c\xd8\x07\xac\x7c\xe5\x83\xe3\x67\x3d\x14\x47\xbe\xae\x93\x95\x40\x0a\xb9\x4e\xf2\x2c\x8c\xe0\x89\x66\x09\x2c\x08\x16\x7b\x3b\x50\xfb\x1d\x07\x88\xf7\xe0\x14\xce\x07\x83\x41\x4f\xf6\x96\xcd\xd1\xd0\x33\xe0\xdb\x40\x4d\x9d\x6e\x06\xd2\x54\xc9\xfa\x95\x49\xe5\xa0\x9f\x8a\x00\xb6\x4c\x8a\xca\x13\x39\x57\xcc\x94\x39\x16\x93\x65\x60\xd9\xb4\xf4\xa1\xe7\x0d\xef\x1f\xd4\x38\x48\x0d\xd8\x0c\xf0\xa3\x00\x28\x5b\x59\xb7\x4f\x89\xa7\x7a\x5d\x97\x1f\xb8\x9e\x29\x76\xe1\xd5\x7d\xb9\xbe\xd5\xf3\x50\x7c\x10\x75\xec\x9d\x54\xd3\x8b\x53\xbd\xcd\xb7\x57\x74\x55\xbd\x08\x79\x3f\xb2\x6c\xc5\x47\xcc\x13\xad\xba\xf2\x54\x44\xef\x4c\x53\xe1\x5e\x7b\xee\x8f\x9a\x10\xd5\xfc\x8d\xe7\x4e\xef\x24\xc6\x5e\x81\x5f\xec\x51\x75\xf4\x61\x55\x57\x45\x08\x17\x4a\x9b\x4a\xa5\xc5\xf0\xd5\xb9\x14\x5f\x55\x9a\x9b\x26\x5f\x93\xe6\x76\xb2\x45\x9a\xb2\xef\xbf\xd1\xa1\xe8\xf5\x0d\xcd\xa9\x1e\x5f\xae\x6f\xa3\xa9\x1b\x95\x06\x5e\x13\x58\xc3\xb7\x54\x57\xa5\xe7\xbf\x1b\x59\x7d\xf7\xcd\x9d\xa0\x8c\x6d\x5e\x02\x8c\x6a\xc3\xaf\x09\xaa\xe9\xbd\x95\x53\xf5\x92\x70\x30\x78\x97\xa8\xb6\xa7\xdb\xf1\x74\x85\x8f\xa3\xab\xef\xdf\xbe\x96\xc2\xda\x8e\xff\x2b\xeb\x9f\x52\x56\xf4\x01\x65\xf5\x2b\xff\xf0\xcc\xec\x25\xd5\x34\xd3\x73\xef\xba\xee\x6e\x97\xbb\xac\xd1\x4e\x6b\x72\xa9\xfd\x06\xc7\x05\x04\xac\x4e\x0e\x00\x00")
+
+func migrations25_add_trade_rollupsSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations25_add_trade_rollupsSql,
+		"migrations/25_add_trade_rollups.sql",
+	)
+}
+
+func migrations25_add_trade_rollupsSql() (*asset, error) {
+	bytes, err := migrations25_add_trade_rollupsSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/25_add_trade_rollups.sql", size: 3662, mode: os.FileMode(420), modTime: time.Unix(1792291214, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
+var _migrations26_add_history_asset_and_amount_indexesSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xb5\x95\x61\x6b\xab\x30\x14\x86\xbf\xfb\x2b\x0e\xfb\xb2\x96\xd5\xfd\x80\x0e\x06\xa5\x95\xcb\x60\xb4\x97\xed\x0e\xf6\x4d\x62\x72\xac\x01\x9b\x48\x12\xb9\xf5\xdf\xdf\xd8\xd8\x12\xe7\x56\xe3\xc6\x85\x52\xa4\x7d\xde\x78\x9e\xe4\x78\x8c\x63\xb8\x3b\xf0\xbd\x22\x06\xe1\xad\x8a\xa2\x38\x86\x95\xd6\x68\x34\x10\x85\x40\x4a\x2d\x41\x21\x95\x8a\x21\x03\x2e\xa0\x52\x98\xf3\xa3\xbd\x66\x68\x08\x2f\xf5\x02\x74\x4d\x0b\x20\x1a\x4c\x81\xa0\x65\xad\xa8\x4d\xb5\x0b\x80\xcc\xdb\xc5\x2a\x62\x0a\xfb\xd5\x1c\x50\x18\x4b\xb7\x14\x71\xeb\xcb\xdc\x7e\x72\x54\xf6\x4e\x82\xf9\x7f\x18\x45\xda\xbb\x65\x8d\xbb\x02\xb4\x14\x35\xfa\x3e\x5a\xbf\x24\xab\x3f\x09\x3c\x6d\x37\xc9\xbb\x2d\x86\xe1\x31\x2d\xb8\x36\x52\x35\xa9\xac\xd0\x2a\x70\x29\x74\x2a\x45\xea\xea\x48\x5d\x1d\xbb\x2d\x0c\x29\x78\x7b\x7d\xda\xfe\x82\xcc\x28\x44\x98\xcd\x66\x9d\x0e\xc4\x8f\x8f\x70\xeb\xc7\x53\xd3\x54\x78\xbb\x5c\x1a\x3c\x9a\xf9\x7c\x01\xd7\x50\x2a\x59\x28\xca\xb5\xae\x51\x79\x30\x67\xf3\x87\x09\x82\x58\x96\x5c\xec\xbf\x6f\xe8\xe7\xc7\x14\x7b\xec\x88\x63\x8f\xfd\xa1\x64\x56\x37\x3f\x71\xf4\xe3\x23\x8a\x3d\xf4\xba\x61\x0f\x9d\x2c\xd8\xb5\xb2\xeb\xd1\x92\x0d\xdd\x3a\x60\xa4\x3d\xcf\xc9\xd1\xe6\xbc\x80\x63\xad\x79\x01\x07\x4a\x83\x5d\x4f\x39\x5b\xc0\x4d\x3b\x11\xd4\x4d\xb0\x6d\x26\xeb\x7d\x61\xbe\xe7\xeb\x67\xc7\x0e\xd2\x47\x47\x0e\xd2\x47\x27\x5b\x9f\xe6\xe4\x41\xd6\xa7\xa1\x26\x15\x68\x43\x94\xb1\x8d\x01\x19\x29\x89\xa0\xa8\x21\xb7\xbf\x12\x4a\x5b\x04\xa8\x42\xd7\xb0\x0b\xc8\x79\x69\x50\xb9\xf1\xa6\x88\xd8\xe3\x84\xb1\x46\x4e\x37\x0c\x7b\x14\xd6\xbb\xd5\x73\xf2\xba\x4e\x3e\x58\xbb\x25\xce\xa2\x76\x53\xfa\x7d\xd0\x59\xa4\x9d\xc5\x65\x43\xe6\xcb\xa5\xa8\x0f\xa8\x38\x9d\xd6\xe4\xc3\x8a\xbf\x38\xf0\xff\x58\x6e\xc8\x51\x5e\x5e\x81\x1b\xf9\x57\x44\xd1\xe6\x65\xf7\x7b\xe2\x6b\xe6\x21\x38\xe4\x8f\xc8\xe0\x94\x3f\x76\x82\x43\x6e\xf7\xae\xe0\x9f\x8e\xa3\x30\xde\x7f\x7c\xc2\x12\xe7\x6a\xfe\x01\x8f\x2f\xdf\xe8\x72\x08\x00\x00")
+
+func migrations26_add_history_asset_and_amount_indexesSqlBytes() ([]byte, error) {
+	return bindataRead(
+		_migrations26_add_history_asset_and_amount_indexesSql,
+		"migrations/26_add_history_asset_and_amount_indexes.sql",
+	)
+}
+
+func migrations26_add_history_asset_and_amount_indexesSql() (*asset, error) {
+	bytes, err := migrations26_add_history_asset_and_amount_indexesSqlBytes()
+	if err != nil {
+		return nil, err
+	}
+
+	info := bindataFileInfo{name: "migrations/26_add_history_asset_and_amount_indexes.sql", size: 2162, mode: os.FileMode(420), modTime: time.Unix(1792293813, 0)}
+	a := &asset{bytes: bytes, info: info}
+	return a, nil
+}
+
 var _migrations2_index_participants_by_toidSql = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\x8c\x8f\xb1\xca\xc2\x50\x0c\x46\xf7\x3c\x45\xc6\xff\x47\xfa\x04\x9d\xc4\x16\xe9\xd2\x4a\xb5\xe0\x76\x49\xdb\x8b\xcd\xe0\xcd\x25\x37\x20\x7d\x7b\x41\x07\x5b\xbb\xb8\x86\x8f\x73\x72\xb2\x0c\x77\x77\xbe\x29\x99\xc7\x2e\x02\x1c\xda\x72\x7f\x29\xb1\xaa\x8b\xf2\x8a\x93\x44\xd7\xcf\x6e\x12\x1e\xb1\xa9\x71\xe2\x64\xa2\xb3\x93\xe8\x95\x8c\x25\xb8\x48\x6a\x3c\x70\xa4\x60\x09\xbb\x73\x55\x1f\xb1\x37\xf5\x1e\xff\xb6\x5b\x1e\xff\xf3\x2f\xbc\xbd\xf1\xb6\xc6\x9b\x52\x48\x34\xfc\x28\x58\xae\x5f\x0a\x58\x26\x15\xf2\x08\x00\x45\xdb\x9c\xb6\x49\xf9\xea\xfe\xf9\x25\x87\x67\x00\x00\x00\xff\xff\x33\xec\x54\x7a\x15\x01\x00\x00")
 
 func migrations2_index_participants_by_toidSqlBytes() ([]byte, error) {
@@ -497,24 +707,34 @@ func AssetNames() []string {
 
 // _bindata is a table, holding each asset generator, mapped to its name.
 var _bindata = map[string]func() (*asset, error){
-	"latest.sql":                                        latestSql,
-	"migrations/10_add_trades_price.sql":                migrations10_add_trades_priceSql,
-	"migrations/11_add_trades_account_index.sql":        migrations11_add_trades_account_indexSql,
-	"migrations/12_asset_stats_amount_string.sql":       migrations12_asset_stats_amount_stringSql,
-	"migrations/13_trade_offer_ids.sql":                 migrations13_trade_offer_idsSql,
-	"migrations/14_fix_asset_toml_field.sql":            migrations14_fix_asset_toml_fieldSql,
-	"migrations/15_ledger_failed_txs.sql":               migrations15_ledger_failed_txsSql,
-	"migrations/16_ingest_failed_transactions.sql":      migrations16_ingest_failed_transactionsSql,
-	"migrations/1_initial_schema.sql":                   migrations1_initial_schemaSql,
-	"migrations/2_index_participants_by_toid.sql":       migrations2_index_participants_by_toidSql,
-	"migrations/3_use_sequence_in_history_accounts.sql": migrations3_use_sequence_in_history_accountsSql,
-	"migrations/4_add_protocol_version.sql":             migrations4_add_protocol_versionSql,
-	"migrations/5_create_trades_table.sql":              migrations5_create_trades_tableSql,
-	"migrations/6_create_assets_table.sql":              migrations6_create_assets_tableSql,
-	"migrations/7_modify_trades_table.sql":              migrations7_modify_trades_tableSql,
-	"migrations/8_add_aggregators.sql":                  migrations8_add_aggregatorsSql,
-	"migrations/8_create_asset_stats_table.sql":         migrations8_create_asset_stats_tableSql,
-	"migrations/9_add_header_xdr.sql":                   migrations9_add_header_xdrSql,
+	"latest.sql":                                             latestSql,
+	"migrations/10_add_trades_price.sql":                     migrations10_add_trades_priceSql,
+	"migrations/11_add_trades_account_index.sql":             migrations11_add_trades_account_indexSql,
+	"migrations/12_asset_stats_amount_string.sql":            migrations12_asset_stats_amount_stringSql,
+	"migrations/13_trade_offer_ids.sql":                      migrations13_trade_offer_idsSql,
+	"migrations/14_fix_asset_toml_field.sql":                 migrations14_fix_asset_toml_fieldSql,
+	"migrations/15_ledger_failed_txs.sql":                    migrations15_ledger_failed_txsSql,
+	"migrations/16_ingest_failed_transactions.sql":           migrations16_ingest_failed_transactionsSql,
+	"migrations/17_add_ledger_entry_changes.sql":             migrations17_add_ledger_entry_changesSql,
+	"migrations/18_add_ledger_entry_changes_key.sql":         migrations18_add_ledger_entry_changes_keySql,
+	"migrations/19_add_ingestion_jobs.sql":                   migrations19_add_ingestion_jobsSql,
+	"migrations/1_initial_schema.sql":                        migrations1_initial_schemaSql,
+	"migrations/20_add_txsub_open_submissions.sql":           migrations20_add_txsub_open_submissionsSql,
+	"migrations/21_add_history_filter_indexes.sql":           migrations21_add_history_filter_indexesSql,
+	"migrations/22_add_transactions_memo_index.sql":          migrations22_add_transactions_memo_indexSql,
+	"migrations/23_extend_asset_stats.sql":                   migrations23_extend_asset_statsSql,
+	"migrations/24_create_history_markets.sql":               migrations24_create_history_marketsSql,
+	"migrations/25_add_trade_rollups.sql":                    migrations25_add_trade_rollupsSql,
+	"migrations/26_add_history_asset_and_amount_indexes.sql": migrations26_add_history_asset_and_amount_indexesSql,
+	"migrations/2_index_participants_by_toid.sql":            migrations2_index_participants_by_toidSql,
+	"migrations/3_use_sequence_in_history_accounts.sql":      migrations3_use_sequence_in_history_accountsSql,
+	"migrations/4_add_protocol_version.sql":                  migrations4_add_protocol_versionSql,
+	"migrations/5_create_trades_table.sql":                   migrations5_create_trades_tableSql,
+	"migrations/6_create_assets_table.sql":                   migrations6_create_assets_tableSql,
+	"migrations/7_modify_trades_table.sql":                   migrations7_modify_trades_tableSql,
+	"migrations/8_add_aggregators.sql":                       migrations8_add_aggregatorsSql,
+	"migrations/8_create_asset_stats_table.sql":              migrations8_create_asset_stats_tableSql,
+	"migrations/9_add_header_xdr.sql":                        migrations9_add_header_xdrSql,
 }
 
 // AssetDir returns the file names below a certain
@@ -560,23 +780,33 @@ type bintree struct {
 var _bintree = &bintree{nil, map[string]*bintree{
 	"latest.sql": &bintree{latestSql, map[string]*bintree{}},
 	"migrations": &bintree{nil, map[string]*bintree{
-		"10_add_trades_price.sql":                &bintree{migrations10_add_trades_priceSql, map[string]*bintree{}},
-		"11_add_trades_account_index.sql":        &bintree{migrations11_add_trades_account_indexSql, map[string]*bintree{}},
-		"12_asset_stats_amount_string.sql":       &bintree{migrations12_asset_stats_amount_stringSql, map[string]*bintree{}},
-		"13_trade_offer_ids.sql":                 &bintree{migrations13_trade_offer_idsSql, map[string]*bintree{}},
-		"14_fix_asset_toml_field.sql":            &bintree{migrations14_fix_asset_toml_fieldSql, map[string]*bintree{}},
-		"15_ledger_failed_txs.sql":               &bintree{migrations15_ledger_failed_txsSql, map[string]*bintree{}},
-		"16_ingest_failed_transactions.sql":      &bintree{migrations16_ingest_failed_transactionsSql, map[string]*bintree{}},
-		"1_initial_schema.sql":                   &bintree{migrations1_initial_schemaSql, map[string]*bintree{}},
-		"2_index_participants_by_toid.sql":       &bintree{migrations2_index_participants_by_toidSql, map[string]*bintree{}},
-		"3_use_sequence_in_history_accounts.sql": &bintree{migrations3_use_sequence_in_history_accountsSql, map[string]*bintree{}},
-		"4_add_protocol_version.sql":             &bintree{migrations4_add_protocol_versionSql, map[string]*bintree{}},
-		"5_create_trades_table.sql":              &bintree{migrations5_create_trades_tableSql, map[string]*bintree{}},
-		"6_create_assets_table.sql":              &bintree{migrations6_create_assets_tableSql, map[string]*bintree{}},
-		"7_modify_trades_table.sql":              &bintree{migrations7_modify_trades_tableSql, map[string]*bintree{}},
-		"8_add_aggregators.sql":                  &bintree{migrations8_add_aggregatorsSql, map[string]*bintree{}},
-		"8_create_asset_stats_table.sql":         &bintree{migrations8_create_asset_stats_tableSql, map[string]*bintree{}},
-		"9_add_header_xdr.sql":                   &bintree{migrations9_add_header_xdrSql, map[string]*bintree{}},
+		"10_add_trades_price.sql":                     &bintree{migrations10_add_trades_priceSql, map[string]*bintree{}},
+		"11_add_trades_account_index.sql":             &bintree{migrations11_add_trades_account_indexSql, map[string]*bintree{}},
+		"12_asset_stats_amount_string.sql":            &bintree{migrations12_asset_stats_amount_stringSql, map[string]*bintree{}},
+		"13_trade_offer_ids.sql":                      &bintree{migrations13_trade_offer_idsSql, map[string]*bintree{}},
+		"14_fix_asset_toml_field.sql":                 &bintree{migrations14_fix_asset_toml_fieldSql, map[string]*bintree{}},
+		"15_ledger_failed_txs.sql":                    &bintree{migrations15_ledger_failed_txsSql, map[string]*bintree{}},
+		"16_ingest_failed_transactions.sql":           &bintree{migrations16_ingest_failed_transactionsSql, map[string]*bintree{}},
+		"17_add_ledger_entry_changes.sql":             &bintree{migrations17_add_ledger_entry_changesSql, map[string]*bintree{}},
+		"18_add_ledger_entry_changes_key.sql":         &bintree{migrations18_add_ledger_entry_changes_keySql, map[string]*bintree{}},
+		"19_add_ingestion_jobs.sql":                   &bintree{migrations19_add_ingestion_jobsSql, map[string]*bintree{}},
+		"1_initial_schema.sql":                        &bintree{migrations1_initial_schemaSql, map[string]*bintree{}},
+		"20_add_txsub_open_submissions.sql":           &bintree{migrations20_add_txsub_open_submissionsSql, map[string]*bintree{}},
+		"21_add_history_filter_indexes.sql":           &bintree{migrations21_add_history_filter_indexesSql, map[string]*bintree{}},
+		"22_add_transactions_memo_index.sql":          &bintree{migrations22_add_transactions_memo_indexSql, map[string]*bintree{}},
+		"23_extend_asset_stats.sql":                   &bintree{migrations23_extend_asset_statsSql, map[string]*bintree{}},
+		"24_create_history_markets.sql":               &bintree{migrations24_create_history_marketsSql, map[string]*bintree{}},
+		"25_add_trade_rollups.sql":                    &bintree{migrations25_add_trade_rollupsSql, map[string]*bintree{}},
+		"26_add_history_asset_and_amount_indexes.sql": &bintree{migrations26_add_history_asset_and_amount_indexesSql, map[string]*bintree{}},
+		"2_index_participants_by_toid.sql":            &bintree{migrations2_index_participants_by_toidSql, map[string]*bintree{}},
+		"3_use_sequence_in_history_accounts.sql":      &bintree{migrations3_use_sequence_in_history_accountsSql, map[string]*bintree{}},
+		"4_add_protocol_version.sql":                  &bintree{migrations4_add_protocol_versionSql, map[string]*bintree{}},
+		"5_create_trades_table.sql":                   &bintree{migrations5_create_trades_tableSql, map[string]*bintree{}},
+		"6_create_assets_table.sql":                   &bintree{migrations6_create_assets_tableSql, map[string]*bintree{}},
+		"7_modify_trades_table.sql":                   &bintree{migrations7_modify_trades_tableSql, map[string]*bintree{}},
+		"8_add_aggregators.sql":                       &bintree{migrations8_add_aggregatorsSql, map[string]*bintree{}},
+		"8_create_asset_stats_table.sql":              &bintree{migrations8_create_asset_stats_tableSql, map[string]*bintree{}},
+		"9_add_header_xdr.sql":                        &bintree{migrations9_add_header_xdrSql, map[string]*bintree{}},
 	}},
 }}
 
@@ -0,0 +1,18 @@
+-- +migrate Up
+
+CREATE TABLE history_ledger_entry_changes (
+    history_account_id bigint NOT NULL,
+    history_operation_id bigint NOT NULL,
+    "order" integer NOT NULL,
+    entry_type integer NOT NULL,
+    change_type integer NOT NULL,
+    entry_before text,
+    entry_after text
+);
+
+CREATE UNIQUE INDEX hist_lec_by_order ON history_ledger_entry_changes USING btree (history_operation_id, "order");
+CREATE UNIQUE INDEX hist_lec_id ON history_ledger_entry_changes USING btree (history_account_id, history_operation_id, "order");
+
+-- +migrate Down
+
+DROP TABLE history_ledger_entry_changes cascade;
@@ -0,0 +1,10 @@
+-- +migrate Up
+
+ALTER TABLE history_ledger_entry_changes ADD ledger_key text;
+
+CREATE INDEX hist_lec_by_key ON history_ledger_entry_changes USING btree (history_account_id, ledger_key, history_operation_id, "order");
+
+-- +migrate Down
+
+DROP INDEX hist_lec_by_key;
+ALTER TABLE history_ledger_entry_changes DROP ledger_key;
@@ -0,0 +1,19 @@
+-- +migrate Up
+
+CREATE TABLE ingestion_jobs (
+    id BIGSERIAL PRIMARY KEY,
+    kind character varying(32) NOT NULL,
+    first_ledger integer NOT NULL,
+    last_ledger integer NOT NULL,
+    last_ingested_ledger integer,
+    status character varying(16) NOT NULL,
+    error text,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+CREATE INDEX ingestion_jobs_by_range ON ingestion_jobs USING btree (kind, first_ledger, last_ledger);
+
+-- +migrate Down
+
+DROP TABLE ingestion_jobs cascade;
@@ -0,0 +1,12 @@
+-- +migrate Up
+
+CREATE TABLE txsub_open_submissions (
+    hash character varying(64) NOT NULL,
+    submitted_at timestamp without time zone NOT NULL
+);
+
+CREATE UNIQUE INDEX txsub_open_submissions_by_hash ON txsub_open_submissions USING btree (hash);
+
+-- +migrate Down
+
+DROP TABLE txsub_open_submissions cascade;
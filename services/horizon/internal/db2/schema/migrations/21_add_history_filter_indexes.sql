@@ -0,0 +1,13 @@
+-- +migrate Up
+
+CREATE INDEX index_history_operations_on_type_and_id ON history_operations USING btree (type, id);
+CREATE INDEX index_history_operations_on_asset ON history_operations USING btree (((details ->> 'asset_type'::text)), ((details ->> 'asset_code'::text)), ((details ->> 'asset_issuer'::text)), id);
+CREATE INDEX index_history_effects_on_type_and_id ON history_effects USING btree (type, history_operation_id, "order");
+CREATE INDEX index_history_effects_on_asset ON history_effects USING btree (((details ->> 'asset_type'::text)), ((details ->> 'asset_code'::text)), ((details ->> 'asset_issuer'::text)), history_operation_id, "order");
+
+-- +migrate Down
+
+DROP INDEX index_history_operations_on_type_and_id;
+DROP INDEX index_history_operations_on_asset;
+DROP INDEX index_history_effects_on_type_and_id;
+DROP INDEX index_history_effects_on_asset;
@@ -0,0 +1,24 @@
+-- +migrate Up
+
+ALTER TABLE asset_stats
+    ADD COLUMN num_unauthorized_accounts integer DEFAULT 0 NOT NULL,
+    ADD COLUMN bid_depth character varying DEFAULT '0' NOT NULL,
+    ADD COLUMN ask_depth character varying DEFAULT '0' NOT NULL;
+
+CREATE TABLE asset_stats_snapshots (
+    id bigint NOT NULL REFERENCES history_assets(id) ON UPDATE RESTRICT ON DELETE CASCADE,
+    day date NOT NULL,
+    amount character varying NOT NULL,
+    num_accounts integer NOT NULL
+);
+
+CREATE UNIQUE INDEX asset_stats_snapshots_by_asset_and_day ON asset_stats_snapshots USING btree (id, day);
+
+-- +migrate Down
+
+DROP TABLE asset_stats_snapshots;
+
+ALTER TABLE asset_stats
+    DROP COLUMN num_unauthorized_accounts,
+    DROP COLUMN bid_depth,
+    DROP COLUMN ask_depth;
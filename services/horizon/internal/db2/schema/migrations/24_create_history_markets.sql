@@ -0,0 +1,21 @@
+-- +migrate Up
+
+CREATE TABLE history_markets (
+    base_asset_id bigint NOT NULL REFERENCES history_assets(id),
+    counter_asset_id bigint NOT NULL REFERENCES history_assets(id),
+    first_trade_at timestamp without time zone NOT NULL,
+    last_trade_at timestamp without time zone NOT NULL,
+    CHECK (base_asset_id < counter_asset_id)
+);
+
+CREATE UNIQUE INDEX hmkt_by_pair ON history_markets USING btree (base_asset_id, counter_asset_id);
+CREATE INDEX hmkt_by_last_trade ON history_markets USING btree (last_trade_at);
+
+INSERT INTO history_markets (base_asset_id, counter_asset_id, first_trade_at, last_trade_at)
+    SELECT base_asset_id, counter_asset_id, min(ledger_closed_at), max(ledger_closed_at)
+    FROM history_trades
+    GROUP BY base_asset_id, counter_asset_id;
+
+-- +migrate Down
+
+DROP TABLE history_markets;
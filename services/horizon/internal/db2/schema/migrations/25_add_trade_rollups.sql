@@ -0,0 +1,77 @@
+-- +migrate Up
+
+-- Trade aggregations precomputed by minute, hour and day.  Prices are
+-- represented as arrays of two elements [n,d], as expected by the max_price,
+-- min_price, first and last aggregates.
+CREATE TABLE history_trades_1m (
+    base_asset_id bigint NOT NULL REFERENCES history_assets(id),
+    counter_asset_id bigint NOT NULL REFERENCES history_assets(id),
+    "timestamp" bigint NOT NULL,
+    count integer NOT NULL,
+    base_volume numeric NOT NULL,
+    counter_volume numeric NOT NULL,
+    high numeric[] NOT NULL,
+    low numeric[] NOT NULL,
+    open numeric[] NOT NULL,
+    open_operation_id bigint NOT NULL,
+    open_order integer NOT NULL,
+    close numeric[] NOT NULL,
+    close_operation_id bigint NOT NULL,
+    close_order integer NOT NULL
+);
+
+CREATE TABLE history_trades_1h (LIKE history_trades_1m INCLUDING ALL);
+ALTER TABLE history_trades_1h
+    ADD FOREIGN KEY (base_asset_id) REFERENCES history_assets(id),
+    ADD FOREIGN KEY (counter_asset_id) REFERENCES history_assets(id);
+
+CREATE TABLE history_trades_1d (LIKE history_trades_1m INCLUDING ALL);
+ALTER TABLE history_trades_1d
+    ADD FOREIGN KEY (base_asset_id) REFERENCES history_assets(id),
+    ADD FOREIGN KEY (counter_asset_id) REFERENCES history_assets(id);
+
+CREATE UNIQUE INDEX htrd_1m_by_pair_and_time ON history_trades_1m USING btree (base_asset_id, counter_asset_id, "timestamp");
+CREATE UNIQUE INDEX htrd_1h_by_pair_and_time ON history_trades_1h USING btree (base_asset_id, counter_asset_id, "timestamp");
+CREATE UNIQUE INDEX htrd_1d_by_pair_and_time ON history_trades_1d USING btree (base_asset_id, counter_asset_id, "timestamp");
+
+INSERT INTO history_trades_1m
+    SELECT base_asset_id, counter_asset_id,
+        div(cast((extract(epoch from ledger_closed_at) * 1000) as bigint), 60000) * 60000 as "timestamp",
+        count(*), sum(base_amount), sum(counter_amount),
+        max_price(ARRAY[price_n, price_d]), min_price(ARRAY[price_n, price_d]),
+        first(ARRAY[price_n, price_d] ORDER BY history_operation_id, "order"),
+        min(history_operation_id), first("order" ORDER BY history_operation_id, "order"),
+        last(ARRAY[price_n, price_d] ORDER BY history_operation_id, "order"),
+        max(history_operation_id), last("order" ORDER BY history_operation_id, "order")
+    FROM history_trades
+    GROUP BY base_asset_id, counter_asset_id, 3;
+
+INSERT INTO history_trades_1h
+    SELECT base_asset_id, counter_asset_id,
+        div("timestamp", 3600000) * 3600000,
+        sum(count), sum(base_volume), sum(counter_volume),
+        max_price(high), min_price(low),
+        first(open ORDER BY open_operation_id, open_order),
+        min(open_operation_id), first(open_order ORDER BY open_operation_id, open_order),
+        last(close ORDER BY close_operation_id, close_order),
+        max(close_operation_id), last(close_order ORDER BY close_operation_id, close_order)
+    FROM history_trades_1m
+    GROUP BY base_asset_id, counter_asset_id, 3;
+
+INSERT INTO history_trades_1d
+    SELECT base_asset_id, counter_asset_id,
+        div("timestamp", 86400000) * 86400000,
+        sum(count), sum(base_volume), sum(counter_volume),
+        max_price(high), min_price(low),
+        first(open ORDER BY open_operation_id, open_order),
+        min(open_operation_id), first(open_order ORDER BY open_operation_id, open_order),
+        last(close ORDER BY close_operation_id, close_order),
+        max(close_operation_id), last(close_order ORDER BY close_operation_id, close_order)
+    FROM history_trades_1h
+    GROUP BY base_asset_id, counter_asset_id, 3;
+
+-- +migrate Down
+
+DROP TABLE history_trades_1d;
+DROP TABLE history_trades_1h;
+DROP TABLE history_trades_1m;
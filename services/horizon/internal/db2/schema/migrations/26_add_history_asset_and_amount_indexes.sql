@@ -0,0 +1,23 @@
+-- +migrate Up
+
+-- Assets are also recorded in prefixed details, such as the source asset of
+-- path payments, the assets of offers and the assets traded by trade effects.
+CREATE INDEX index_history_operations_on_source_asset ON history_operations USING btree (((details ->> 'source_asset_type'::text)), ((details ->> 'source_asset_code'::text)), ((details ->> 'source_asset_issuer'::text)), id);
+CREATE INDEX index_history_operations_on_selling_asset ON history_operations USING btree (((details ->> 'selling_asset_type'::text)), ((details ->> 'selling_asset_code'::text)), ((details ->> 'selling_asset_issuer'::text)), id);
+CREATE INDEX index_history_operations_on_buying_asset ON history_operations USING btree (((details ->> 'buying_asset_type'::text)), ((details ->> 'buying_asset_code'::text)), ((details ->> 'buying_asset_issuer'::text)), id);
+CREATE INDEX index_history_effects_on_sold_asset ON history_effects USING btree (((details ->> 'sold_asset_type'::text)), ((details ->> 'sold_asset_code'::text)), ((details ->> 'sold_asset_issuer'::text)), history_operation_id, "order");
+CREATE INDEX index_history_effects_on_bought_asset ON history_effects USING btree (((details ->> 'bought_asset_type'::text)), ((details ->> 'bought_asset_code'::text)), ((details ->> 'bought_asset_issuer'::text)), history_operation_id, "order");
+
+-- Amounts, or starting balances for account creations, filtered by range.
+CREATE INDEX index_history_operations_on_amount ON history_operations USING btree (((COALESCE((details ->> 'amount'::text), (details ->> 'starting_balance'::text)))::numeric), id);
+CREATE INDEX index_history_effects_on_amount ON history_effects USING btree (((COALESCE((details ->> 'amount'::text), (details ->> 'starting_balance'::text)))::numeric), history_operation_id, "order");
+
+-- +migrate Down
+
+DROP INDEX index_history_operations_on_source_asset;
+DROP INDEX index_history_operations_on_selling_asset;
+DROP INDEX index_history_operations_on_buying_asset;
+DROP INDEX index_history_operations_on_amount;
+DROP INDEX index_history_effects_on_sold_asset;
+DROP INDEX index_history_effects_on_bought_asset;
+DROP INDEX index_history_effects_on_amount;
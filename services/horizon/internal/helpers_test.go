@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/http"
 	"net/http/httptest"
 	"time"
 
@@ -75,3 +76,14 @@ func NewTestAction(ctx context.Context, path string) *Action {
 		},
 	}
 }
+
+// RequestHelperStreamOnce requests a stream whose context is already done, so
+// that the stream returns after sending the events available at request time
+// instead of waiting for the next ledger to close.
+func RequestHelperStreamOnce(r *http.Request) {
+	test.RequestHelperStreaming(r)
+
+	ctx, cancel := context.WithCancel(r.Context())
+	cancel()
+	*r = *r.WithContext(ctx)
+}
@@ -0,0 +1,213 @@
+package ingest
+
+import (
+	"encoding/hex"
+	"io"
+
+	"github.com/cowry-network/go/network"
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/support/errors"
+	"github.com/cowry-network/go/support/historyarchive"
+	"github.com/cowry-network/go/xdr"
+)
+
+// archiveCheckpointCacheSize is the number of checkpoints an
+// ArchiveLedgerSource keeps in memory.
+const archiveCheckpointCacheSize = 16
+
+var _ LedgerSource = (*ArchiveLedgerSource)(nil)
+
+// LatestLedger returns the latest ledger published to the archive.
+func (s *ArchiveLedgerSource) LatestLedger() (int32, error) {
+	has, err := s.Archive.GetRootHAS()
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to load history archive state")
+	}
+
+	return int32(has.CurrentLedger), nil
+}
+
+// LoadLedger loads the header, transactions and results of a ledger from the
+// checkpoint containing it.  The checkpoints last loaded are kept in memory, so
+// that sessions iterating through consecutive ledgers, possibly concurrently,
+// read each checkpoint once.
+func (s *ArchiveLedgerSource) LoadLedger(lb *LedgerBundle) error {
+	seq := uint32(lb.Sequence)
+	ledgers, err := s.checkpointLedgers(checkpointContaining(seq))
+	if err != nil {
+		return err
+	}
+
+	ledger, ok := ledgers[seq]
+	if !ok {
+		return errors.Errorf("ledger %d not found in history archive", seq)
+	}
+
+	header := ledger.header.Header
+	lb.Header = core.LedgerHeader{
+		LedgerHash:     hex.EncodeToString(ledger.header.Hash[:]),
+		PrevHash:       hex.EncodeToString(header.PreviousLedgerHash[:]),
+		BucketListHash: hex.EncodeToString(header.BucketListHash[:]),
+		CloseTime:      int64(header.ScpValue.CloseTime),
+		Sequence:       uint32(header.LedgerSeq),
+		Data:           header,
+	}
+
+	// Transaction sets are sorted by hash, whereas results are recorded in the
+	// order transactions were applied, which is the order horizon ingests.
+	envelopes := map[string]xdr.TransactionEnvelope{}
+	for _, envelope := range ledger.transactions.TxSet.Txs {
+		hash, err := network.HashTransaction(&envelope.Tx, s.Network)
+		if err != nil {
+			return errors.Wrap(err, "failed to hash transaction")
+		}
+		envelopes[hex.EncodeToString(hash[:])] = envelope
+	}
+
+	results := ledger.results.TxResultSet.Results
+	lb.Transactions = make([]core.Transaction, 0, len(results))
+	lb.TransactionFees = make([]core.TransactionFee, 0, len(results))
+	lb.WithoutMeta = true
+
+	for i, result := range results {
+		hash := hex.EncodeToString(result.TransactionHash[:])
+		envelope, ok := envelopes[hash]
+		if !ok {
+			return errors.Errorf("transaction %s of ledger %d not found in its transaction set", hash, seq)
+		}
+
+		lb.Transactions = append(lb.Transactions, core.Transaction{
+			TransactionHash: hash,
+			LedgerSequence:  lb.Sequence,
+			Index:           int32(i + 1),
+			Envelope:        envelope,
+			Result:          result,
+			ResultMeta:      xdr.TransactionMeta{Operations: &[]xdr.OperationMeta{}},
+		})
+		lb.TransactionFees = append(lb.TransactionFees, core.TransactionFee{
+			TransactionHash: hash,
+			LedgerSequence:  lb.Sequence,
+			Index:           int32(i + 1),
+			Changes:         xdr.LedgerEntryChanges{},
+		})
+	}
+
+	return nil
+}
+
+// checkpointLedgers returns the ledgers of `checkpoint`, from the cache when
+// possible.  The archive is read without holding the lock, so that concurrent
+// sessions needing different checkpoints do not wait on each other.
+func (s *ArchiveLedgerSource) checkpointLedgers(checkpoint uint32) (map[uint32]*archiveLedger, error) {
+	s.lock.Lock()
+	ledgers, ok := s.checkpoints[checkpoint]
+	s.lock.Unlock()
+	if ok {
+		return ledgers, nil
+	}
+
+	ledgers, err := s.loadCheckpoint(checkpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to load checkpoint %d", checkpoint)
+	}
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.checkpoints == nil {
+		s.checkpoints = map[uint32]map[uint32]*archiveLedger{}
+	}
+	if _, ok := s.checkpoints[checkpoint]; !ok {
+		// evict the checkpoints loaded first
+		for len(s.loaded) >= archiveCheckpointCacheSize {
+			delete(s.checkpoints, s.loaded[0])
+			s.loaded = s.loaded[1:]
+		}
+		s.checkpoints[checkpoint] = ledgers
+		s.loaded = append(s.loaded, checkpoint)
+	}
+
+	return ledgers, nil
+}
+
+// loadCheckpoint reads the ledger headers, transaction sets and results of
+// every ledger in `checkpoint`.
+func (s *ArchiveLedgerSource) loadCheckpoint(checkpoint uint32) (map[uint32]*archiveLedger, error) {
+	ledgers := map[uint32]*archiveLedger{}
+
+	err := s.readCategory("ledger", checkpoint, func(stream *historyarchive.XdrStream) error {
+		var entry xdr.LedgerHeaderHistoryEntry
+		if err := stream.ReadOne(&entry); err != nil {
+			return err
+		}
+		ledgers[uint32(entry.Header.LedgerSeq)] = &archiveLedger{header: entry}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	// Only ledgers which applied transactions have entries in the transactions
+	// and results categories.
+	err = s.readCategory("transactions", checkpoint, func(stream *historyarchive.XdrStream) error {
+		var entry xdr.TransactionHistoryEntry
+		if err := stream.ReadOne(&entry); err != nil {
+			return err
+		}
+		if ledger, ok := ledgers[uint32(entry.LedgerSeq)]; ok {
+			ledger.transactions = entry
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.readCategory("results", checkpoint, func(stream *historyarchive.XdrStream) error {
+		var entry xdr.TransactionHistoryResultEntry
+		if err := stream.ReadOne(&entry); err != nil {
+			return err
+		}
+		if ledger, ok := ledgers[uint32(entry.LedgerSeq)]; ok {
+			ledger.results = entry
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ledgers, nil
+}
+
+// readCategory calls `readOne` until the file of category `cat` for
+// `checkpoint` has been read entirely.
+func (s *ArchiveLedgerSource) readCategory(
+	cat string,
+	checkpoint uint32,
+	readOne func(*historyarchive.XdrStream) error,
+) error {
+	stream, err := s.Archive.GetXdrStream(historyarchive.CategoryCheckpointPath(cat, checkpoint))
+	if err != nil {
+		return errors.Wrapf(err, "failed to open %s file", cat)
+	}
+	defer stream.Close()
+
+	for {
+		err = readOne(stream)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "failed to read %s file", cat)
+		}
+	}
+}
+
+// checkpointContaining returns the checkpoint a ledger is published in.  A
+// checkpoint is named after its last ledger, so that the first one, 63,
+// contains ledgers 1 through 63 and the following ones 64 ledgers each.
+func checkpointContaining(seq uint32) uint32 {
+	freq := historyarchive.CheckpointFreq
+	return (seq/freq+1)*freq - 1
+}
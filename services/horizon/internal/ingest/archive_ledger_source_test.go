@@ -0,0 +1,131 @@
+package ingest
+
+import (
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/cowry-network/go/network"
+	"github.com/cowry-network/go/support/historyarchive"
+	"github.com/cowry-network/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestArchiveLedgerSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "horizon-archive")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	var envelope xdr.TransactionEnvelope
+	err = xdr.SafeUnmarshalBase64("AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAACgAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAEAKZ7IPj/46PuWU6ZOtyMosctNAkXRNX9WCAI5RnfRk+AyxDLoDZP/9l3NvsxQtWj9juQOuoBlFLnWu8intgxQA", &envelope)
+	require.NoError(t, err)
+	hash, err := network.HashTransaction(&envelope.Tx, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+
+	// ledger 2 is empty, ledger 3 applied a single transaction
+	var headers []interface{}
+	for seq := 1; seq <= 63; seq++ {
+		headers = append(headers, xdr.LedgerHeaderHistoryEntry{
+			Hash: xdr.Hash{byte(seq)},
+			Header: xdr.LedgerHeader{
+				PreviousLedgerHash: xdr.Hash{byte(seq - 1)},
+				ScpValue:           xdr.StellarValue{CloseTime: xdr.Uint64(1000 + seq)},
+				LedgerSeq:          xdr.Uint32(seq),
+			},
+		})
+	}
+	writeArchiveFile(t, dir, "ledger", headers...)
+	writeArchiveFile(t, dir, "transactions", xdr.TransactionHistoryEntry{
+		LedgerSeq: 3,
+		TxSet:     xdr.TransactionSet{Txs: []xdr.TransactionEnvelope{envelope}},
+	})
+	writeArchiveFile(t, dir, "results", xdr.TransactionHistoryResultEntry{
+		LedgerSeq: 3,
+		TxResultSet: xdr.TransactionResultSet{
+			Results: []xdr.TransactionResultPair{{
+				TransactionHash: xdr.Hash(hash),
+				Result: xdr.TransactionResult{
+					FeeCharged: 100,
+					Result: xdr.TransactionResultResult{
+						Code:    xdr.TransactionResultCodeTxSuccess,
+						Results: &[]xdr.OperationResult{},
+					},
+				},
+			}},
+		},
+	})
+
+	has, err := json.Marshal(historyarchive.HistoryArchiveState{CurrentLedger: 63})
+	require.NoError(t, err)
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, ".well-known"), 0755))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, ".well-known", "stellar-history.json"), has, 0644))
+
+	source, err := NewArchiveLedgerSource("file://"+dir, network.TestNetworkPassphrase)
+	require.NoError(t, err)
+
+	latest, err := source.LatestLedger()
+	if assert.NoError(t, err) {
+		assert.Equal(t, int32(63), latest)
+	}
+
+	bundle := &LedgerBundle{Sequence: 2}
+	if assert.NoError(t, source.LoadLedger(bundle)) {
+		ledgerHash, prevHash := xdr.Hash{2}, xdr.Hash{1}
+		assert.Equal(t, uint32(2), bundle.Header.Sequence)
+		assert.Equal(t, hex.EncodeToString(ledgerHash[:]), bundle.Header.LedgerHash)
+		assert.Equal(t, hex.EncodeToString(prevHash[:]), bundle.Header.PrevHash)
+		assert.Equal(t, int64(1002), bundle.Header.CloseTime)
+		assert.Len(t, bundle.Transactions, 0)
+		assert.True(t, bundle.WithoutMeta)
+	}
+
+	bundle = &LedgerBundle{Sequence: 3}
+	if assert.NoError(t, source.LoadLedger(bundle)) {
+		assert.Equal(t, uint32(3), bundle.Header.Sequence)
+		if assert.Len(t, bundle.Transactions, 1) && assert.Len(t, bundle.TransactionFees, 1) {
+			tx := bundle.Transactions[0]
+			assert.Equal(t, hex.EncodeToString(hash[:]), tx.TransactionHash)
+			assert.Equal(t, int32(3), tx.LedgerSequence)
+			assert.Equal(t, int32(1), tx.Index)
+			assert.True(t, tx.IsSuccessful())
+			assert.NotPanics(t, func() {
+				tx.ResultMetaXDR()
+				bundle.TransactionFees[0].ChangesXDR()
+			})
+		}
+	}
+
+	// ledger 64 belongs to the next checkpoint, which is not in the archive
+	bundle = &LedgerBundle{Sequence: 64}
+	assert.Error(t, source.LoadLedger(bundle))
+}
+
+func TestCheckpointContaining(t *testing.T) {
+	assert.Equal(t, uint32(63), checkpointContaining(1))
+	assert.Equal(t, uint32(63), checkpointContaining(63))
+	assert.Equal(t, uint32(127), checkpointContaining(64))
+	assert.Equal(t, uint32(127), checkpointContaining(127))
+	assert.Equal(t, uint32(191), checkpointContaining(128))
+}
+
+// writeArchiveFile writes `entries` to the file of category `cat` of the first
+// checkpoint of the archive in `dir`.
+func writeArchiveFile(t *testing.T, dir, cat string, entries ...interface{}) {
+	path := filepath.Join(dir, historyarchive.CategoryCheckpointPath(cat, 63))
+	require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+
+	file, err := os.Create(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	out := gzip.NewWriter(file)
+	for _, entry := range entries {
+		require.NoError(t, historyarchive.WriteFramedXdr(out, entry))
+	}
+	require.NoError(t, out.Close())
+}
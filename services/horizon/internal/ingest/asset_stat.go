@@ -3,6 +3,7 @@ package ingest
 import (
 	"database/sql"
 	"strings"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	"github.com/cowry-network/go/services/horizon/internal/db2/core"
@@ -19,8 +20,11 @@ func (assetStats *AssetStats) init() {
 			"id",
 			"amount",
 			"num_accounts",
+			"num_unauthorized_accounts",
 			"flags",
 			"toml",
+			"bid_depth",
+			"ask_depth",
 		},
 	}
 
@@ -60,6 +64,26 @@ func (assetStats *AssetStats) AddAllAssetsFromCore() (int, error) {
 	return len(assets), nil
 }
 
+// LatestCloseTime returns the close time of the latest ledger of stellar-core,
+// whose state the stats are computed from.
+func (assetStats *AssetStats) LatestCloseTime() (time.Time, error) {
+	coreQ := &core.Q{Session: assetStats.CoreSession}
+
+	var latest int32
+	err := coreQ.LatestLedger(&latest)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "Error getting latest ledger")
+	}
+
+	var header core.LedgerHeader
+	err = coreQ.LedgerHeaderBySequence(&header, latest)
+	if err != nil {
+		return time.Time{}, errors.Wrap(err, "Error getting latest ledger header")
+	}
+
+	return time.Unix(header.CloseTime, 0).UTC(), nil
+}
+
 func (assetStats *AssetStats) add(asset xdr.Asset) {
 	assetStats.toUpdate[asset.String()] = asset
 }
@@ -103,10 +127,13 @@ func (assetStats *AssetStats) IngestOperation(op *xdr.Operation, source *xdr.Acc
 }
 
 // UpdateAssetStats updates the db with the latest asset stats for the assets that were modified
-func (assetStats *AssetStats) UpdateAssetStats() error {
+// and records their supply on the day of `closedAt`, the close time of the last ledger ingested.
+// Like the stats, the supply is read from the current state of stellar-core, not from that
+// ledger, so that it is only accurate for the latest ledger of stellar-core.
+func (assetStats *AssetStats) UpdateAssetStats(closedAt time.Time) error {
 	assetStats.initOnce.Do(assetStats.init)
 
-	hasValue := false
+	var updated []*history.AssetStat
 	for _, asset := range assetStats.toUpdate {
 		assetStat, err := assetStats.computeAssetStat(&asset)
 		if err != nil {
@@ -114,18 +141,21 @@ func (assetStats *AssetStats) UpdateAssetStats() error {
 		}
 
 		if assetStat != nil {
-			hasValue = true
+			updated = append(updated, assetStat)
 			assetStats.batchInsertBuilder.Values(
 				assetStat.ID,
 				assetStat.Amount,
 				assetStat.NumAccounts,
+				assetStat.NumUnauthorizedAccounts,
 				assetStat.Flags,
 				assetStat.Toml,
+				assetStat.BidDepth,
+				assetStat.AskDepth,
 			)
 		}
 	}
 
-	if hasValue {
+	if len(updated) > 0 {
 		// perform a delete first since upsert is not supported if postgres < 9.5
 		err := errors.Wrap(assetStats.deleteRows(assetStats.HistorySession), "Error deleting asset_stats row")
 		if err != nil {
@@ -135,7 +165,25 @@ func (assetStats *AssetStats) UpdateAssetStats() error {
 		// can perform a direct upsert if postgres > 9.4
 		// is.Ingestion.assetStats = is.Ingestion.assetStats.
 		// 	Suffix("ON CONFLICT (id) DO UPDATE SET (amount, num_accounts, flags, toml) = (excluded.amount, excluded.num_accounts, excluded.flags, excluded.toml)")
-		return errors.Wrap(assetStats.batchInsertBuilder.Exec(assetStats.HistorySession), "Error inserting asset_stats row")
+		err = errors.Wrap(assetStats.batchInsertBuilder.Exec(assetStats.HistorySession), "Error inserting asset_stats row")
+		if err != nil {
+			return err
+		}
+
+		return errors.Wrap(assetStats.addSnapshots(updated, closedAt), "Error inserting asset_stats_snapshots row")
+	}
+
+	return nil
+}
+
+// addSnapshots records the supply of the assets of `updated` on the day of `day`
+func (assetStats *AssetStats) addSnapshots(updated []*history.AssetStat, day time.Time) error {
+	historyQ := history.Q{Session: assetStats.HistorySession}
+	for _, assetStat := range updated {
+		err := historyQ.AddAssetStatSnapshot(assetStat.ID, day, assetStat.Amount, assetStat.NumAccounts)
+		if err != nil {
+			return err
+		}
 	}
 
 	return nil
@@ -216,17 +264,31 @@ func (assetStats *AssetStats) computeAssetStat(asset *xdr.Asset) (*history.Asset
 		return nil, errors.Wrap(err, "statTrustlinesInfo error")
 	}
 
+	coreQ := &core.Q{Session: assetStats.CoreSession}
+	numUnauthorizedAccounts, err := coreQ.UnauthorizedAccountsForAsset(int32(assetType), assetCode, assetIssuer)
+	if err != nil {
+		return nil, errors.Wrap(err, "coreQ.UnauthorizedAccountsForAsset error")
+	}
+
 	flags, toml, err := statAccountInfo(assetStats.CoreSession, assetIssuer)
 	if err != nil {
 		return nil, errors.Wrap(err, "statAccountInfo error")
 	}
 
+	bidDepth, askDepth, err := coreQ.OfferDepthsForAsset(*asset)
+	if err != nil {
+		return nil, errors.Wrap(err, "coreQ.OfferDepthsForAsset error")
+	}
+
 	return &history.AssetStat{
-		ID:          assetID,
-		Amount:      amount,
-		NumAccounts: numAccounts,
-		Flags:       flags,
-		Toml:        toml,
+		ID:                      assetID,
+		Amount:                  amount,
+		NumAccounts:             numAccounts,
+		NumUnauthorizedAccounts: numUnauthorizedAccounts,
+		Flags:                   flags,
+		Toml:                    toml,
+		BidDepth:                bidDepth,
+		AskDepth:                askDepth,
 	}, nil
 }
 
@@ -76,9 +76,15 @@ func (c *Cursor) LedgerSequence() int32 {
 	return c.data.Sequence
 }
 
+// HasMeta returns true if the meta of the transactions in the current ledger
+// is available, see LedgerBundle.WithoutMeta.
+func (c *Cursor) HasMeta() bool {
+	return !c.data.WithoutMeta
+}
+
 // NextLedger advances `c` to the next ledger in the iteration, loading a new
-// LedgerBundle from the core database. Returns false if an error occurs or
-// the iteration is complete.
+// LedgerBundle from the ledger source or the core database. Returns false if
+// an error occurs or the iteration is complete.
 func (c *Cursor) NextLedger() bool {
 	if c.Err != nil {
 		return false
@@ -90,7 +96,11 @@ func (c *Cursor) NextLedger() bool {
 
 	c.data = &LedgerBundle{Sequence: c.lg}
 	start := time.Now()
-	c.Err = c.data.Load(c.CoreDB)
+	if c.Source != nil {
+		c.Err = c.Source.LoadLedger(c.data)
+	} else {
+		c.Err = c.data.Load(c.CoreDB)
+	}
 	if c.Err != nil {
 		return false
 	}
@@ -218,6 +228,12 @@ func (c *Cursor) TransactionFee() *core.TransactionFee {
 	return &c.data.TransactionFees[c.tx]
 }
 
+// TransactionFees returns the txfeehistory rows for every transaction in the
+// current ledger.
+func (c *Cursor) TransactionFees() []core.TransactionFee {
+	return c.data.TransactionFees
+}
+
 // TransactionMetaBundle provides easier access to the meta data regarding
 // the application of the current transaction.
 func (c *Cursor) TransactionMetaBundle() *meta.Bundle {
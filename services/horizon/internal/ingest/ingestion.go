@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"sort"
 	"time"
 
 	sq "github.com/Masterminds/squirrel"
@@ -29,6 +30,10 @@ func (ingest *Ingestion) Clear(start int64, end int64) error {
 	if err != nil {
 		return errors.Wrap(err, "Error clearing history_effects")
 	}
+	err = clear(start, end, "history_ledger_entry_changes", "history_operation_id")
+	if err != nil {
+		return errors.Wrap(err, "Error clearing history_ledger_entry_changes")
+	}
 	err = clear(start, end, "history_operation_participants", "history_operation_id")
 	if err != nil {
 		return errors.Wrap(err, "Error clearing history_operation_participants")
@@ -49,6 +54,8 @@ func (ingest *Ingestion) Clear(start int64, end int64) error {
 	if err != nil {
 		return errors.Wrap(err, "Error clearing history_ledgers")
 	}
+	// the trade rollups keep the cleared trades until they are rebuilt once
+	// the whole range is reingested, see System.rebuildTradeRollups
 	err = clear(start, end, "history_trades", "history_operation_id")
 	if err != nil {
 		return errors.Wrap(err, "Error clearing history_trades")
@@ -78,6 +85,7 @@ func (ingest *Ingestion) Effect(address Address, opid int64, order int, typ hist
 func (ingest *Ingestion) Flush() error {
 	tables := []TableName{
 		EffectsTableName,
+		LedgerEntryChangesTableName,
 		LedgersTableName,
 		OperationParticipantsTableName,
 		OperationsTableName,
@@ -148,6 +156,10 @@ func (ingest *Ingestion) UpdateAccountIDs(tables []TableName) error {
 	}
 
 	if len(addresses) > 0 {
+		// Sessions running concurrently insert accounts in the same order so
+		// that they wait on each other rather than deadlock.
+		sort.Strings(addresses)
+
 		// TODO we should probably batch this too
 		dbAccounts = make([]history.Account, 0, len(addresses))
 		err = q.CreateAccounts(&dbAccounts, addresses)
@@ -168,8 +180,10 @@ func (ingest *Ingestion) UpdateAccountIDs(tables []TableName) error {
 	return nil
 }
 
-// Ledger adds a ledger to the current ingestion
+// Ledger adds a ledger to the current ingestion, tagged with the version of
+// the ingestion algorithm `importerVersion`
 func (ingest *Ingestion) Ledger(
+	importerVersion int,
 	id int64,
 	header *core.LedgerHeader,
 	successTxsCount int,
@@ -177,7 +191,7 @@ func (ingest *Ingestion) Ledger(
 	ops int,
 ) {
 	ingest.builders[LedgersTableName].Values(
-		CurrentVersion,
+		importerVersion,
 		id,
 		header.Sequence,
 		header.LedgerHash,
@@ -199,6 +213,39 @@ func (ingest *Ingestion) Ledger(
 	)
 }
 
+// LedgerEntryChange adds a new row into the `history_ledger_entry_changes`
+// table, recording the state of a ledger entry owned by `address` before and
+// after a change.  `id` is the id of the operation that made the change, or
+// that of the transaction or ledger for sequence number and fee changes made
+// outside of any operation.
+func (ingest *Ingestion) LedgerEntryChange(
+	address Address,
+	id int64,
+	order int,
+	key xdr.LedgerKey,
+	change xdr.LedgerEntryChangeType,
+	before *xdr.LedgerEntry,
+	after *xdr.LedgerEntry,
+) error {
+	keyXDR, err := xdr.MarshalBase64(key)
+	if err != nil {
+		return errors.Wrap(err, "Error encoding ledger key")
+	}
+
+	beforeXDR, err := ledgerEntryXDR(before)
+	if err != nil {
+		return errors.Wrap(err, "Error encoding entry before")
+	}
+
+	afterXDR, err := ledgerEntryXDR(after)
+	if err != nil {
+		return errors.Wrap(err, "Error encoding entry after")
+	}
+
+	ingest.builders[LedgerEntryChangesTableName].Values(address, id, order, key.Type, change, beforeXDR, afterXDR, keyXDR)
+	return nil
+}
+
 // Operation ingests the provided operation data into a new row in the
 // `history_operations` table
 func (ingest *Ingestion) Operation(
@@ -439,6 +486,20 @@ func (ingest *Ingestion) createInsertBuilders() {
 		},
 	}
 
+	ingest.builders[LedgerEntryChangesTableName] = &BatchInsertBuilder{
+		TableName: LedgerEntryChangesTableName,
+		Columns: []string{
+			"history_account_id",
+			"history_operation_id",
+			"\"order\"",
+			"entry_type",
+			"change_type",
+			"entry_before",
+			"entry_after",
+			"ledger_key",
+		},
+	}
+
 	ingest.builders[TradesTableName] = &BatchInsertBuilder{
 		TableName: TradesTableName,
 		Columns: []string{
@@ -482,3 +543,18 @@ func (ingest *Ingestion) formatTimeBounds(bounds *xdr.TimeBounds) interface{} {
 
 	return sq.Expr("int8range(?,?)", bounds.MinTime, maxTime)
 }
+
+// ledgerEntryXDR returns the base64-encoded xdr of `entry`, or NULL if the
+// entry does not exist.
+func ledgerEntryXDR(entry *xdr.LedgerEntry) (null.String, error) {
+	if entry == nil {
+		return null.String{}, nil
+	}
+
+	encoded, err := xdr.MarshalBase64(entry)
+	if err != nil {
+		return null.String{}, err
+	}
+
+	return null.StringFrom(encoded), nil
+}
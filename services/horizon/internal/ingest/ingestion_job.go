@@ -0,0 +1,81 @@
+package ingest
+
+import (
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/support/errors"
+	ilog "github.com/cowry-network/go/support/log"
+	"github.com/guregu/null"
+)
+
+// ingestionJob loads the unfinished ingestion job of `kind` over the ledgers
+// from `start` to `end`, so that it is resumed, or creates a new one when there
+// is none.
+func (i *System) ingestionJob(kind string, start, end int32) (*history.IngestionJob, error) {
+	q := history.Q{Session: i.HorizonDB}
+	var job history.IngestionJob
+
+	err := q.UnfinishedIngestionJobForRange(&job, kind, start, end)
+	if q.NoRows(err) {
+		err = q.CreateIngestionJob(&job, kind, start, end)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create ingestion job")
+		}
+		return &job, nil
+	}
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load ingestion job")
+	}
+
+	return &job, nil
+}
+
+// runJob runs `is` over the ledgers of `job` that were not committed by a
+// previous run, then records whether the job completed or failed.
+func (i *System) runJob(is *Session, job *history.IngestionJob) error {
+	q := history.Q{Session: i.HorizonDB}
+	logFields := ilog.F{
+		"job":   job.ID,
+		"kind":  job.Kind,
+		"start": job.FirstLedger,
+		"end":   job.LastLedger,
+	}
+
+	next := job.FirstLedger
+	if job.LastIngestedLedger.Valid {
+		last := int32(job.LastIngestedLedger.Int64)
+		next = last + 1
+		if job.FirstLedger > job.LastLedger {
+			next = last - 1
+		}
+		logFields["last_ingested"] = last
+		log.WithFields(logFields).Info("ingest: resuming job")
+	}
+
+	// the job was interrupted after committing its last ledger
+	if next > job.LastLedger && job.FirstLedger <= job.LastLedger ||
+		next < job.LastLedger && job.FirstLedger > job.LastLedger {
+		err := q.UpdateIngestionJobStatus(job.ID, history.IngestionJobComplete, null.String{})
+		return errors.Wrap(err, "failed to record job status")
+	}
+
+	err := q.UpdateIngestionJobStatus(job.ID, history.IngestionJobRunning, null.String{})
+	if err != nil {
+		return errors.Wrap(err, "failed to record job status")
+	}
+
+	is.Cursor = NewCursor(next, job.LastLedger, i)
+	is.Job = job
+	is.Run()
+
+	if is.Err != nil {
+		err = q.UpdateIngestionJobStatus(job.ID, history.IngestionJobFailed, null.StringFrom(is.Err.Error()))
+		if err != nil {
+			logFields["err"] = err.Error()
+			log.WithFields(logFields).Error("ingest: failed to record job status")
+		}
+		return is.Err
+	}
+
+	err = q.UpdateIngestionJobStatus(job.ID, history.IngestionJobComplete, null.String{})
+	return errors.Wrap(err, "failed to record job status")
+}
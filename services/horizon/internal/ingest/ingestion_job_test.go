@@ -0,0 +1,46 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/guregu/null"
+)
+
+func TestReingestRangeResumesJob(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	is := sys(tt, Config{EnableAssetStats: false})
+	is.SkipCursorUpdate = true
+
+	var latest int32
+	cq := core.Q{Session: tt.CoreSession()}
+	tt.Require.NoError(cq.LatestLedger(&latest))
+
+	// simulate a reingestion interrupted after committing ledgers down to 11
+	q := history.Q{Session: tt.HorizonSession()}
+	var job history.IngestionJob
+	tt.Require.NoError(q.CreateIngestionJob(&job, JobReingest, latest, 1))
+	tt.Require.NoError(q.UpdateIngestionJobProgress(job.ID, 11))
+	tt.Require.NoError(q.UpdateIngestionJobStatus(job.ID, history.IngestionJobFailed, null.StringFrom("interrupted")))
+
+	ingested, err := is.ReingestRange(latest, 1)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(10, ingested)
+
+	var jobs []history.IngestionJob
+	tt.Require.NoError(q.IngestionJobs(&jobs, 10))
+	if tt.Assert.Len(jobs, 1) {
+		tt.Assert.Equal(job.ID, jobs[0].ID)
+		tt.Assert.Equal(history.IngestionJobComplete, jobs[0].Status)
+		tt.Assert.Equal(int64(1), jobs[0].LastIngestedLedger.Int64)
+		tt.Assert.False(jobs[0].Error.Valid)
+	}
+
+	// a completed job is not resumed
+	ingested, err = is.ReingestRange(latest, 1)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int(latest), ingested)
+}
@@ -177,6 +177,19 @@ func TestAssetStatsIngest(t *testing.T) {
 		Flags:       1,
 		Toml:        "https://test.com/.well-known/stellar.toml",
 	}, assetStats[2])
+
+	// the supply is recorded on the day the last ledger ingested closed
+	var header core.LedgerHeader
+	coreQ := core.Q{Session: tt.CoreSession()}
+	tt.Require.NoError(coreQ.LedgerHeaderBySequence(&header, s.Cursor.LastLedger))
+	closedAt := time.Unix(header.CloseTime, 0).UTC()
+
+	var days []time.Time
+	err = q.SelectRaw(&days, "SELECT DISTINCT day FROM asset_stats_snapshots")
+	tt.Require.NoError(err)
+	if tt.Assert.Len(days, 1) {
+		tt.Assert.Equal(closedAt.Format("2006-01-02"), days[0].Format("2006-01-02"))
+	}
 }
 
 func TestAssetStatsDisabledIngest(t *testing.T) {
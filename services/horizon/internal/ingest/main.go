@@ -5,11 +5,16 @@ package ingest
 
 import (
 	"sync"
+	"time"
 
 	sq "github.com/Masterminds/squirrel"
 	metrics "github.com/rcrowley/go-metrics"
 	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/support/db"
+	"github.com/cowry-network/go/support/errors"
+	"github.com/cowry-network/go/support/historyarchive"
 	ilog "github.com/cowry-network/go/support/log"
 	"github.com/cowry-network/go/xdr"
 )
@@ -25,9 +30,36 @@ const (
 	// Scripts, that have yet to be ported to this codebase can then be leveraged
 	// to re-ingest old data with the new algorithm, providing a seamless
 	// transition when the ingested data's structure changes.
-	CurrentVersion = 16
+	CurrentVersion = 18
+
+	// WithoutMetaVersion tags the ledgers ingested without the meta of their
+	// transactions, such as ledgers read from a history archive, which lack
+	// effects, trades and ledger entry changes.  It is a sentinel outside of
+	// the sequence of versions, so that these ledgers are told apart from the
+	// ones ingested by older versions.  Being lower than CurrentVersion, they
+	// are reingested by `reingest outdated`, which fills in the missing data
+	// once a stellar-core database is available.
+	WithoutMetaVersion = history.WithoutMetaImporterVersion
+)
+
+// DefaultReingestChunkSize is the number of ledgers reingested by each session
+// of a ParallelReingestion, 160 checkpoints.  Smaller chunks are cheaper to
+// retry when they fail.
+const DefaultReingestChunkSize = int32(160 * historyarchive.CheckpointFreq)
+
+const (
+	// JobBackfill is the kind of the ingestion jobs run by System.Backfill.
+	JobBackfill = "backfill"
+	// JobReingest is the kind of the ingestion jobs run by System.ReingestRange
+	// and by each worker of a ParallelReingestion.
+	JobReingest = "reingest"
 )
 
+// JobCheckpointInterval is the number of ledgers a session running an
+// ingestion job ingests between commits.  An interrupted job resumes after the
+// last ledger committed.
+const JobCheckpointInterval = 100
+
 // Address is a type of a param provided to BatchInsertBuilder that gets exchanged
 // to record ID in a DB.
 type Address string
@@ -37,6 +69,7 @@ type TableName string
 const (
 	AssetStatsTableName              TableName = "asset_stats"
 	EffectsTableName                 TableName = "history_effects"
+	LedgerEntryChangesTableName      TableName = "history_ledger_entry_changes"
 	LedgersTableName                 TableName = "history_ledgers"
 	OperationParticipantsTableName   TableName = "history_operation_participants"
 	OperationsTableName              TableName = "history_operations"
@@ -56,6 +89,9 @@ type Cursor struct {
 
 	// CoreDB is the stellar-core db that data is ingested from.
 	CoreDB *db.Session
+	// Source provides the ledgers to ingest.  When nil, ledgers are loaded from
+	// CoreDB.
+	Source LedgerSource
 
 	Metrics    *IngesterMetrics
 	AssetStats *AssetStats
@@ -97,6 +133,44 @@ type LedgerBundle struct {
 	Header          core.LedgerHeader
 	TransactionFees []core.TransactionFee
 	Transactions    []core.Transaction
+	// WithoutMeta is true when the transactions of the bundle carry no meta and
+	// their fees no changes, as is the case for ledgers loaded from a history
+	// archive.
+	WithoutMeta bool
+}
+
+// LedgerSource provides the ledgers ingested by a Cursor, as an alternative to
+// the stellar-core database.
+type LedgerSource interface {
+	// LatestLedger returns the sequence of the latest ledger available.
+	LatestLedger() (int32, error)
+	// LoadLedger fills in the records of `lb` for the ledger whose sequence is
+	// lb.Sequence.
+	LoadLedger(lb *LedgerBundle) error
+}
+
+// ArchiveLedgerSource is a LedgerSource that reads ledger headers, transaction
+// sets and results from a history archive, so that horizon can ingest ledgers
+// without access to a stellar-core database holding them.  History archives
+// do not record the meta of transactions, so the effects, trades and ledger
+// entry changes of the ledgers it loads cannot be ingested.
+type ArchiveLedgerSource struct {
+	Archive *historyarchive.Archive
+	// Network is the passphrase of the network the archive belongs to, used to
+	// match transactions with their results.
+	Network string
+
+	lock        sync.Mutex
+	checkpoints map[uint32]map[uint32]*archiveLedger
+	loaded      []uint32
+}
+
+// archiveLedger holds the entries recorded in a history archive for a single
+// ledger.
+type archiveLedger struct {
+	header       xdr.LedgerHeaderHistoryEntry
+	transactions xdr.TransactionHistoryEntry
+	results      xdr.TransactionHistoryResultEntry
 }
 
 // System represents the data ingestion subsystem of horizon.
@@ -124,9 +198,26 @@ type System struct {
 	HistoryRetentionCount uint
 	// IngestFailedTransactions toggles whether to ingest failed transactions
 	IngestFailedTransactions bool
+	// LedgerSource provides the ledgers to ingest.  When nil, ledgers are read
+	// from CoreDB.
+	LedgerSource LedgerSource
+	// LedgerEvents, when set, receives an event for every ledger committed by
+	// the sessions of the system.
+	LedgerEvents *ledger.Bus
 
 	lock    sync.Mutex
 	current *Session
+	paused  bool
+}
+
+// Status is a snapshot of the state of the ingestion system.
+type Status struct {
+	// Paused is true when ingestion has been paused, see System.Pause.
+	Paused bool `json:"paused"`
+	// InProgress is true while an ingestion session is running.
+	InProgress bool `json:"in_progress"`
+	// Version is the version of the ingestion algorithm, see CurrentVersion.
+	Version int `json:"version"`
 }
 
 // IngesterMetrics tracks all the metrics for the ingestion subsystem
@@ -165,6 +256,66 @@ type Ingestion struct {
 	builders map[TableName]*BatchInsertBuilder
 }
 
+// ParallelReingestion reingests a range of ledgers by splitting it into chunks
+// which a pool of workers reingest concurrently, each chunk in its own session.
+// The ids of ingested rows are derived from ledger sequences (see toid), so
+// they are ordered correctly whichever chunk completes first.
+type ParallelReingestion struct {
+	System *System
+	// Start and End are the first and last ledgers to reingest, inclusive.
+	Start int32
+	End   int32
+	// Workers is the number of chunks reingested concurrently.
+	Workers int
+	// ChunkSize is the number of ledgers in each chunk.  Chunks start at
+	// multiples of ChunkSize whatever the range and the number of workers, so
+	// that reingesting a range again resumes the same chunks.  A multiple of
+	// historyarchive.CheckpointFreq makes chunks end on checkpoints.
+	ChunkSize int32
+
+	lock    sync.Mutex
+	started time.Time
+	chunks  []ReingestChunk
+	workers []WorkerStatus
+}
+
+// ReingestChunk is a range of ledgers reingested by a single session of a
+// ParallelReingestion.
+type ReingestChunk struct {
+	// Start and End are the first and last ledgers of the chunk, inclusive.
+	Start int32
+	End   int32
+	// Ingested is the number of ledgers of the chunk that were reingested.
+	Ingested int
+	// Err is the error that caused the reingestion of the chunk to fail, if any.
+	Err error
+}
+
+// WorkerStatus is a snapshot of the progress of a worker of a
+// ParallelReingestion.
+type WorkerStatus struct {
+	// Busy is true while the worker is reingesting Chunk.
+	Busy  bool
+	Chunk ReingestChunk
+	// Ledger is the last ledger reingested by the worker.
+	Ledger int32
+	// Ingested is the number of ledgers reingested by the worker.
+	Ingested int
+}
+
+// ParallelReingestionStatus is a snapshot of the progress of a
+// ParallelReingestion.
+type ParallelReingestionStatus struct {
+	Workers []WorkerStatus
+	// Ingested is the number of ledgers reingested so far, out of Total.
+	Ingested int
+	Total    int
+	// Failed is the number of chunks whose reingestion failed.
+	Failed int
+	// ETA is the estimated time remaining, zero until a ledger is reingested.
+	ETA time.Duration
+}
+
 // Session represents a single attempt at ingesting data into the history
 // database.
 type Session struct {
@@ -186,6 +337,16 @@ type Session struct {
 	SkipCursorUpdate bool
 	// Metrics is a reference to where the session should record its metric information
 	Metrics *IngesterMetrics
+	// OnLedgerIngested, when set, is called with the sequence of every ledger
+	// successfully ingested during this session.
+	OnLedgerIngested func(seq int32)
+	// Job, when set, is the ingestion job run by this session.  The session
+	// commits every JobCheckpointInterval ledgers, recording in the same
+	// transaction the last ledger ingested by the job.
+	Job *history.IngestionJob
+	// LedgerEvents, when set, receives an event for every ledger committed by
+	// the session.
+	LedgerEvents *ledger.Bus
 	// AssetStats calculates asset stats
 	AssetStats *AssetStats
 
@@ -198,6 +359,13 @@ type Session struct {
 	// Ingested is the number of ledgers that were successfully ingested during
 	// this session.
 	Ingested int
+
+	// events are the events of the ledgers ingested in the current transaction,
+	// published once it commits.  accounts and assets collect the accounts and
+	// assets touched by the ledger being ingested.
+	events   []ledger.IngestedLedger
+	accounts map[string]struct{}
+	assets   map[string]xdr.Asset
 }
 
 // New initializes the ingester, causing it to begin polling the stellar-core
@@ -223,17 +391,60 @@ func NewCursor(first, last int32, i *System) *Cursor {
 		FirstLedger: first,
 		LastLedger:  last,
 		CoreDB:      i.CoreDB,
+		Source:      i.LedgerSource,
 		Metrics:     &i.Metrics,
 	}
 }
 
-// NewSession initialize a new ingestion session
+// NewArchiveLedgerSource creates a ledger source reading from the history
+// archive at `archiveURL`, such as file:///var/lib/stellar/history, for the
+// network identified by `network`.
+func NewArchiveLedgerSource(archiveURL string, network string) (*ArchiveLedgerSource, error) {
+	archive, err := historyarchive.Connect(archiveURL, historyarchive.ConnectOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to connect to history archive")
+	}
+
+	return &ArchiveLedgerSource{Archive: archive, Network: network}, nil
+}
+
+// NewParallelReingestion initializes the reingestion of the ledgers from
+// `start` to `end`, inclusive, by `workers` concurrent sessions.  The range is
+// split in chunks of DefaultReingestChunkSize ledgers.
+func NewParallelReingestion(i *System, start, end int32, workers int) *ParallelReingestion {
+	if start > end {
+		start, end = end, start
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &ParallelReingestion{
+		System:    i,
+		Start:     start,
+		End:       end,
+		Workers:   workers,
+		ChunkSize: DefaultReingestChunkSize,
+	}
+}
+
+// NewSession initialize a new ingestion session.  Asset stats, which are read
+// from the stellar-core database, are disabled when ingesting from a
+// LedgerSource, which doesn't require one.
 func NewSession(i *System) *Session {
-	cdb := i.CoreDB.Clone()
+	var cdb *db.Session
+	if i.CoreDB != nil {
+		cdb = i.CoreDB.Clone()
+	}
 	hdb := i.HorizonDB.Clone()
 
+	config := i.Config
+	if i.LedgerSource != nil {
+		config.EnableAssetStats = false
+	}
+
 	return &Session{
-		Config: i.Config,
+		Config: config,
 		Ingestion: &Ingestion{
 			DB: hdb,
 		},
@@ -241,6 +452,7 @@ func NewSession(i *System) *Session {
 		StellarCoreURL:   i.StellarCoreURL,
 		SkipCursorUpdate: i.SkipCursorUpdate,
 		Metrics:          &i.Metrics,
+		LedgerEvents:     i.LedgerEvents,
 		AssetStats: &AssetStats{
 			CoreSession:    cdb,
 			HistorySession: hdb,
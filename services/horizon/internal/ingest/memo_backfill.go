@@ -0,0 +1,50 @@
+package ingest
+
+import (
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/support/db"
+	"github.com/cowry-network/go/support/errors"
+)
+
+// BackfillMemos records the memo type and memo of the transactions in the
+// horizon database that have none recorded but carry a memo in their
+// envelope, e.g. rows that were not inserted by the ingester.  Transactions
+// are loaded `batchSize` at a time.  It returns the number of transactions
+// updated.
+func BackfillMemos(session *db.Session, batchSize uint64) (int, error) {
+	q := history.Q{Session: session}
+	updated := 0
+
+	var afterID int64
+	for {
+		var txs []history.TransactionEnvelope
+		err := q.TransactionEnvelopesWithoutMemo(&txs, afterID, batchSize)
+		if err != nil {
+			return updated, errors.Wrap(err, "failed to load transactions")
+		}
+
+		if len(txs) == 0 {
+			return updated, nil
+		}
+
+		for _, tx := range txs {
+			coreTx := core.Transaction{Envelope: tx.Envelope}
+			memoType := coreTx.MemoType()
+			if memoType == "none" {
+				continue
+			}
+
+			err = q.UpdateTransactionMemo(tx.ID, memoType, coreTx.Memo())
+			if err != nil {
+				return updated, errors.Wrap(err, "failed to update transaction memo")
+			}
+			updated++
+		}
+
+		afterID = txs[len(txs)-1].ID
+		log.WithField("last_id", afterID).
+			WithField("updated", updated).
+			Info("ingest: backfilling memos")
+	}
+}
@@ -0,0 +1,38 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+)
+
+func TestBackfillMemos(t *testing.T) {
+	tt := test.Start(t).Scenario("kahuna")
+	defer tt.Finish()
+	q := history.Q{Session: tt.HorizonSession()}
+
+	// simulate transactions recorded without their memo
+	_, err := q.ExecRaw(
+		"UPDATE history_transactions SET memo_type = 'none', memo = NULL WHERE memo IS NOT NULL",
+	)
+	tt.Require.NoError(err)
+
+	updated, err := BackfillMemos(tt.HorizonSession(), 2)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(4, updated)
+
+	var tx history.Transaction
+	tt.Require.NoError(q.TransactionByHash(&tx, "2551e76a3ce4881b7bc73fdfd89d670d511ea7d4e56156252b51777023202de7"))
+	tt.Assert.Equal("text", tx.MemoType)
+	tt.Assert.Equal("hello", tx.Memo.String)
+
+	tt.Require.NoError(q.TransactionByHash(&tx, "3b36ecfbcc2adb0cfff08ae86199f64e12984f084bb03be9bb249611df82322b"))
+	tt.Assert.Equal("hash", tx.MemoType)
+	tt.Assert.Equal("AQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQEBAQE=", tx.Memo.String)
+
+	// transactions with a memo are left untouched
+	updated, err = BackfillMemos(tt.HorizonSession(), 2)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(0, updated)
+}
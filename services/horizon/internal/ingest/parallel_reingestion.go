@@ -0,0 +1,191 @@
+package ingest
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cowry-network/go/support/errors"
+	ilog "github.com/cowry-network/go/support/log"
+)
+
+// Run reingests every chunk of the range, returning once all of them have been
+// processed.  A chunk failing does not stop the others: the chunks that failed
+// are returned along with an error, and each can be reingested again on its
+// own using System.ReingestRange.
+//
+// Asset stats are not updated by the sessions, which would race to replace the
+// same rows, but once all chunks have been processed.  Trade rollups, which
+// the sessions merge the reingested trades into, are rebuilt then as well.
+func (r *ParallelReingestion) Run() ([]ReingestChunk, error) {
+	r.lock.Lock()
+	r.started = time.Now()
+	r.chunks = r.split()
+	r.workers = make([]WorkerStatus, r.Workers)
+	r.lock.Unlock()
+
+	queue := make(chan int, len(r.chunks))
+	for idx := range r.chunks {
+		queue <- idx
+	}
+	close(queue)
+
+	var wg sync.WaitGroup
+	for worker := 0; worker < r.Workers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for idx := range queue {
+				r.reingestChunk(worker, idx)
+			}
+		}(worker)
+	}
+	wg.Wait()
+
+	err := r.updateAssetStats()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to update asset stats")
+	}
+
+	err = r.System.rebuildTradeRollups(r.Start, r.End)
+	if err != nil {
+		return nil, err
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	var failed []ReingestChunk
+	for _, chunk := range r.chunks {
+		if chunk.Err != nil {
+			failed = append(failed, chunk)
+		}
+	}
+
+	if len(failed) > 0 {
+		return failed, errors.Errorf("reingestion of %d out of %d chunks failed", len(failed), len(r.chunks))
+	}
+
+	return nil, nil
+}
+
+// Status returns a snapshot of the progress of the reingestion.
+func (r *ParallelReingestion) Status() ParallelReingestionStatus {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	status := ParallelReingestionStatus{
+		Workers: append([]WorkerStatus(nil), r.workers...),
+		Total:   int(r.End-r.Start) + 1,
+	}
+
+	for _, worker := range r.workers {
+		status.Ingested += worker.Ingested
+	}
+
+	for _, chunk := range r.chunks {
+		if chunk.Err != nil {
+			status.Failed++
+		}
+	}
+
+	if status.Ingested > 0 {
+		elapsed := time.Since(r.started)
+		remaining := status.Total - status.Ingested
+		status.ETA = elapsed / time.Duration(status.Ingested) * time.Duration(remaining)
+	}
+
+	return status
+}
+
+// reingestChunk reingests the chunk at `idx` in a new session, on behalf of
+// `worker`.  Each chunk is an ingestion job of its own, resumed when the same
+// range is reingested again with the same chunks.
+func (r *ParallelReingestion) reingestChunk(worker, idx int) {
+	r.lock.Lock()
+	chunk := r.chunks[idx]
+	r.workers[worker].Busy = true
+	r.workers[worker].Chunk = chunk
+	r.lock.Unlock()
+
+	is := NewSession(r.System)
+	is.ClearExisting = true
+	is.Config.EnableAssetStats = false
+	is.OnLedgerIngested = func(seq int32) {
+		r.lock.Lock()
+		r.workers[worker].Ledger = seq
+		r.workers[worker].Ingested++
+		r.lock.Unlock()
+	}
+
+	job, err := r.System.ingestionJob(JobReingest, chunk.Start, chunk.End)
+	if err == nil {
+		err = r.System.runJob(is, job)
+	}
+
+	logFields := ilog.F{
+		"worker":   worker,
+		"start":    chunk.Start,
+		"end":      chunk.End,
+		"ingested": is.Ingested,
+	}
+	if err != nil {
+		logFields["err"] = err.Error()
+		log.WithFields(logFields).Error("reingest: chunk failed")
+	} else {
+		log.WithFields(logFields).Info("reingest: chunk complete")
+	}
+
+	r.lock.Lock()
+	r.chunks[idx].Ingested = is.Ingested
+	r.chunks[idx].Err = err
+	r.workers[worker].Busy = false
+	r.lock.Unlock()
+}
+
+// updateAssetStats recomputes the stats of every asset, if enabled.  Like
+// those updated by a session, they are read from the current state of
+// stellar-core.
+func (r *ParallelReingestion) updateAssetStats() error {
+	if !r.System.Config.EnableAssetStats || r.System.LedgerSource != nil {
+		return nil
+	}
+
+	assetStats := &AssetStats{
+		CoreSession:    r.System.CoreDB.Clone(),
+		HistorySession: r.System.HorizonDB.Clone(),
+	}
+
+	_, err := assetStats.AddAllAssetsFromCore()
+	if err != nil {
+		return err
+	}
+
+	closedAt, err := assetStats.LatestCloseTime()
+	if err != nil {
+		return err
+	}
+
+	return assetStats.UpdateAssetStats(closedAt)
+}
+
+// split divides the range into chunks ending on the ledgers preceding the
+// multiples of ChunkSize, so that the chunks of a ledger only depend on the
+// ledger itself.
+func (r *ParallelReingestion) split() []ReingestChunk {
+	size := int64(r.ChunkSize)
+	if size < 1 {
+		size = int64(DefaultReingestChunkSize)
+	}
+
+	var chunks []ReingestChunk
+	for start := int64(r.Start); start <= int64(r.End); {
+		end := (start/size+1)*size - 1
+		if end > int64(r.End) {
+			end = int64(r.End)
+		}
+		chunks = append(chunks, ReingestChunk{Start: int32(start), End: int32(end)})
+		start = end + 1
+	}
+
+	return chunks
+}
@@ -0,0 +1,79 @@
+package ingest
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParallelReingestion(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	is := sys(tt, Config{EnableAssetStats: false})
+	is.SkipCursorUpdate = true
+
+	var latest int32
+	q := core.Q{Session: tt.CoreSession()}
+	tt.Require.NoError(q.LatestLedger(&latest))
+
+	r := NewParallelReingestion(is, latest, 1, 4)
+	r.ChunkSize = 10
+
+	failed, err := r.Run()
+	tt.Require.NoError(err)
+	tt.Assert.Empty(failed)
+
+	status := r.Status()
+	tt.Assert.Equal(int(latest), status.Ingested)
+	tt.Assert.Equal(int(latest), status.Total)
+	tt.Assert.Equal(0, status.Failed)
+	tt.Assert.Len(status.Workers, 4)
+
+	var found int
+	err = tt.HorizonSession().GetRaw(&found, "SELECT COUNT(*) FROM history_ledgers")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(int(latest), found)
+
+	// the transactions of every ledger were ingested once, with ids ordered by
+	// ledger sequence
+	var unordered int
+	err = tt.HorizonSession().GetRaw(&unordered, `
+		SELECT COUNT(*) FROM history_transactions a
+		JOIN history_transactions b ON a.id < b.id
+		WHERE a.ledger_sequence > b.ledger_sequence
+	`)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(0, unordered)
+}
+
+func TestParallelReingestionChunks(t *testing.T) {
+	r := NewParallelReingestion(&System{}, 100, 1, 3)
+	assert.Equal(t, int32(1), r.Start)
+	assert.Equal(t, int32(100), r.End)
+	assert.Equal(t, DefaultReingestChunkSize, r.ChunkSize)
+	assert.Equal(t, []ReingestChunk{{Start: 1, End: 100}}, r.split())
+
+	r = NewParallelReingestion(&System{}, 1, 1, 8)
+	assert.Equal(t, []ReingestChunk{{Start: 1, End: 1}}, r.split())
+
+	// chunks end on checkpoints
+	r = NewParallelReingestion(&System{}, 1, 200, 2)
+	r.ChunkSize = 64
+	assert.Equal(t, []ReingestChunk{
+		{Start: 1, End: 63},
+		{Start: 64, End: 127},
+		{Start: 128, End: 191},
+		{Start: 192, End: 200},
+	}, r.split())
+
+	// the chunks don't depend on the number of workers, nor on the range
+	// other than its ends
+	r = NewParallelReingestion(&System{}, 1, 100000, 2)
+	chunks := r.split()
+	assert.Len(t, chunks, 10)
+	r = NewParallelReingestion(&System{}, 5000, 100000, 16)
+	assert.Equal(t, chunks[1:], r.split()[1:])
+	assert.Equal(t, ReingestChunk{Start: 5000, End: DefaultReingestChunkSize - 1}, r.split()[0])
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/base64"
 	"fmt"
 	"reflect"
+	"sort"
 	"time"
 
 	"github.com/cowry-network/go/clients/stellarcore"
@@ -14,6 +15,7 @@ import (
 	"github.com/cowry-network/go/meta"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
 	"github.com/cowry-network/go/services/horizon/internal/ingest/participants"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/support/errors"
 	ilog "github.com/cowry-network/go/support/log"
 	sTime "github.com/cowry-network/go/support/time"
@@ -36,7 +38,8 @@ func (is *Session) Run() {
 
 	defer is.Ingestion.Rollback()
 
-	var sectionStart, i int32
+	var sectionStart, lastIngested, i int32
+	var lastClosedAt time.Time
 
 	for is.Cursor.NextLedger() {
 		if sectionStart == 0 {
@@ -50,7 +53,18 @@ func (is *Session) Run() {
 		is.ingestLedger()
 		is.flush()
 
+		if is.Err == nil {
+			lastIngested = is.Cursor.LedgerSequence()
+			lastClosedAt = time.Unix(is.Cursor.Ledger().CloseTime, 0).UTC()
+			if is.OnLedgerIngested != nil {
+				is.OnLedgerIngested(lastIngested)
+			}
+		}
+
 		i++
+		if is.Job != nil && i%JobCheckpointInterval == 0 {
+			is.checkpoint(lastIngested)
+		}
 		if i%100 == 0 {
 			// Print status update every 100 ledgers. Can be helpful when reingesting or
 			// backfilling large number of ledgers.
@@ -67,7 +81,7 @@ func (is *Session) Run() {
 	}
 
 	if is.Config.EnableAssetStats && is.Err == nil {
-		is.Err = is.AssetStats.UpdateAssetStats()
+		is.Err = is.AssetStats.UpdateAssetStats(lastClosedAt)
 	}
 
 	if is.Err != nil {
@@ -80,15 +94,44 @@ func (is *Session) Run() {
 		return
 	}
 
+	if is.Job != nil && lastIngested != 0 {
+		is.recordJobProgress(lastIngested)
+		if is.Err != nil {
+			return
+		}
+	}
+
 	is.Err = is.Ingestion.Close()
 	if is.Err != nil {
 		is.Err = errors.Wrap(is.Err, "Ingestion.Close error")
 		return
 	}
+	is.publishEvents()
 
 	is.Err = errors.Wrap(is.reportCursorState(), "reportCursorState error")
 }
 
+// checkpoint commits the ledgers ingested so far by the session's job, up to
+// `seq`, and begins a new transaction for the following ones.
+func (is *Session) checkpoint(seq int32) {
+	is.recordJobProgress(seq)
+	if is.Err != nil {
+		return
+	}
+
+	is.Err = is.Ingestion.Close()
+	if is.Err != nil {
+		is.Err = errors.Wrap(is.Err, "Ingestion.Close error")
+		return
+	}
+	is.publishEvents()
+
+	is.Err = is.Ingestion.Start()
+	if is.Err != nil {
+		is.Err = errors.Wrap(is.Err, "Ingestion.Start error")
+	}
+}
+
 func (is *Session) clearLedger() {
 	if is.Err != nil {
 		return
@@ -388,7 +431,16 @@ func (is *Session) ingestLedger() {
 	}
 
 	start := time.Now()
+	is.accounts = map[string]struct{}{}
+	is.assets = map[string]xdr.Asset{}
+
+	importerVersion := CurrentVersion
+	if !is.Cursor.HasMeta() {
+		importerVersion = WithoutMetaVersion
+	}
+
 	is.Ingestion.Ledger(
+		importerVersion,
 		is.Cursor.LedgerID(),
 		is.Cursor.Ledger(),
 		is.Cursor.SuccessfulTransactionCount(),
@@ -396,10 +448,25 @@ func (is *Session) ingestLedger() {
 		is.Cursor.SuccessfulLedgerOperationCount(),
 	)
 
+	is.ingestFeeChanges()
+
 	for is.Cursor.NextTx() {
 		is.ingestTransaction()
 	}
 
+	event := ledger.IngestedLedger{Sequence: is.Cursor.LedgerSequence()}
+	for address := range is.accounts {
+		event.Accounts = append(event.Accounts, address)
+	}
+	sort.Strings(event.Accounts)
+	for _, asset := range is.assets {
+		event.Assets = append(event.Assets, asset)
+	}
+	sort.Slice(event.Assets, func(i, j int) bool {
+		return event.Assets[i].String() < event.Assets[j].String()
+	})
+	is.events = append(is.events, event)
+
 	is.Ingested++
 	if is.Metrics != nil {
 		is.Metrics.IngestLedgerTimer.Update(time.Since(start))
@@ -408,6 +475,157 @@ func (is *Session) ingestLedger() {
 	return
 }
 
+// ingestEntryChanges records the state of every ledger entry created, updated
+// or removed by `changes` under `id`, numbering the records from `order` + 1.
+// It returns the order of the last record written.  This is used for the
+// changes stellar-core makes outside of any operation: charging fees and
+// bumping sequence numbers.
+func (is *Session) ingestEntryChanges(id int64, order int, changes xdr.LedgerEntryChanges) int {
+	for _, key := range uniqueLedgerKeys(changes) {
+		if is.Err != nil {
+			return order
+		}
+
+		var before, after *xdr.LedgerEntry
+		var modified bool
+
+		for _, change := range changes {
+			changeKey := change.LedgerKey()
+			if !changeKey.Equals(key) {
+				continue
+			}
+
+			switch change.Type {
+			case xdr.LedgerEntryChangeTypeLedgerEntryState:
+				if !modified && before == nil {
+					entry := change.MustState()
+					before = &entry
+				}
+			case xdr.LedgerEntryChangeTypeLedgerEntryCreated:
+				entry := change.MustCreated()
+				after = &entry
+				modified = true
+			case xdr.LedgerEntryChangeTypeLedgerEntryUpdated:
+				entry := change.MustUpdated()
+				after = &entry
+				modified = true
+			case xdr.LedgerEntryChangeTypeLedgerEntryRemoved:
+				after = nil
+				modified = true
+			}
+		}
+
+		// entries that were only loaded, not changed
+		if !modified {
+			continue
+		}
+
+		change, ok := ledgerEntryChangeType(before, after)
+		if !ok {
+			continue
+		}
+
+		order++
+		is.Err = is.Ingestion.LedgerEntryChange(
+			ledgerEntryOwner(key),
+			id,
+			order,
+			key,
+			change,
+			before,
+			after,
+		)
+		if is.Err != nil {
+			is.Err = errors.Wrap(is.Err, "Ingestion.LedgerEntryChange error")
+		}
+	}
+
+	return order
+}
+
+// ingestFeeChanges records the changes made when charging the fees of every
+// transaction in the current ledger, successful or not.  stellar-core charges
+// all fees before applying any transaction, so these changes are recorded
+// against the ledger itself and sort before those of its transactions.
+func (is *Session) ingestFeeChanges() {
+	if is.Err != nil {
+		return
+	}
+
+	order := 0
+	for _, fee := range is.Cursor.TransactionFees() {
+		order = is.ingestEntryChanges(is.Cursor.LedgerID(), order, fee.Changes)
+		is.addEntryOwners(fee.Changes)
+	}
+}
+
+// addTransactionEntryOwners adds the owners of the ledger entries changed by
+// the current transaction to the accounts of the ledger's event, see
+// addEntryOwners.
+func (is *Session) addTransactionEntryOwners() {
+	meta := is.Cursor.Transaction().ResultMeta
+
+	if operations, ok := meta.GetOperations(); ok {
+		for _, op := range operations {
+			is.addEntryOwners(op.Changes)
+		}
+	}
+
+	if v1, ok := meta.GetV1(); ok {
+		is.addEntryOwners(v1.TxChanges)
+		for _, op := range v1.Operations {
+			is.addEntryOwners(op.Changes)
+		}
+	}
+}
+
+// addEntryOwners adds the owners of the ledger entries of `changes` to the
+// accounts of the current ledger's event.  Unlike the participants of a
+// transaction, which only include the accounts whose account entry changed,
+// they include the makers of the offers crossed and the owners of the
+// trustlines and data entries changed.
+func (is *Session) addEntryOwners(changes xdr.LedgerEntryChanges) {
+	for _, change := range changes {
+		is.accounts[string(ledgerEntryOwner(change.LedgerKey()))] = struct{}{}
+	}
+}
+
+// ingestLedgerEntryChanges records the state of every ledger entry created,
+// updated or removed by the current operation, before and after the operation
+// was applied.
+func (is *Session) ingestLedgerEntryChanges() {
+	if is.Err != nil {
+		return
+	}
+
+	for i, key := range uniqueLedgerKeys(is.Cursor.OperationChanges()) {
+		before, after, err := is.Cursor.BeforeAndAfter(key)
+		if err != nil {
+			is.Err = errors.Wrap(err, "Cursor.BeforeAndAfter error")
+			return
+		}
+
+		change, ok := ledgerEntryChangeType(before, after)
+		if !ok {
+			panic("Invalid before-and-after state")
+		}
+
+		is.Err = is.Ingestion.LedgerEntryChange(
+			ledgerEntryOwner(key),
+			is.Cursor.OperationID(),
+			i+1,
+			key,
+			change,
+			before,
+			after,
+		)
+		if is.Err != nil {
+			is.Err = errors.Wrap(is.Err, "Ingestion.LedgerEntryChange error")
+			return
+		}
+	}
+}
+
 func (is *Session) ingestOperation() {
 	if is.Err != nil {
 		return
@@ -429,8 +647,20 @@ func (is *Session) ingestOperation() {
 	is.ingestOperationParticipants()
 
 	if is.Cursor.Transaction().IsSuccessful() {
-		is.ingestEffects()
-		is.ingestTrades()
+		// Effects, ledger entry changes and trades are derived from the meta of
+		// the transaction, which ledgers loaded from a history archive lack.
+		if is.Cursor.HasMeta() {
+			is.ingestEffects()
+			is.ingestLedgerEntryChanges()
+			is.ingestTrades()
+		}
+
+		for _, asset := range operationAssets(
+			is.Cursor.Operation(),
+			&is.Cursor.Transaction().Envelope.Tx.SourceAccount,
+		) {
+			is.assets[asset.String()] = asset
+		}
 
 		if is.Config.EnableAssetStats && is.Err == nil {
 			is.Err = is.AssetStats.IngestOperation(
@@ -642,6 +872,15 @@ func (is *Session) ingestTransaction() {
 		return
 	}
 
+	// Sequence numbers are bumped whether or not the transaction succeeds, so
+	// these changes are recorded even when the transaction itself is not.
+	is.ingestTransactionChanges()
+
+	// The source account pays the fee and has its sequence number bumped even
+	// if the transaction fails.
+	is.accounts[is.Cursor.Transaction().Envelope.Tx.SourceAccount.Address()] = struct{}{}
+	is.addTransactionEntryOwners()
+
 	if !is.Config.IngestFailedTransactions && !is.Cursor.Transaction().IsSuccessful() {
 		return
 	}
@@ -664,6 +903,22 @@ func (is *Session) ingestTransaction() {
 	is.ingestTransactionParticipants()
 }
 
+// ingestTransactionChanges records the changes the current transaction made
+// outside of its operations, i.e. bumping the source account's sequence
+// number.  Only version 1 transaction meta carries these changes.
+func (is *Session) ingestTransactionChanges() {
+	if is.Err != nil {
+		return
+	}
+
+	meta := is.Cursor.Transaction().ResultMeta
+	if meta.V1 == nil {
+		return
+	}
+
+	is.ingestEntryChanges(is.Cursor.TransactionID(), 0, meta.V1.TxChanges)
+}
+
 func (is *Session) ingestTransactionParticipants() {
 	if is.Err != nil {
 		return
@@ -682,6 +937,9 @@ func (is *Session) ingestTransactionParticipants() {
 	}
 
 	is.Ingestion.TransactionParticipants(is.Cursor.TransactionID(), p)
+	for _, account := range p {
+		is.accounts[account.Address()] = struct{}{}
+	}
 }
 
 // assetDetails sets the details for `a` on `result` using keys with `prefix`
@@ -871,6 +1129,26 @@ func (is *Session) operationFlagDetails(result map[string]interface{}, f int32,
 	result[prefix+"_flags_s"] = s
 }
 
+// publishEvents publishes the events of the ledgers committed by the session.
+func (is *Session) publishEvents() {
+	for _, event := range is.events {
+		is.LedgerEvents.Publish(event)
+	}
+	is.events = nil
+}
+
+// recordJobProgress records `seq` as the last ledger ingested by the session's
+// job, within the ingestion transaction so that it is committed along with the
+// ledger's data.
+func (is *Session) recordJobProgress(seq int32) {
+	if is.Err != nil {
+		return
+	}
+
+	q := history.Q{Session: is.Ingestion.DB}
+	is.Err = errors.Wrap(q.UpdateIngestionJobProgress(is.Job.ID, seq), "failed to record job progress")
+}
+
 // reportCursorState makes an http request to the configured stellar-core server
 // to report that it has finished processing the data being ingested.  This
 // allows stellar-core to free that storage when next it runs its own
@@ -911,3 +1189,89 @@ func (is *Session) validateLedger() {
 	// if hashes mistmatch, return an error
 
 }
+
+// ledgerEntryOwner returns the address of the account owning the ledger entry
+// identified by `key`: the account itself, the trustor of a trustline, the
+// seller of an offer or the account a data entry is attached to.
+func ledgerEntryOwner(key xdr.LedgerKey) Address {
+	var owner xdr.AccountId
+
+	switch key.Type {
+	case xdr.LedgerEntryTypeAccount:
+		owner = key.MustAccount().AccountId
+	case xdr.LedgerEntryTypeTrustline:
+		owner = key.MustTrustLine().AccountId
+	case xdr.LedgerEntryTypeOffer:
+		owner = key.MustOffer().SellerId
+	case xdr.LedgerEntryTypeData:
+		owner = key.MustData().AccountId
+	default:
+		panic(fmt.Errorf("Unknown ledger key type: %v", key.Type))
+	}
+
+	return Address(owner.Address())
+}
+
+// ledgerEntryChangeType returns the kind of change that took an entry from
+// `before` to `after`, where nil means the entry did not exist.  ok is false
+// when neither state exists.
+func ledgerEntryChangeType(before, after *xdr.LedgerEntry) (change xdr.LedgerEntryChangeType, ok bool) {
+	switch {
+	case before == nil && after != nil:
+		return xdr.LedgerEntryChangeTypeLedgerEntryCreated, true
+	case before != nil && after == nil:
+		return xdr.LedgerEntryChangeTypeLedgerEntryRemoved, true
+	case before != nil && after != nil:
+		return xdr.LedgerEntryChangeTypeLedgerEntryUpdated, true
+	default:
+		return 0, false
+	}
+}
+
+// operationAssets returns the assets referenced by `op`, whose source account
+// defaults to `source`.
+func operationAssets(op *xdr.Operation, source *xdr.AccountId) []xdr.Asset {
+	body := op.Body
+	switch body.Type {
+	case xdr.OperationTypePayment:
+		return []xdr.Asset{body.PaymentOp.Asset}
+	case xdr.OperationTypePathPayment:
+		assets := []xdr.Asset{body.PathPaymentOp.SendAsset, body.PathPaymentOp.DestAsset}
+		return append(assets, body.PathPaymentOp.Path...)
+	case xdr.OperationTypeManageOffer:
+		return []xdr.Asset{body.ManageOfferOp.Selling, body.ManageOfferOp.Buying}
+	case xdr.OperationTypeCreatePassiveOffer:
+		return []xdr.Asset{body.CreatePassiveOfferOp.Selling, body.CreatePassiveOfferOp.Buying}
+	case xdr.OperationTypeChangeTrust:
+		return []xdr.Asset{body.ChangeTrustOp.Line}
+	case xdr.OperationTypeAllowTrust:
+		issuer := defaultSourceAccount(op.SourceAccount, source)
+		return []xdr.Asset{body.AllowTrustOp.Asset.ToAsset(*issuer)}
+	default:
+		return nil
+	}
+}
+
+// uniqueLedgerKeys returns the keys of the entries affected by `changes`, in
+// order of first appearance.  Updated and removed entries appear twice in a
+// list of changes, once as a "state" entry and once as the change itself.
+func uniqueLedgerKeys(changes xdr.LedgerEntryChanges) []xdr.LedgerKey {
+	var keys []xdr.LedgerKey
+
+	for _, change := range changes {
+		key := change.LedgerKey()
+		seen := false
+		for _, k := range keys {
+			if k.Equals(key) {
+				seen = true
+				break
+			}
+		}
+
+		if !seen {
+			keys = append(keys, key)
+		}
+	}
+
+	return keys
+}
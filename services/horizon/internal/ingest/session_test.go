@@ -6,7 +6,10 @@ import (
 	protocolEffects "github.com/cowry-network/go/protocols/horizon/effects"
 	"github.com/cowry-network/go/services/horizon/internal/db2"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/cowry-network/go/services/horizon/internal/toid"
+	"github.com/cowry-network/go/support/db"
 	"github.com/cowry-network/go/xdr"
 )
 
@@ -121,3 +124,187 @@ func Test_ingestBumpSeq(t *testing.T) {
 		tt.Assert.Equal(int64(300000000000), details.NewSq)
 	}
 }
+
+func Test_ingestLedgerEntryChanges(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("change_trust")
+	defer tt.Finish()
+
+	s := ingest(tt, Config{EnableAssetStats: false})
+	tt.Require.NoError(s.Err)
+
+	q := &history.Q{Session: tt.HorizonSession()}
+	pq, err := db2.NewPageQuery("", true, "asc", 200)
+	tt.Require.NoError(err)
+
+	var changes []history.LedgerEntryChange
+	err = q.LedgerEntryChanges().
+		ForAccount("GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU").
+		Page(pq).
+		Select(&changes)
+	tt.Require.NoError(err)
+
+	// create_account, change_trust (x3): the trustline changes in all three
+	// change_trust operations, the account when the trustline is created and
+	// removed (subentry count).  Each change_trust transaction also charges a
+	// fee and bumps the account's sequence number outside of its operation.
+	if !tt.Assert.Len(changes, 12) {
+		return
+	}
+
+	tt.Assert.Equal(xdr.LedgerEntryTypeAccount, changes[0].EntryType)
+	tt.Assert.Equal(xdr.LedgerEntryChangeTypeLedgerEntryCreated, changes[0].ChangeType)
+	tt.Assert.False(changes[0].EntryBefore.Valid)
+	tt.Assert.True(changes[0].EntryAfter.Valid)
+
+	var feeChanges, txChanges int
+	for _, change := range changes {
+		id := toid.Parse(change.HistoryOperationID)
+		tt.Assert.True(change.LedgerKey.Valid)
+		switch {
+		case id.TransactionOrder == 0:
+			feeChanges++
+			tt.Assert.Equal(xdr.LedgerEntryTypeAccount, change.EntryType)
+			tt.Assert.Equal(xdr.LedgerEntryChangeTypeLedgerEntryUpdated, change.ChangeType)
+		case id.OperationOrder == 0:
+			txChanges++
+			tt.Assert.Equal(xdr.LedgerEntryTypeAccount, change.EntryType)
+		}
+	}
+	tt.Assert.Equal(3, feeChanges)
+	tt.Assert.Equal(3, txChanges)
+
+	var trustlineChanges []xdr.LedgerEntryChangeType
+	for _, change := range changes {
+		if change.EntryType != xdr.LedgerEntryTypeTrustline {
+			continue
+		}
+		trustlineChanges = append(trustlineChanges, change.ChangeType)
+
+		if change.EntryAfter.Valid {
+			var entry xdr.LedgerEntry
+			err = xdr.SafeUnmarshalBase64(change.EntryAfter.String, &entry)
+			tt.Require.NoError(err)
+			trustor := entry.Data.MustTrustLine().AccountId
+			tt.Assert.Equal(
+				"GCXKG6RN4ONIEPCMNFB732A436Z5PNDSRLGWK7GBLCMQLIFO4S7EYWVU",
+				trustor.Address(),
+			)
+		}
+	}
+
+	tt.Assert.Equal([]xdr.LedgerEntryChangeType{
+		xdr.LedgerEntryChangeTypeLedgerEntryCreated,
+		xdr.LedgerEntryChangeTypeLedgerEntryUpdated,
+		xdr.LedgerEntryChangeTypeLedgerEntryRemoved,
+	}, trustlineChanges)
+}
+
+func Test_ingestLedgerEvents(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+
+	sys := sys(tt, Config{EnableAssetStats: false})
+	sys.LedgerEvents = ledger.NewBus()
+	events := sys.LedgerEvents.Subscribe()
+	defer events.Close()
+
+	s := NewSession(sys)
+	s.Cursor = NewCursor(1, ledger.CurrentState().CoreLatest, sys)
+	s.Run()
+	tt.Require.NoError(s.Err)
+
+	tt.Require.Len(events.C, 3)
+	for seq := int32(1); seq <= 3; seq++ {
+		event := <-events.C
+		tt.Assert.Equal(seq, event.Sequence)
+
+		switch seq {
+		case 1:
+			tt.Assert.Empty(event.Accounts)
+		case 2:
+			// the master account creates three accounts
+			tt.Assert.Len(event.Accounts, 4)
+			tt.Assert.Contains(event.Accounts, "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+		}
+	}
+}
+
+// metalessSource loads ledgers from stellar-core but drops their meta, the way
+// ledgers read from a history archive come.
+type metalessSource struct {
+	core *db.Session
+}
+
+func (s *metalessSource) LatestLedger() (int32, error) {
+	return ledger.CurrentState().CoreLatest, nil
+}
+
+func (s *metalessSource) LoadLedger(lb *LedgerBundle) error {
+	err := lb.Load(s.core)
+	lb.WithoutMeta = true
+	return err
+}
+
+func Test_ingestWithoutMetaVersion(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+
+	sys := sys(tt, Config{EnableAssetStats: false})
+	sys.LedgerSource = &metalessSource{core: tt.CoreSession()}
+
+	s := NewSession(sys)
+	s.Cursor = NewCursor(1, ledger.CurrentState().CoreLatest, sys)
+	s.Run()
+	tt.Require.NoError(s.Err)
+
+	q := history.Q{Session: tt.HorizonSession()}
+	var ledgers []history.Ledger
+	tt.Require.NoError(q.Ledgers().Select(&ledgers))
+	tt.Require.NotEmpty(ledgers)
+	for _, l := range ledgers {
+		tt.Assert.Equal(int32(WithoutMetaVersion), l.ImporterVersion)
+	}
+}
+
+func Test_ingestLedgerEventsTradeCounterparties(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("trades")
+	defer tt.Finish()
+
+	sys := sys(tt, Config{EnableAssetStats: false})
+	sys.LedgerEvents = ledger.NewBus()
+	events := sys.LedgerEvents.Subscribe()
+	defer events.Close()
+
+	// one ledger per session, so that no event is dropped
+	accounts := map[int32][]string{}
+	for seq := int32(1); seq <= ledger.CurrentState().CoreLatest; seq++ {
+		s := NewSession(sys)
+		s.Cursor = NewCursor(seq, seq, sys)
+		s.Run()
+		tt.Require.NoError(s.Err)
+
+		event := <-events.C
+		accounts[event.Sequence] = event.Accounts
+	}
+
+	var trades []struct {
+		Ledger  int32  `db:"ledger"`
+		Base    string `db:"base"`
+		Counter string `db:"counter"`
+	}
+	err := tt.HorizonSession().SelectRaw(&trades, `
+		SELECT (htrd.history_operation_id >> 32)::integer as ledger, ba.address as base, ca.address as counter
+		FROM history_trades htrd
+		JOIN history_accounts ba ON ba.id = htrd.base_account_id
+		JOIN history_accounts ca ON ca.id = htrd.counter_account_id
+	`)
+	tt.Require.NoError(err)
+	tt.Require.NotEmpty(trades)
+
+	// the makers of the offers crossed wake up their streams, even when only
+	// their offers and trustlines changed
+	for _, trade := range trades {
+		tt.Assert.Contains(accounts[trade.Ledger], trade.Base)
+		tt.Assert.Contains(accounts[trade.Ledger], trade.Counter)
+	}
+}
@@ -6,19 +6,44 @@ import (
 	"github.com/cowry-network/go/services/horizon/internal/db2/core"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
 	herr "github.com/cowry-network/go/services/horizon/internal/errors"
-	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/toid"
 	"github.com/cowry-network/go/support/errors"
 	ilog "github.com/cowry-network/go/support/log"
 )
 
 // Backfill ingests history in reverse chronological order, from the current
-// horizon elder query for `n` ledgers
+// horizon elder query for `n` ledgers.  If a previous backfill of `n` ledgers
+// was interrupted or failed, it is resumed instead.  An unfinished backfill of
+// another number of ledgers is an error, as it must complete first for the
+// history to remain contiguous.
 func (i *System) Backfill(n uint) error {
-	start := ledger.CurrentState().HistoryElder - 1
-	end := start - int32(n) + 1
+	q := history.Q{Session: i.HorizonDB}
+	job := &history.IngestionJob{}
+
+	err := q.UnfinishedIngestionJob(job, JobBackfill)
+	if err == nil && int64(job.FirstLedger-job.LastLedger)+1 != int64(n) {
+		return errors.Errorf(
+			"backfill job %d of ledgers %d to %d is unfinished, run `horizon db backfill %d` to resume it",
+			job.ID, job.FirstLedger, job.LastLedger, job.FirstLedger-job.LastLedger+1,
+		)
+	}
+	if q.NoRows(err) {
+		var elder int32
+		err = q.ElderLedger(&elder)
+		if err != nil {
+			return errors.Wrap(err, "failed to load history elder ledger")
+		}
+
+		start := elder - 1
+		end := start - int32(n) + 1
+		err = q.CreateIngestionJob(job, JobBackfill, start, end)
+	}
+	if err != nil {
+		return errors.Wrap(err, "failed to load backfill job")
+	}
+
+	start, end := job.FirstLedger, job.LastLedger
 	is := NewSession(i)
-	is.Cursor = NewCursor(start, end, i)
 
 	log.WithField("start", start).
 		WithField("end", end).
@@ -26,15 +51,15 @@ func (i *System) Backfill(n uint) error {
 		WithField("ingested", is.Ingested).
 		Info("ingest: backfill start")
 
-	is.Run()
+	err = i.runJob(is, job)
 
 	log.WithField("start", start).
 		WithField("end", end).
-		WithField("err", is.Err).
+		WithField("err", err).
 		WithField("ingested", is.Ingested).
 		Info("ingest: backfill complete")
 
-	return is.Err
+	return err
 }
 
 // ClearAll removes all previously ingested historical data from the horizon
@@ -69,6 +94,10 @@ func (i *System) RebaseHistory() error {
 	var latest int32
 	var elder int32
 
+	if i.CoreDB == nil {
+		return errors.New("rebasing history requires a stellar-core database")
+	}
+
 	q := core.Q{Session: i.CoreDB}
 	err := q.LatestLedger(&latest)
 	if err != nil {
@@ -95,6 +124,15 @@ func (i *System) RebaseHistory() error {
 	return nil
 }
 
+// Pause prevents the system from starting new ingestion sessions until Resume
+// is called.  A session already in progress runs to completion.
+func (i *System) Pause() {
+	i.lock.Lock()
+	i.paused = true
+	i.lock.Unlock()
+	log.Info("ingest: paused")
+}
+
 // ReingestAll re-ingests all ledgers
 func (i *System) ReingestAll() (int, error) {
 
@@ -184,18 +222,50 @@ func (i *System) ReingestOutdated() (n int, err error) {
 }
 
 // ReingestRange reingests a range of ledgers, from `start` to `end`, inclusive.
+// The progress of the reingestion is recorded as an ingestion job, so that
+// reingesting the same range after an interruption resumes after the last
+// ledger committed.
 func (i *System) ReingestRange(start, end int32) (int, error) {
+	job, err := i.ingestionJob(JobReingest, start, end)
+	if err != nil {
+		return 0, err
+	}
+
 	is := NewSession(i)
-	is.Cursor = NewCursor(start, end, i)
 	is.ClearExisting = true
 
-	is.Run()
+	err = i.runJob(is, job)
+	rerr := i.rebuildTradeRollups(start, end)
+	if err == nil {
+		err = rerr
+	}
+
 	log.WithField("start", start).
 		WithField("end", end).
-		WithField("err", is.Err).
+		WithField("err", err).
 		WithField("ingested", is.Ingested).
 		Info("ingest: range complete")
-	return is.Ingested, is.Err
+	return is.Ingested, err
+}
+
+// rebuildTradeRollups recomputes the trade rollups of the days the ledgers
+// from `start` to `end` closed on, after their trades were reingested.  The
+// rollups are not updated as trades are cleared, as the trades they would be
+// recomputed from may be reingested concurrently.
+func (i *System) rebuildTradeRollups(start, end int32) error {
+	q := history.Q{Session: i.HorizonDB}
+
+	var timeRange history.TradeTimeRange
+	err := q.LedgerTimeRange(&timeRange, start, end)
+	if err != nil {
+		return errors.Wrap(err, "failed to load ledger close times")
+	}
+	if timeRange.First == nil {
+		return nil
+	}
+
+	err = q.RebuildTradeRollups(*timeRange.First, timeRange.Last.Add(time.Millisecond))
+	return errors.Wrap(err, "failed to rebuild trade rollups")
 }
 
 // ReingestSingle re-ingests a single ledger
@@ -204,10 +274,37 @@ func (i *System) ReingestSingle(sequence int32) error {
 	return err
 }
 
+// Resume lets the system start ingestion sessions again after a call to Pause.
+func (i *System) Resume() {
+	i.lock.Lock()
+	i.paused = false
+	i.lock.Unlock()
+	log.Info("ingest: resumed")
+}
+
+// Status returns a snapshot of the state of the ingestion system.
+func (i *System) Status() Status {
+	i.lock.Lock()
+	defer i.lock.Unlock()
+
+	return Status{
+		Paused:     i.paused,
+		InProgress: i.current != nil,
+		Version:    CurrentVersion,
+	}
+}
+
 // Tick triggers the ingestion system to ingest any new ledger data, provided
-// that there currently is not an import session in progress.
+// that there currently is not an import session in progress and that ingestion
+// is not paused.
 func (i *System) Tick() *Session {
 	i.lock.Lock()
+	if i.paused {
+		log.Debug("ingest: paused")
+		i.lock.Unlock()
+		return nil
+	}
+
 	if i.current != nil {
 		log.Info("ingest: already in progress")
 		i.lock.Unlock()
@@ -252,8 +349,13 @@ func (i *System) runOnce() {
 	// in another go routine and can return the same data for two different ingestion sessions.
 	var coreLatest, historyLatest int32
 
-	coreQ := core.Q{Session: i.CoreDB}
-	err := coreQ.LatestLedger(&coreLatest)
+	var err error
+	if i.LedgerSource != nil {
+		coreLatest, err = i.LedgerSource.LatestLedger()
+	} else {
+		coreQ := core.Q{Session: i.CoreDB}
+		err = coreQ.LatestLedger(&coreLatest)
+	}
 	if err != nil {
 		log.WithFields(ilog.F{"err": err}).Error("Error getting core latest ledger")
 		return
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/cowry-network/go/network"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
 	"github.com/cowry-network/go/services/horizon/internal/test"
 )
 
@@ -31,6 +32,68 @@ func TestBackfill(t *testing.T) {
 	}
 }
 
+func TestBackfillPendingJob(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("kahuna")
+	defer tt.Finish()
+	is := sys(tt, Config{EnableAssetStats: false})
+
+	err := is.ReingestSingle(10)
+	tt.Require.NoError(err)
+	tt.UpdateLedgerState()
+
+	q := history.Q{Session: tt.HorizonSession()}
+	var job history.IngestionJob
+	err = q.CreateIngestionJob(&job, JobBackfill, 9, 7)
+	tt.Require.NoError(err)
+
+	// a backfill of another length doesn't resume the pending job
+	err = is.Backfill(5)
+	if tt.Assert.Error(err) {
+		tt.Assert.Contains(err.Error(), "ledgers 9 to 7")
+	}
+
+	var found int
+	err = tt.HorizonSession().GetRaw(&found, "SELECT COUNT(*) FROM history_ledgers")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(1, found)
+
+	err = is.Backfill(3)
+	tt.Require.NoError(err)
+	err = tt.HorizonSession().GetRaw(&found, "SELECT COUNT(*) FROM history_ledgers")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(4, found)
+}
+
+func TestReingestRangeTradeRollups(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("trades")
+	defer tt.Finish()
+	s := ingest(tt, Config{EnableAssetStats: false})
+	tt.Require.NoError(s.Err)
+
+	type rollup struct {
+		Count      int64 `db:"count"`
+		BaseVolume int64 `db:"base_volume"`
+	}
+	load := func() []rollup {
+		var rollups []rollup
+		err := tt.HorizonSession().SelectRaw(&rollups, `
+			SELECT count, base_volume FROM history_trades_1m
+			ORDER BY base_asset_id, counter_asset_id, "timestamp"
+		`)
+		tt.Require.NoError(err)
+		return rollups
+	}
+
+	expected := load()
+	tt.Require.NotEmpty(expected)
+
+	// reingested trades are not counted twice
+	is := sys(tt, Config{EnableAssetStats: false})
+	_, err := is.ReingestRange(1, s.Cursor.LastLedger)
+	tt.Require.NoError(err)
+	tt.Assert.Equal(expected, load())
+}
+
 func TestClearAll(t *testing.T) {
 	tt := test.Start(t).Scenario("kahuna")
 	defer tt.Finish()
@@ -47,6 +110,27 @@ func TestClearAll(t *testing.T) {
 	tt.Assert.Equal(0, found)
 }
 
+func TestPause(t *testing.T) {
+	tt := test.Start(t).ScenarioWithoutHorizon("base")
+	defer tt.Finish()
+	is := sys(tt, Config{EnableAssetStats: false})
+	is.SkipCursorUpdate = true
+
+	is.Pause()
+	tt.Assert.Equal(Status{Paused: true, Version: CurrentVersion}, is.Status())
+	tt.Assert.Nil(is.Tick())
+
+	var found int
+	err := tt.HorizonSession().GetRaw(&found, "SELECT COUNT(*) FROM history_ledgers")
+	tt.Require.NoError(err)
+	tt.Assert.Equal(0, found)
+
+	is.Resume()
+	tt.Assert.Equal(Status{Paused: false, Version: CurrentVersion}, is.Status())
+	tt.Assert.NotNil(is.Tick())
+	tt.Assert.False(is.Status().InProgress)
+}
+
 func TestValidation(t *testing.T) {
 	tt := test.Start(t).Scenario("kahuna")
 	defer tt.Finish()
@@ -12,9 +12,13 @@ import (
 	"github.com/cowry-network/go/services/horizon/internal/db2/core"
 	"github.com/cowry-network/go/services/horizon/internal/db2/history"
 	"github.com/cowry-network/go/services/horizon/internal/ingest"
+	"github.com/cowry-network/go/services/horizon/internal/ledger"
+	"github.com/cowry-network/go/services/horizon/internal/orderbook"
+	"github.com/cowry-network/go/services/horizon/internal/simplepath"
 	"github.com/cowry-network/go/services/horizon/internal/txsub"
 	results "github.com/cowry-network/go/services/horizon/internal/txsub/results/db"
 	"github.com/cowry-network/go/services/horizon/internal/txsub/sequence"
+	submissions "github.com/cowry-network/go/services/horizon/internal/txsub/submissions/db"
 	"github.com/cowry-network/go/support/db"
 	"github.com/cowry-network/go/support/log"
 )
@@ -30,6 +34,19 @@ func initHorizonDb(app *App) {
 	session.DB.SetMaxIdleConns(app.config.MaxDBConnections)
 	session.DB.SetMaxOpenConns(app.config.MaxDBConnections)
 	app.historyQ = &history.Q{session}
+
+	for _, dsn := range app.config.HistoryReplicaURLs {
+		replica, err := db.Open("postgres", dsn)
+		if err != nil {
+			log.Panic(err)
+		}
+
+		replica.DB.SetMaxIdleConns(app.config.MaxDBConnections)
+		replica.DB.SetMaxOpenConns(app.config.MaxDBConnections)
+		app.historyReplicas = append(app.historyReplicas, &historyReplica{
+			q: &history.Q{replica},
+		})
+	}
 }
 
 func initCoreDb(app *App) {
@@ -67,7 +84,29 @@ func initIngester(app *App) {
 	)
 
 	app.ingester.SkipCursorUpdate = app.config.SkipCursorUpdate
+	app.ledgerEvents = ledger.NewBus()
+	app.ingester.LedgerEvents = app.ledgerEvents
 	app.ingester.HistoryRetentionCount = app.config.HistoryRetentionCount
+
+	if app.config.HistoryArchiveURL != "" {
+		source, err := ingest.NewArchiveLedgerSource(app.config.HistoryArchiveURL, app.config.NetworkPassphrase)
+		if err != nil {
+			log.Panic(err)
+		}
+		app.ingester.LedgerSource = source
+	}
+}
+
+// initPathFinder installs the path finding engine selected by the config onto
+// the provided app.
+func initPathFinder(app *App) {
+	switch app.config.PathFinder {
+	case PathFinderOrderBook:
+		app.orderBookGraph = orderbook.NewOrderBookGraph()
+		app.paths = &orderbook.Finder{Graph: app.orderBookGraph}
+	default:
+		app.paths = &simplepath.Finder{Q: app.CoreQ()}
+	}
 }
 
 // initSentry initialized the default sentry client with the configured DSN
@@ -116,6 +155,13 @@ func initDbMetrics(app *App) {
 	app.metrics.Register("history.open_connections", app.horizonConnGauge)
 	app.metrics.Register("stellar_core.open_connections", app.coreConnGauge)
 	app.metrics.Register("goroutines", app.goroutineGauge)
+
+	// both connection pools share the same limit
+	maxConnections := metrics.NewFunctionalGauge(func() int64 {
+		return int64(app.config.MaxDBConnections)
+	})
+	app.metrics.Register("history.max_open_connections", maxConnections)
+	app.metrics.Register("stellar_core.max_open_connections", maxConnections)
 }
 
 func initIngesterMetrics(app *App) {
@@ -126,6 +172,8 @@ func initIngesterMetrics(app *App) {
 		app.ingester.Metrics.IngestLedgerTimer)
 	app.metrics.Register("ingester.clear_ledger",
 		app.ingester.Metrics.ClearLedgerTimer)
+	app.metrics.Register("ingester.load_ledger",
+		app.ingester.Metrics.LoadLedgerTimer)
 }
 
 func initTxSubMetrics(app *App) {
@@ -135,6 +183,8 @@ func initTxSubMetrics(app *App) {
 	app.metrics.Register("txsub.succeeded", app.submitter.Metrics.SuccessfulSubmissionsMeter)
 	app.metrics.Register("txsub.failed", app.submitter.Metrics.FailedSubmissionsMeter)
 	app.metrics.Register("txsub.total", app.submitter.Metrics.SubmissionTimer)
+	app.metrics.Register("txsub.stuck", app.submitter.Metrics.StuckSubmissionsMeter)
+	app.metrics.Register("txsub.rebroadcast", app.submitter.Metrics.RebroadcastSubmissionsMeter)
 }
 
 // initWebMetrics registers the metrics for the web server into the provided
@@ -199,16 +249,29 @@ func dialRedis(redisURL *url.URL) func() (redis.Conn, error) {
 
 func initSubmissionSystem(app *App) {
 	cq := &core.Q{Session: app.CoreSession(nil)}
+	hq := &history.Q{Session: app.HorizonSession(nil)}
+
+	pending := txsub.NewDefaultSubmissionList()
+	if app.config.TxSubSharedSubmissions {
+		pending = txsub.NewSharedSubmissionList(&submissions.DB{History: hq})
+	}
 
 	app.submitter = &txsub.System{
-		Pending:         txsub.NewDefaultSubmissionList(),
+		Pending:         pending,
 		Submitter:       txsub.NewDefaultSubmitter(http.DefaultClient, app.config.StellarCoreURL),
 		SubmissionQueue: sequence.NewManager(),
 		Results: &results.DB{
 			Core:    cq,
-			History: &history.Q{Session: app.HorizonSession(nil)},
+			History: hq,
 		},
 		Sequences:         cq.SequenceProvider(),
 		NetworkPassphrase: app.config.NetworkPassphrase,
 	}
+
+	if app.config.TxSubFeeSource != nil {
+		app.submitter.FeeEscalation = &txsub.FeeEscalation{
+			Source:          app.config.TxSubFeeSource,
+			MaxOperationFee: int64(app.config.TxSubMaxOperationFee),
+		}
+	}
 }
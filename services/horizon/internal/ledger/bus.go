@@ -0,0 +1,110 @@
+package ledger
+
+import (
+	"sort"
+)
+
+// subscriptionBufferSize is the number of events a Subscription holds until
+// they are received.
+const subscriptionBufferSize = 16
+
+// NewBus returns a new Bus without subscribers.
+func NewBus() *Bus {
+	return &Bus{}
+}
+
+// Publish delivers `event` to the subscribers of the bus whose filter matches
+// it.
+func (b *Bus) Publish(event IngestedLedger) {
+	if b == nil {
+		return
+	}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	for sub := range b.subscribers {
+		if !sub.filter.Matches(event) {
+			continue
+		}
+
+		select {
+		case sub.c <- event:
+		default:
+			// the subscriber is behind, it will find the data of this ledger when
+			// handling the events it has yet to receive
+		}
+	}
+}
+
+// Subscribe returns a new subscription to every event published on the bus,
+// until its filter is set.
+// The subscription of a nil Bus never receives events.
+func (b *Bus) Subscribe() *Subscription {
+	if b == nil {
+		return &Subscription{}
+	}
+
+	c := make(chan IngestedLedger, subscriptionBufferSize)
+	sub := &Subscription{C: c, c: c, bus: b}
+
+	b.lock.Lock()
+	defer b.lock.Unlock()
+
+	if b.subscribers == nil {
+		b.subscribers = map[*Subscription]struct{}{}
+	}
+	b.subscribers[sub] = struct{}{}
+
+	return sub
+}
+
+// Close stops the delivery of events to the subscription.
+func (s *Subscription) Close() {
+	if s.bus == nil {
+		return
+	}
+
+	s.bus.lock.Lock()
+	defer s.bus.lock.Unlock()
+	delete(s.bus.subscribers, s)
+}
+
+// SetFilter restricts the events delivered to the subscription to those
+// matching `filter`.
+func (s *Subscription) SetFilter(filter Filter) {
+	if s.bus == nil {
+		return
+	}
+
+	s.bus.lock.Lock()
+	defer s.bus.lock.Unlock()
+	s.filter = filter
+}
+
+// Matches returns true if `event` touches any of the accounts or assets of the
+// filter, or if the filter is empty.
+func (f Filter) Matches(event IngestedLedger) bool {
+	if len(f.Accounts) == 0 && len(f.Assets) == 0 {
+		return true
+	}
+
+	for _, account := range f.Accounts {
+		i := sort.SearchStrings(event.Accounts, account)
+		if i < len(event.Accounts) && event.Accounts[i] == account {
+			return true
+		}
+	}
+
+	for _, asset := range f.Assets {
+		key := asset.String()
+		i := sort.Search(len(event.Assets), func(i int) bool {
+			return event.Assets[i].String() >= key
+		})
+		if i < len(event.Assets) && event.Assets[i].Equals(asset) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,63 @@
+package ledger
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus(t *testing.T) {
+	bus := NewBus()
+	first := bus.Subscribe()
+	second := bus.Subscribe()
+
+	bus.Publish(IngestedLedger{Sequence: 2, Accounts: []string{"GA"}})
+	assert.Equal(t, IngestedLedger{Sequence: 2, Accounts: []string{"GA"}}, <-first.C)
+	assert.Equal(t, int32(2), (<-second.C).Sequence)
+
+	second.Close()
+	bus.Publish(IngestedLedger{Sequence: 3})
+	assert.Equal(t, int32(3), (<-first.C).Sequence)
+	assert.Len(t, second.C, 0)
+
+	// a subscriber that is behind does not block the publisher
+	for seq := int32(4); seq < 4+2*subscriptionBufferSize; seq++ {
+		bus.Publish(IngestedLedger{Sequence: seq})
+	}
+	assert.Len(t, first.C, subscriptionBufferSize)
+	assert.Equal(t, int32(4), (<-first.C).Sequence)
+	first.Close()
+}
+
+func TestBusFilter(t *testing.T) {
+	usd := xdr.MustNewCreditAsset("USD", "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+	eur := xdr.MustNewCreditAsset("EUR", "GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H")
+
+	bus := NewBus()
+	byAccount := bus.Subscribe()
+	byAccount.SetFilter(Filter{Accounts: []string{"GB"}})
+	byAsset := bus.Subscribe()
+	byAsset.SetFilter(Filter{Assets: []xdr.Asset{usd}})
+
+	bus.Publish(IngestedLedger{Sequence: 2, Accounts: []string{"GA", "GC"}, Assets: []xdr.Asset{eur}})
+	bus.Publish(IngestedLedger{Sequence: 3, Accounts: []string{"GA", "GB"}})
+	bus.Publish(IngestedLedger{Sequence: 4, Assets: []xdr.Asset{eur, usd}})
+
+	if assert.Len(t, byAccount.C, 1) {
+		assert.Equal(t, int32(3), (<-byAccount.C).Sequence)
+	}
+	if assert.Len(t, byAsset.C, 1) {
+		assert.Equal(t, int32(4), (<-byAsset.C).Sequence)
+	}
+
+	assert.True(t, Filter{}.Matches(IngestedLedger{Sequence: 5}))
+}
+
+func TestNilBus(t *testing.T) {
+	var bus *Bus
+	sub := bus.Subscribe()
+	bus.Publish(IngestedLedger{Sequence: 2})
+	assert.Nil(t, sub.C)
+	sub.Close()
+}
@@ -7,14 +7,56 @@ package ledger
 
 import (
 	"sync"
+
+	"github.com/cowry-network/go/xdr"
 )
 
 // State represents a snapshot of both horizon's and stellar-core's view of the
 // ledger.
 type State struct {
-	CoreLatest    int32 `db:"core_latest"`
-	HistoryLatest int32 `db:"history_latest"`
-	HistoryElder  int32 `db:"history_elder"`
+	CoreLatest    int32 `db:"core_latest" json:"core_latest"`
+	HistoryLatest int32 `db:"history_latest" json:"history_latest"`
+	HistoryElder  int32 `db:"history_elder" json:"history_elder"`
+}
+
+// IngestedLedger is the event published on a Bus once the data of a ledger has
+// been committed to the history database.
+type IngestedLedger struct {
+	Sequence int32
+	// Accounts are the addresses of the accounts participating in the
+	// transactions of the ledger, sorted.
+	Accounts []string
+	// Assets are the assets referenced by the operations of the ledger, sorted
+	// by their string representation.
+	Assets []xdr.Asset
+}
+
+// Bus delivers the IngestedLedger events published by the ingestion system to
+// its subscribers, so that they can react to new ledgers without polling the
+// database.  The zero value is ready to use, and a nil Bus publishes nothing.
+type Bus struct {
+	lock        sync.Mutex
+	subscribers map[*Subscription]struct{}
+}
+
+// Filter selects the IngestedLedger events touching any of its accounts or
+// assets.  The zero Filter selects every event.
+type Filter struct {
+	Accounts []string
+	Assets   []xdr.Asset
+}
+
+// Subscription receives the events published on a Bus until it is closed.
+type Subscription struct {
+	// C receives the events published on the bus that match the subscription's
+	// filter.  Events published while its buffer is full are dropped rather than
+	// blocking the publisher, so an event signals that new data is available,
+	// not the complete list of changes.
+	C <-chan IngestedLedger
+
+	c      chan IngestedLedger
+	bus    *Bus
+	filter Filter
 }
 
 // CurrentState returns the cached snapshot of ledger state
@@ -51,6 +51,7 @@ func contextMiddleware(next http.Handler) http.Handler {
 const (
 	clientNameHeader    = "X-Client-Name"
 	clientVersionHeader = "X-Client-Version"
+	apiKeyHeader        = "X-Api-Key"
 )
 
 // loggerMiddleware logs http requests and resposnes to the logging subsytem of horizon.
@@ -137,10 +138,18 @@ func firstXForwardedFor(r *http.Request) string {
 }
 
 func (web *Web) RateLimitMiddleware(next http.Handler) http.Handler {
-	if web.rateLimiter == nil {
+	if web.rateLimiter == nil && len(web.routeRateLimiters) == 0 {
 		return next
 	}
-	return web.rateLimiter.RateLimit(next)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		rateLimiter := web.rateLimiterFor(r)
+		if rateLimiter == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+		rateLimiter.RateLimit(next).ServeHTTP(w, r)
+	})
 }
 
 // recoverMiddleware helps the server recover from panics. It ensures that
@@ -1,9 +1,11 @@
 package horizon
 
 import (
+	"net/http"
 	"strconv"
 	"testing"
 
+	"github.com/cowry-network/go/services/horizon/internal/ratelimit"
 	"github.com/cowry-network/go/services/horizon/internal/test"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
@@ -142,3 +144,80 @@ func TestRateLimit_Redis(t *testing.T) {
 	w = rh.Get("/", test.RequestHelperRemoteAddr("127.0.0.2"))
 	assert.Equal(t, 200, w.Code)
 }
+
+// Per-route rules use their own quota.
+func TestRateLimit_PerRoute(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+	c := NewTestConfig()
+	c.RateLimit = &throttled.RateQuota{
+		MaxRate:  throttled.PerHour(10),
+		MaxBurst: 9,
+	}
+	c.RateLimitRules = []ratelimit.Rule{
+		{Method: "GET", Path: "/ledgers", Quota: throttled.RateQuota{MaxRate: throttled.PerHour(2), MaxBurst: 1}},
+	}
+	app := NewApp(c)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	for i := 0; i < 2; i++ {
+		w := rh.Get("/ledgers")
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "2", w.Header().Get("X-RateLimit-Limit"))
+	}
+
+	w := rh.Get("/ledgers")
+	assert.Equal(t, 429, w.Code)
+
+	// routes beneath the rule's path share its quota
+	w = rh.Get("/ledgers/1")
+	assert.Equal(t, 429, w.Code)
+
+	// other routes use the default quota
+	w = rh.Get("/")
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, "10", w.Header().Get("X-RateLimit-Limit"))
+	assert.Equal(t, "9", w.Header().Get("X-RateLimit-Remaining"))
+}
+
+// Allowlisted ips and api keys are not rate limited.
+func TestRateLimit_Allowlist(t *testing.T) {
+	ht := StartHTTPTest(t, "base")
+	defer ht.Finish()
+	c := NewTestConfig()
+	c.RateLimit = &throttled.RateQuota{
+		MaxRate:  throttled.PerHour(1),
+		MaxBurst: 0,
+	}
+	c.RateLimitAllowlist = []string{"127.0.0.2", "5.5.0.0/16", "s3cr3t"}
+	app := NewApp(c)
+	defer app.Close()
+	rh := NewRequestHelper(app)
+
+	w := rh.Get("/")
+	assert.Equal(t, 200, w.Code)
+	w = rh.Get("/")
+	assert.Equal(t, 429, w.Code)
+
+	apiKey := func(r *http.Request) {
+		r.Header.Set("X-Api-Key", "s3cr3t")
+	}
+	for i := 0; i < 3; i++ {
+		w = rh.Get("/", apiKey)
+		assert.Equal(t, 200, w.Code)
+		assert.Equal(t, "", w.Header().Get("X-RateLimit-Limit"))
+
+		w = rh.Get("/", test.RequestHelperRemoteAddr("127.0.0.2:4312"))
+		assert.Equal(t, 200, w.Code)
+
+		w = rh.Get("/", test.RequestHelperXFF("5.5.1.2"))
+		assert.Equal(t, 200, w.Code)
+	}
+
+	// unknown api keys are rate limited by ip
+	w = rh.Get("/", func(r *http.Request) {
+		r.Header.Set("X-Api-Key", "guess")
+	})
+	assert.Equal(t, 429, w.Code)
+}
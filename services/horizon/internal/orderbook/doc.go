@@ -0,0 +1,31 @@
+// Package orderbook provides an implementation of paths.Finder that searches
+// for payment paths against an in-memory graph of every offer in stellar-core's
+// order book, instead of querying stellar-core's database for every hop.
+//
+// The graph (OrderBookGraph) maps every selling asset to the buying assets it
+// can be traded for, along with the offers for each pair sorted by price. It is
+// rebuilt from stellar-core's `offers` table every time a new ledger closes, so
+// a search never observes a partially applied ledger.
+//
+// The search itself works like a breadth first search starting at the
+// destination asset and moving towards the source assets, one hop per
+// iteration:
+// 1. The frontier is initialized with a single-asset path containing the
+//    destination asset, the cost of which is the destination amount.
+// 2. Every iteration extends each path in the frontier by one asset, computing
+//    the amount of the new asset needed to buy the current cost of the path's
+//    head (see `OrderBookGraph.costToConsumeLiquidity`). For every asset only
+//    the cheapest path of a given length is kept, which bounds the work done
+//    per iteration by the number of edges in the graph.
+// 3. Every path in the frontier whose head is one of the source assets is a
+//    result.
+// The search ends when the frontier is empty or the maximum path length has
+// been reached. Results are sorted by cost, so the best rate for each source
+// asset comes first.
+//
+// Strict send searches (Finder.FindFixedPaths) run the same algorithm in the
+// opposite direction: the frontier starts at the source asset with the source
+// amount, every iteration computes the amount of the new asset received (see
+// `OrderBookGraph.amountReceived`) keeping the path receiving the most for
+// every asset, and results are sorted by the amount received.
+package orderbook
@@ -0,0 +1,108 @@
+package orderbook
+
+import (
+	"github.com/cowry-network/go/services/horizon/internal/paths"
+	"github.com/cowry-network/go/support/errors"
+	"github.com/cowry-network/go/support/log"
+)
+
+// MaxPathLength is a maximum path length as defined in XDR file (includes source and
+// destination assets).
+const MaxPathLength uint = 7
+
+// Finder implements the paths.Finder interface and searches for payment paths
+// using an in-memory graph of the order book. Unlike simplepath.Finder it does
+// not query the database while searching, so it's considerably faster at the
+// cost of keeping every offer in memory.
+type Finder struct {
+	Graph *OrderBookGraph
+}
+
+// ensure the struct is paths.Finder compliant
+var _ paths.Finder = &Finder{}
+
+// Find performs a path find with the provided query.
+func (f *Finder) Find(q paths.Query, maxLength uint) (result []paths.Path, err error) {
+	log.WithField("source_assets", q.SourceAssets).
+		WithField("destination_asset", q.DestinationAsset).
+		WithField("destination_amount", q.DestinationAmount).
+		Info("Starting pathfind")
+
+	if len(q.SourceAssets) == 0 {
+		err = errors.New("No source assets")
+		return
+	}
+
+	if maxLength == 0 {
+		maxLength = MaxPathLength
+	}
+
+	if maxLength < 2 || maxLength > MaxPathLength {
+		err = errors.New("invalid value of maxLength")
+		return
+	}
+
+	// Hold the read lock for the whole search so that a refresh of the graph
+	// can't happen in the middle of it.
+	f.Graph.lock.RLock()
+	defer f.Graph.lock.RUnlock()
+
+	s := &search{
+		Query:     q,
+		Graph:     f.Graph,
+		MaxLength: maxLength,
+	}
+
+	s.Init()
+	s.Run()
+
+	result, err = s.Results, s.Err
+
+	log.WithField("found", len(s.Results)).
+		WithField("err", s.Err).
+		WithField("ledger", f.Graph.lastLedger).
+		Info("Finished pathfind")
+	return
+}
+
+// FindFixedPaths performs a strict send path find with the provided query.
+func (f *Finder) FindFixedPaths(q paths.Query, maxLength uint) (result []paths.Path, err error) {
+	log.WithField("source_asset", q.SourceAsset).
+		WithField("source_amount", q.SourceAmount).
+		WithField("destination_assets", q.DestinationAssets).
+		Info("Starting strict send pathfind")
+
+	if len(q.DestinationAssets) == 0 {
+		err = errors.New("No destination assets")
+		return
+	}
+
+	if maxLength == 0 {
+		maxLength = MaxPathLength
+	}
+
+	if maxLength < 2 || maxLength > MaxPathLength {
+		err = errors.New("invalid value of maxLength")
+		return
+	}
+
+	f.Graph.lock.RLock()
+	defer f.Graph.lock.RUnlock()
+
+	s := &sendSearch{
+		Query:     q,
+		Graph:     f.Graph,
+		MaxLength: maxLength,
+	}
+
+	s.Init()
+	s.Run()
+
+	result, err = s.Results, s.Err
+
+	log.WithField("found", len(s.Results)).
+		WithField("err", s.Err).
+		WithField("ledger", f.Graph.lastLedger).
+		Info("Finished strict send pathfind")
+	return
+}
@@ -0,0 +1,288 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/paths"
+	"github.com/cowry-network/go/services/horizon/internal/simplepath"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/cowry-network/go/support/db"
+	"github.com/cowry-network/go/xdr"
+)
+
+func TestFinder(t *testing.T) {
+	tt := test.Start(t).Scenario("paths")
+	defer tt.Finish()
+
+	graph := NewOrderBookGraph()
+	tt.Require.NoError(graph.Refresh(&core.Q{Session: tt.CoreSession()}, 5))
+	finder := &Finder{Graph: graph}
+
+	native := xdr.MustNewNativeAsset()
+	usd := xdr.MustNewCreditAsset("USD", issuer)
+	eur := xdr.MustNewCreditAsset("EUR", issuer)
+	inter1 := xdr.MustNewCreditAsset("1", issuer)
+	inter21 := xdr.MustNewCreditAsset("21", issuer)
+	inter22 := xdr.MustNewCreditAsset("22", issuer)
+
+	query := paths.Query{
+		DestinationAddress: "GAEDTJ4PPEFVW5XV2S7LUXBEHNQMX5Q2GM562RJGOQG7GVCE5H3HIB4V",
+		DestinationAsset:   eur,
+		DestinationAmount:  xdr.Int64(200000000), // 20.0000000
+		SourceAssets:       []xdr.Asset{usd},
+	}
+
+	p, err := finder.Find(query, MaxPathLength)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 3)
+
+		// Consuming offers:
+		// - selling 10 USD for EUR, price = 0.5
+		// - selling 10 USD for EUR, price = 0.5
+		tt.Assert.Equal(p[0].Source.String(), usd.String())
+		tt.Assert.Equal(p[0].Destination.String(), eur.String())
+		tt.Assert.Equal(p[0].Cost, xdr.Int64(100000000)) // 10.0000000
+		tt.Assert.Len(p[0].Path, 0)
+
+		// Consuming offers:
+		// - selling 20 USD for `1`, price = 1
+		// - selling 20 `1` for EUR, price = 1
+		tt.Assert.Equal(p[1].Source.String(), usd.String())
+		tt.Assert.Equal(p[1].Destination.String(), eur.String())
+		tt.Assert.Equal(p[1].Cost, xdr.Int64(200000000))
+		if tt.Assert.Len(p[1].Path, 1) {
+			tt.Assert.Equal(p[1].Path[0].String(), inter1.String())
+		}
+
+		// Consuming offers:
+		// - selling 20 USD for `21`, price = 1
+		// - selling 20 `21` for `22`, price = 1
+		// - selling 20 `22` for EUR, price = 1
+		tt.Assert.Equal(p[2].Source.String(), usd.String())
+		tt.Assert.Equal(p[2].Destination.String(), eur.String())
+		tt.Assert.Equal(p[2].Cost, xdr.Int64(200000000))
+		if tt.Assert.Len(p[2].Path, 2) {
+			tt.Assert.Equal(p[2].Path[0].String(), inter21.String())
+			tt.Assert.Equal(p[2].Path[1].String(), inter22.String())
+		}
+	}
+
+	query.DestinationAmount = xdr.Int64(200000001)
+	p, err = finder.Find(query, MaxPathLength)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 2)
+
+		tt.Assert.Equal(p[0].Cost, xdr.Int64(100000001))
+		tt.Assert.Len(p[0].Path, 0)
+
+		tt.Assert.Equal(p[1].Cost, xdr.Int64(200000001))
+		tt.Assert.Len(p[1].Path, 2)
+	}
+
+	// respects the maximum path length
+	p, err = finder.Find(query, 3)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 1)
+	}
+
+	query.DestinationAmount = xdr.Int64(500000001)
+	p, err = finder.Find(query, MaxPathLength)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 0)
+	}
+
+	// paths that involve native currencies can be found
+	query = paths.Query{
+		DestinationAddress: issuer,
+		DestinationAsset:   native,
+		DestinationAmount:  xdr.Int64(1),
+		SourceAssets:       []xdr.Asset{usd, native},
+	}
+	p, err = finder.Find(query, MaxPathLength)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 2)
+	}
+
+	// trades are priced starting from the destination asset, see the
+	// equivalent test in simplepath for details.
+	aaa := xdr.MustNewCreditAsset("AAA", issuer)
+	bbb := xdr.MustNewCreditAsset("BBB", issuer)
+	ccc := xdr.MustNewCreditAsset("CCC", issuer)
+
+	query = paths.Query{
+		DestinationAddress: issuer,
+		DestinationAsset:   ccc,
+		DestinationAmount:  xdr.Int64(100000000), // 10.0
+		SourceAssets:       []xdr.Asset{aaa},
+	}
+	p, err = finder.Find(query, MaxPathLength)
+	if tt.Assert.NoError(err) {
+		if tt.Assert.Len(p, 1) {
+			tt.Assert.Equal(p[0].Source.String(), aaa.String())
+			tt.Assert.Equal(p[0].Destination.String(), ccc.String())
+			tt.Assert.Equal(p[0].Cost, xdr.Int64(110000000)) // 11.0
+			if tt.Assert.Len(p[0].Path, 1) {
+				tt.Assert.Equal(p[0].Path[0].String(), bbb.String())
+			}
+		}
+	}
+
+	// invalid queries
+	_, err = finder.Find(paths.Query{DestinationAsset: eur}, MaxPathLength)
+	tt.Assert.Error(err)
+	_, err = finder.Find(query, MaxPathLength+1)
+	tt.Assert.Error(err)
+}
+
+// benchmarkQueries are the queries exercised by TestFinder, used to compare
+// the performance of the available finders on the same data.
+func TestFinder_FindFixedPaths(t *testing.T) {
+	tt := test.Start(t).Scenario("paths")
+	defer tt.Finish()
+
+	graph := NewOrderBookGraph()
+	tt.Require.NoError(graph.Refresh(&core.Q{Session: tt.CoreSession()}, 5))
+	finder := &Finder{Graph: graph}
+
+	usd := xdr.MustNewCreditAsset("USD", issuer)
+	eur := xdr.MustNewCreditAsset("EUR", issuer)
+	inter1 := xdr.MustNewCreditAsset("1", issuer)
+	inter21 := xdr.MustNewCreditAsset("21", issuer)
+
+	query := paths.Query{
+		SourceAsset:       usd,
+		SourceAmount:      xdr.Int64(100000000), // 10.0000000
+		DestinationAssets: []xdr.Asset{eur},
+	}
+
+	p, err := finder.FindFixedPaths(query, MaxPathLength)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 4)
+
+		tt.Assert.Equal(p[0].Source.String(), usd.String())
+		tt.Assert.Equal(p[0].Destination.String(), eur.String())
+		tt.Assert.Equal(p[0].Cost, xdr.Int64(100000000))
+		tt.Assert.Equal(p[0].DestinationAmount, xdr.Int64(200000000)) // 20.0000000
+		tt.Assert.Len(p[0].Path, 0)
+
+		tt.Assert.Equal(p[1].DestinationAmount, xdr.Int64(100000000))
+		if tt.Assert.Len(p[1].Path, 1) {
+			tt.Assert.Equal(p[1].Path[0].String(), inter1.String())
+		}
+
+		tt.Assert.Equal(p[2].DestinationAmount, xdr.Int64(100000000))
+		if tt.Assert.Len(p[2].Path, 2) {
+			tt.Assert.Equal(p[2].Path[0].String(), inter21.String())
+		}
+
+		tt.Assert.Equal(p[3].DestinationAmount, xdr.Int64(6250000))
+		tt.Assert.Len(p[3].Path, 3)
+	}
+
+	// respects the maximum path length
+	p, err = finder.FindFixedPaths(query, 3)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 2)
+	}
+
+	query.SourceAmount = xdr.Int64(250000000)
+	p, err = finder.FindFixedPaths(query, MaxPathLength)
+	if tt.Assert.NoError(err) {
+		if tt.Assert.Len(p, 2) {
+			tt.Assert.Equal(p[0].DestinationAmount, xdr.Int64(250000000))
+			tt.Assert.Equal(p[1].DestinationAmount, xdr.Int64(15625000))
+		}
+	}
+
+	query.DestinationAssets = nil
+	_, err = finder.FindFixedPaths(query, MaxPathLength)
+	tt.Assert.Error(err)
+}
+
+func benchmarkQueries() []paths.Query {
+	usd := xdr.MustNewCreditAsset("USD", issuer)
+	eur := xdr.MustNewCreditAsset("EUR", issuer)
+	native := xdr.MustNewNativeAsset()
+
+	return []paths.Query{
+		{
+			DestinationAsset:  eur,
+			DestinationAmount: xdr.Int64(200000000),
+			SourceAssets:      []xdr.Asset{usd},
+		},
+		{
+			DestinationAsset:  eur,
+			DestinationAmount: xdr.Int64(200000001),
+			SourceAssets:      []xdr.Asset{usd},
+		},
+		{
+			DestinationAsset:  native,
+			DestinationAmount: xdr.Int64(1),
+			SourceAssets:      []xdr.Asset{usd, native},
+		},
+		{
+			DestinationAsset:  xdr.MustNewCreditAsset("CCC", issuer),
+			DestinationAmount: xdr.Int64(100000000),
+			SourceAssets:      []xdr.Asset{xdr.MustNewCreditAsset("AAA", issuer)},
+		},
+	}
+}
+
+func benchmarkFinder(b *testing.B, finder paths.Finder) {
+	queries := benchmarkQueries()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, q := range queries {
+			_, err := finder.Find(q, MaxPathLength)
+			if err != nil {
+				b.Fatal(err)
+			}
+		}
+	}
+}
+
+func benchmarkCoreQ(b *testing.B) *core.Q {
+	test.LoadScenarioWithoutHorizon("paths")
+
+	session, err := db.Open("postgres", test.StellarCoreDatabaseURL())
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	return &core.Q{Session: session}
+}
+
+func BenchmarkFinder(b *testing.B) {
+	q := benchmarkCoreQ(b)
+	defer q.Session.DB.Close()
+
+	graph := NewOrderBookGraph()
+	if err := graph.Refresh(q, 5); err != nil {
+		b.Fatal(err)
+	}
+
+	benchmarkFinder(b, &Finder{Graph: graph})
+}
+
+func BenchmarkSimplePathFinder(b *testing.B) {
+	q := benchmarkCoreQ(b)
+	defer q.Session.DB.Close()
+
+	benchmarkFinder(b, &simplepath.Finder{Q: q})
+}
+
+func BenchmarkOrderBookGraphRefresh(b *testing.B) {
+	q := benchmarkCoreQ(b)
+	defer q.Session.DB.Close()
+
+	graph := NewOrderBookGraph()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if err := graph.Refresh(q, 5); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
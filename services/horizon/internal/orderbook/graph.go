@@ -0,0 +1,218 @@
+package orderbook
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/paths"
+	"github.com/cowry-network/go/support/errors"
+	"github.com/cowry-network/go/xdr"
+)
+
+// ErrNotEnough represents an error that occurs when pricing a trade on an
+// orderbook.  This error occurs when the orderbook cannot fulfill the
+// requested amount.
+var ErrNotEnough = errors.New("not enough depth")
+
+// offer contains the fields of a stellar-core offer needed to compute the cost
+// of consuming liquidity from an order book.
+type offer struct {
+	ID     int64
+	Amount int64
+	Pricen int64
+	Priced int64
+	Price  float64
+}
+
+// OrderBookGraph is an in-memory representation of every offer in the order
+// book, indexed by the assets being traded. It is safe for concurrent use.
+type OrderBookGraph struct {
+	lock sync.RWMutex
+	// edges maps a selling asset to every asset it is being sold for, each of
+	// which holds the offers for that pair sorted by price.
+	edges map[string]map[string][]offer
+	// buyingEdges maps a buying asset to the sorted list of assets offered in
+	// exchange for it, it's used to walk the graph from the source asset in
+	// strict send searches.
+	buyingEdges map[string][]string
+	// assets maps the string representation of every asset in the graph to
+	// the asset itself.
+	assets     map[string]xdr.Asset
+	lastLedger int32
+}
+
+// NewOrderBookGraph returns an empty OrderBookGraph.
+func NewOrderBookGraph() *OrderBookGraph {
+	return &OrderBookGraph{
+		edges:       map[string]map[string][]offer{},
+		buyingEdges: map[string][]string{},
+		assets:      map[string]xdr.Asset{},
+	}
+}
+
+// LastLedger returns the ledger sequence the graph was last refreshed at.
+func (g *OrderBookGraph) LastLedger() int32 {
+	g.lock.RLock()
+	defer g.lock.RUnlock()
+	return g.lastLedger
+}
+
+// Refresh rebuilds the graph from the active offers in stellar-core's database,
+// recording `ledger` as the ledger sequence the offers were loaded at.
+func (g *OrderBookGraph) Refresh(q *core.Q, ledger int32) error {
+	var offers []core.Offer
+	err := q.AllOffers(&offers)
+	if err != nil {
+		return errors.Wrap(err, "failed to load offers")
+	}
+
+	return g.Load(offers, ledger)
+}
+
+// Load replaces the contents of the graph with `offers`, recording `ledger` as
+// the ledger sequence the offers were loaded at.
+func (g *OrderBookGraph) Load(offers []core.Offer, ledger int32) error {
+	edges := map[string]map[string][]offer{}
+	assets := map[string]xdr.Asset{}
+
+	for _, row := range offers {
+		selling, err := core.AssetFromDB(
+			row.SellingAssetType,
+			row.SellingAssetCode.String,
+			row.SellingIssuer.String,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "invalid selling asset in offer %d", row.OfferID)
+		}
+
+		buying, err := core.AssetFromDB(
+			row.BuyingAssetType,
+			row.BuyingAssetCode.String,
+			row.BuyingIssuer.String,
+		)
+		if err != nil {
+			return errors.Wrapf(err, "invalid buying asset in offer %d", row.OfferID)
+		}
+
+		sellingKey, buyingKey := selling.String(), buying.String()
+		assets[sellingKey] = selling
+		assets[buyingKey] = buying
+
+		if edges[sellingKey] == nil {
+			edges[sellingKey] = map[string][]offer{}
+		}
+
+		edges[sellingKey][buyingKey] = append(edges[sellingKey][buyingKey], offer{
+			ID:     row.OfferID,
+			Amount: int64(row.Amount),
+			Pricen: int64(row.Pricen),
+			Priced: int64(row.Priced),
+			Price:  row.Price,
+		})
+	}
+
+	buyingEdges := map[string][]string{}
+	for sellingKey, buyingAssets := range edges {
+		for buyingKey := range buyingAssets {
+			buyingEdges[buyingKey] = append(buyingEdges[buyingKey], sellingKey)
+		}
+	}
+	for _, sellingAssets := range buyingEdges {
+		sort.Strings(sellingAssets)
+	}
+
+	// Offers are consumed in the same order as stellar-core would: the best
+	// price first, and the oldest offer first among offers at the same price.
+	for _, buyingAssets := range edges {
+		for _, book := range buyingAssets {
+			sort.Slice(book, func(i, j int) bool {
+				if book[i].Price != book[j].Price {
+					return book[i].Price < book[j].Price
+				}
+				return book[i].ID < book[j].ID
+			})
+		}
+	}
+
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	g.edges = edges
+	g.buyingEdges = buyingEdges
+	g.assets = assets
+	g.lastLedger = ledger
+	return nil
+}
+
+// costToConsumeLiquidity returns the amount of `buying` needed to consume
+// `sellingAmount` of `selling` from the order book. Callers must hold the
+// graph's read lock.
+func (g *OrderBookGraph) costToConsumeLiquidity(
+	selling, buying string,
+	sellingAmount xdr.Int64,
+) (xdr.Int64, error) {
+	// remaining is the units of `selling` that we want to consume
+	remaining := int64(sellingAmount)
+	var buyingAmount int64
+	for _, o := range g.edges[selling][buying] {
+		buyingUnitsExtracted, sellingUnitsExtracted, err := paths.ConvertToBuyingUnits(
+			o.Amount,
+			remaining,
+			o.Pricen,
+			o.Priced,
+		)
+		if err != nil {
+			return 0, err
+		}
+		// overflow check
+		if paths.WillAddOverflow(buyingAmount, buyingUnitsExtracted) {
+			return 0, fmt.Errorf("adding these two values will cause an integer overflow: %d, %d", buyingAmount, buyingUnitsExtracted)
+		}
+		buyingAmount += buyingUnitsExtracted
+		remaining -= sellingUnitsExtracted
+
+		// check if we got all the units we wanted
+		if remaining <= 0 {
+			return xdr.Int64(buyingAmount), nil
+		}
+	}
+	return 0, ErrNotEnough
+}
+
+// amountReceived returns the amount of `selling` received in exchange for
+// exactly `buyingAmount` of `buying`. Callers must hold the graph's read lock.
+func (g *OrderBookGraph) amountReceived(
+	selling, buying string,
+	buyingAmount xdr.Int64,
+) (xdr.Int64, error) {
+	// remaining is the units of `buying` that we want to spend
+	remaining := int64(buyingAmount)
+	var sellingAmount int64
+	for _, o := range g.edges[selling][buying] {
+		sellingUnitsExtracted, buyingUnitsSpent, err := paths.ConvertToSellingUnits(
+			o.Amount,
+			remaining,
+			o.Pricen,
+			o.Priced,
+		)
+		if err != nil {
+			return 0, err
+		}
+		// overflow check
+		if paths.WillAddOverflow(sellingAmount, sellingUnitsExtracted) {
+			return 0, fmt.Errorf("adding these two values will cause an integer overflow: %d, %d", sellingAmount, sellingUnitsExtracted)
+		}
+		sellingAmount += sellingUnitsExtracted
+		remaining -= buyingUnitsSpent
+
+		// check if we spent all the units we wanted
+		if remaining <= 0 {
+			if sellingAmount == 0 {
+				return 0, ErrNotEnough
+			}
+			return xdr.Int64(sellingAmount), nil
+		}
+	}
+	return 0, ErrNotEnough
+}
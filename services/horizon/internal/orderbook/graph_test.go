@@ -0,0 +1,117 @@
+package orderbook
+
+import (
+	"testing"
+
+	"github.com/guregu/null"
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/xdr"
+	"github.com/stretchr/testify/assert"
+)
+
+const issuer = "GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN"
+
+func makeOffer(id int64, selling, buying string, amount xdr.Int64, pricen, priced int32) core.Offer {
+	return core.Offer{
+		SellerID:         issuer,
+		OfferID:          id,
+		SellingAssetType: xdr.AssetTypeAssetTypeCreditAlphanum4,
+		SellingAssetCode: null.StringFrom(selling),
+		SellingIssuer:    null.StringFrom(issuer),
+		BuyingAssetType:  xdr.AssetTypeAssetTypeCreditAlphanum4,
+		BuyingAssetCode:  null.StringFrom(buying),
+		BuyingIssuer:     null.StringFrom(issuer),
+		Amount:           amount,
+		Pricen:           pricen,
+		Priced:           priced,
+		Price:            float64(pricen) / float64(priced),
+	}
+}
+
+func TestOrderBookGraph(t *testing.T) {
+	eur := xdr.MustNewCreditAsset("EUR", issuer).String()
+	usd := xdr.MustNewCreditAsset("USD", issuer).String()
+
+	graph := NewOrderBookGraph()
+	err := graph.Load([]core.Offer{
+		makeOffer(3, "EUR", "USD", 100000000, 1, 1),
+		makeOffer(2, "EUR", "USD", 100000000, 1, 2),
+		makeOffer(1, "EUR", "USD", 100000000, 1, 4),
+	}, 5)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	assert.Equal(t, int32(5), graph.LastLedger())
+	assert.Len(t, graph.assets, 2)
+
+	// offers are sorted by price
+	book := graph.edges[eur][usd]
+	if assert.Len(t, book, 3) {
+		assert.Equal(t, int64(1), book[0].ID)
+		assert.Equal(t, int64(2), book[1].ID)
+		assert.Equal(t, int64(3), book[2].ID)
+	}
+
+	testCases := []struct {
+		scenario    string
+		eur         xdr.Int64
+		wantCostUSD xdr.Int64
+	}{
+		{"first unit", 2, 1},
+		{"first full offer", 100000000, 25000000},
+		{"first full offer + 1", 100000002, 25000001},
+		{"first two full offers", 200000000, 75000000},
+		{"all offers", 300000000, 175000000},
+	}
+
+	for _, kase := range testCases {
+		t.Run(kase.scenario, func(t *testing.T) {
+			cost, err := graph.costToConsumeLiquidity(eur, usd, kase.eur)
+			if assert.NoError(t, err) {
+				assert.Equal(t, kase.wantCostUSD, cost)
+			}
+		})
+	}
+
+	_, err = graph.costToConsumeLiquidity(eur, usd, 300000001)
+	assert.Equal(t, ErrNotEnough, err)
+
+	// the order book is one-way
+	_, err = graph.costToConsumeLiquidity(usd, eur, 1)
+	assert.Equal(t, ErrNotEnough, err)
+
+	assert.Equal(t, []string{eur}, graph.buyingEdges[usd])
+
+	amountCases := []struct {
+		scenario string
+		usd      xdr.Int64
+		wantEUR  xdr.Int64
+	}{
+		{"first unit", 1, 4},
+		{"first full offer", 25000000, 100000000},
+		{"first two full offers", 75000000, 200000000},
+		{"all offers", 175000000, 300000000},
+	}
+
+	for _, kase := range amountCases {
+		t.Run(kase.scenario, func(t *testing.T) {
+			received, err := graph.amountReceived(eur, usd, kase.usd)
+			if assert.NoError(t, err) {
+				assert.Equal(t, kase.wantEUR, received)
+			}
+		})
+	}
+
+	_, err = graph.amountReceived(eur, usd, 175000001)
+	assert.Equal(t, ErrNotEnough, err)
+
+	// reloading replaces the previous state
+	err = graph.Load(nil, 6)
+	if assert.NoError(t, err) {
+		assert.Equal(t, int32(6), graph.LastLedger())
+		assert.Empty(t, graph.edges)
+		assert.Empty(t, graph.buyingEdges)
+		assert.Empty(t, graph.assets)
+	}
+}
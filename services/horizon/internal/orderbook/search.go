@@ -0,0 +1,214 @@
+package orderbook
+
+import (
+	"sort"
+
+	"github.com/cowry-network/go/services/horizon/internal/paths"
+	"github.com/cowry-network/go/xdr"
+)
+
+// pathNode represents a path as a linked list pointing from source to
+// destination. Assets are identified by their string representation, which is
+// how they are keyed in OrderBookGraph.
+type pathNode struct {
+	Asset string
+	Tail  *pathNode
+	// Cost is the amount of Asset needed to send the destination amount of
+	// the query along this path.
+	Cost  xdr.Int64
+	Depth uint
+}
+
+// IsOnPath returns true if a given asset is in the path.
+func (p *pathNode) IsOnPath(asset string) bool {
+	for cur := p; cur != nil; cur = cur.Tail {
+		if cur.Asset == asset {
+			return true
+		}
+	}
+	return false
+}
+
+// Flatten walks the list and returns a slice of asset keys
+func (p *pathNode) Flatten() []string {
+	result := make([]string, 0, p.Depth)
+	for cur := p; cur != nil; cur = cur.Tail {
+		result = append(result, cur.Asset)
+	}
+	return result
+}
+
+// search represents a single query against the order book graph.  It provides
+// a place to store the results of the query, mostly for the purposes of code
+// clarity.
+//
+// The search struct is used as follows:
+//
+// 1.  Create an instance, ensuring the Query, Graph and MaxLength fields are set
+// 2.  Call Init() to populate dependent fields in the struct with their initial values
+// 3.  Call Run() to perform the search.
+//
+// The graph's read lock must be held for the duration of the search.
+type search struct {
+	Query     paths.Query
+	Graph     *OrderBookGraph
+	MaxLength uint
+
+	// Fields below are initialized by a call to Init() after
+	// setting the fields above
+	destination string
+	frontier    []*pathNode
+	targets     map[string]bool
+
+	//This fields below are initialized after the search is run
+	Err     error
+	Results []paths.Path
+}
+
+// Init initialized the search, setting fields on the struct used to
+// hold state needed during the actual search.
+func (s *search) Init() {
+	s.destination = s.Query.DestinationAsset.String()
+	s.frontier = []*pathNode{
+		&pathNode{
+			Asset: s.destination,
+			Cost:  s.Query.DestinationAmount,
+			Depth: 1,
+		},
+	}
+
+	s.targets = map[string]bool{}
+	for _, a := range s.Query.SourceAssets {
+		s.targets[a.String()] = true
+	}
+
+	s.Err = nil
+	s.Results = nil
+}
+
+// Run triggers the search, which will populate the Results and Err
+// field for the search after completion.
+func (s *search) Run() {
+	s.collectResults()
+
+	for depth := uint(2); depth <= s.MaxLength && len(s.frontier) > 0; depth++ {
+		s.extendFrontier(depth)
+		if s.Err != nil {
+			s.Results = nil
+			return
+		}
+
+		s.collectResults()
+	}
+
+	// Return the cheapest paths first, preferring shorter paths when the cost
+	// is the same.
+	sort.SliceStable(s.Results, func(i, j int) bool {
+		if s.Results[i].Cost != s.Results[j].Cost {
+			return s.Results[i].Cost < s.Results[j].Cost
+		}
+		return len(s.Results[i].Path) < len(s.Results[j].Path)
+	})
+}
+
+// extendFrontier replaces the frontier with every path of length `depth` that
+// can be built by prepending an asset to a path in the current frontier. Only
+// the cheapest path is kept for each asset.
+func (s *search) extendFrontier(depth uint) {
+	best := map[string]*pathNode{}
+	var order []string
+
+	for _, p := range s.frontier {
+		// Iterate the connected assets in a stable order so that ties are
+		// always resolved the same way.
+		connected := make([]string, 0, len(s.Graph.edges[p.Asset]))
+		for asset := range s.Graph.edges[p.Asset] {
+			connected = append(connected, asset)
+		}
+		sort.Strings(connected)
+
+		for _, asset := range connected {
+			// We don't want the same asset on the path twice as buying and
+			// then selling the asset will be a bad deal in most cases
+			// (especially A -> B -> A trades).
+			if p.IsOnPath(asset) {
+				continue
+			}
+
+			// If this is the last extension of the path, only source assets
+			// are worth considering.
+			if depth == s.MaxLength && !s.targets[asset] {
+				continue
+			}
+
+			cost, err := s.Graph.costToConsumeLiquidity(p.Asset, asset, p.Cost)
+			if err == ErrNotEnough {
+				continue
+			}
+			if err != nil {
+				s.Err = err
+				return
+			}
+
+			current, found := best[asset]
+			if found && current.Cost <= cost {
+				continue
+			}
+			if !found {
+				order = append(order, asset)
+			}
+
+			best[asset] = &pathNode{
+				Asset: asset,
+				Tail:  p,
+				Cost:  cost,
+				Depth: depth,
+			}
+		}
+	}
+
+	s.frontier = make([]*pathNode, 0, len(order))
+	for _, asset := range order {
+		s.frontier = append(s.frontier, best[asset])
+	}
+}
+
+// collectResults appends every path in the frontier starting at one of the
+// source assets to the results.
+func (s *search) collectResults() {
+	for _, p := range s.frontier {
+		if s.targets[p.Asset] {
+			s.Results = append(s.Results, s.asPath(p))
+		}
+	}
+}
+
+// asPath converts `p` into the paths.Path returned by the finder
+func (s *search) asPath(p *pathNode) paths.Path {
+	keys := p.Flatten()
+
+	result := paths.Path{
+		Source:            s.asset(p.Asset),
+		Destination:       s.Query.DestinationAsset,
+		Cost:              p.Cost,
+		DestinationAmount: s.Query.DestinationAmount,
+	}
+
+	// the path excludes the source and the destination assets
+	if len(keys) > 2 {
+		result.Path = make([]xdr.Asset, 0, len(keys)-2)
+		for _, key := range keys[1 : len(keys)-1] {
+			result.Path = append(result.Path, s.asset(key))
+		}
+	}
+
+	return result
+}
+
+// asset returns the asset identified by `key`
+func (s *search) asset(key string) xdr.Asset {
+	if key == s.destination {
+		return s.Query.DestinationAsset
+	}
+	return s.Graph.assets[key]
+}
@@ -0,0 +1,196 @@
+package orderbook
+
+import (
+	"sort"
+
+	"github.com/cowry-network/go/services/horizon/internal/paths"
+	"github.com/cowry-network/go/xdr"
+)
+
+// sendNode represents a strict send path as a linked list pointing from the
+// last asset reached back to the source asset.
+type sendNode struct {
+	Asset string
+	Prev  *sendNode
+	// Amount is the amount of Asset received after sending the source amount
+	// of the query along this path.
+	Amount xdr.Int64
+	Depth  uint
+}
+
+// IsOnPath returns true if a given asset is in the path.
+func (n *sendNode) IsOnPath(asset string) bool {
+	for cur := n; cur != nil; cur = cur.Prev {
+		if cur.Asset == asset {
+			return true
+		}
+	}
+	return false
+}
+
+// Flatten walks the list and returns a slice of asset keys, from source to
+// destination
+func (n *sendNode) Flatten() []string {
+	result := make([]string, n.Depth)
+	for cur := n; cur != nil; cur = cur.Prev {
+		result[cur.Depth-1] = cur.Asset
+	}
+	return result
+}
+
+// sendSearch represents a single strict send query against the order book
+// graph. It mirrors search, but walks the graph from the source asset towards
+// the destination assets, keeping the path receiving the most for each asset.
+//
+// The graph's read lock must be held for the duration of the search.
+type sendSearch struct {
+	Query     paths.Query
+	Graph     *OrderBookGraph
+	MaxLength uint
+
+	// Fields below are initialized by a call to Init() after
+	// setting the fields above
+	source   string
+	frontier []*sendNode
+	targets  map[string]bool
+
+	//This fields below are initialized after the search is run
+	Err     error
+	Results []paths.Path
+}
+
+// Init initialized the search, setting fields on the struct used to
+// hold state needed during the actual search.
+func (s *sendSearch) Init() {
+	s.source = s.Query.SourceAsset.String()
+	s.frontier = []*sendNode{
+		&sendNode{
+			Asset:  s.source,
+			Amount: s.Query.SourceAmount,
+			Depth:  1,
+		},
+	}
+
+	s.targets = map[string]bool{}
+	for _, a := range s.Query.DestinationAssets {
+		s.targets[a.String()] = true
+	}
+
+	s.Err = nil
+	s.Results = nil
+}
+
+// Run triggers the search, which will populate the Results and Err
+// field for the search after completion.
+func (s *sendSearch) Run() {
+	s.collectResults()
+
+	for depth := uint(2); depth <= s.MaxLength && len(s.frontier) > 0; depth++ {
+		s.extendFrontier(depth)
+		if s.Err != nil {
+			s.Results = nil
+			return
+		}
+
+		s.collectResults()
+	}
+
+	// Return the paths receiving the most first, preferring shorter paths
+	// when the amount is the same.
+	sort.SliceStable(s.Results, func(i, j int) bool {
+		if s.Results[i].DestinationAmount != s.Results[j].DestinationAmount {
+			return s.Results[i].DestinationAmount > s.Results[j].DestinationAmount
+		}
+		return len(s.Results[i].Path) < len(s.Results[j].Path)
+	})
+}
+
+// extendFrontier replaces the frontier with every path of length `depth` that
+// can be built by appending an asset to a path in the current frontier. Only
+// the path receiving the most is kept for each asset.
+func (s *sendSearch) extendFrontier(depth uint) {
+	best := map[string]*sendNode{}
+	var order []string
+
+	for _, n := range s.frontier {
+		for _, asset := range s.Graph.buyingEdges[n.Asset] {
+			// See search.extendFrontier, the same pruning rules apply.
+			if n.IsOnPath(asset) {
+				continue
+			}
+
+			if depth == s.MaxLength && !s.targets[asset] {
+				continue
+			}
+
+			received, err := s.Graph.amountReceived(asset, n.Asset, n.Amount)
+			if err == ErrNotEnough {
+				continue
+			}
+			if err != nil {
+				s.Err = err
+				return
+			}
+
+			current, found := best[asset]
+			if found && current.Amount >= received {
+				continue
+			}
+			if !found {
+				order = append(order, asset)
+			}
+
+			best[asset] = &sendNode{
+				Asset:  asset,
+				Prev:   n,
+				Amount: received,
+				Depth:  depth,
+			}
+		}
+	}
+
+	s.frontier = make([]*sendNode, 0, len(order))
+	for _, asset := range order {
+		s.frontier = append(s.frontier, best[asset])
+	}
+}
+
+// collectResults appends every path in the frontier ending at one of the
+// destination assets to the results.
+func (s *sendSearch) collectResults() {
+	for _, n := range s.frontier {
+		if s.targets[n.Asset] {
+			s.Results = append(s.Results, s.asPath(n))
+		}
+	}
+}
+
+// asPath converts `n` into the paths.Path returned by the finder
+func (s *sendSearch) asPath(n *sendNode) paths.Path {
+	keys := n.Flatten()
+
+	result := paths.Path{
+		Source:            s.Query.SourceAsset,
+		Destination:       s.asset(n.Asset),
+		Cost:              s.Query.SourceAmount,
+		DestinationAmount: n.Amount,
+	}
+
+	// the path excludes the source and the destination assets
+	if len(keys) > 2 {
+		result.Path = make([]xdr.Asset, 0, len(keys)-2)
+		for _, key := range keys[1 : len(keys)-1] {
+			result.Path = append(result.Path, s.asset(key))
+		}
+	}
+
+	return result
+}
+
+// asset returns the asset identified by `key`
+func (s *sendSearch) asset(key string) xdr.Asset {
+	if key == s.source {
+		return s.Query.SourceAsset
+	}
+	return s.Graph.assets[key]
+}
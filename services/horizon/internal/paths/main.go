@@ -4,25 +4,43 @@ import (
 	"github.com/cowry-network/go/xdr"
 )
 
-// Query is a query for paths
+// Query is a query for paths. A query is either a "strict receive" query,
+// looking for paths delivering exactly DestinationAmount of DestinationAsset
+// starting from any of SourceAssets (see Finder.Find), or a "strict send"
+// query, looking for paths sending exactly SourceAmount of SourceAsset to any
+// of DestinationAssets (see Finder.FindFixedPaths).
 type Query struct {
 	DestinationAddress string
 	DestinationAsset   xdr.Asset
 	DestinationAmount  xdr.Int64
 	SourceAssets       []xdr.Asset
+
+	// SourceAsset, SourceAmount and DestinationAssets are only used by strict
+	// send queries.
+	SourceAsset       xdr.Asset
+	SourceAmount      xdr.Int64
+	DestinationAssets []xdr.Asset
 }
 
-// Path is the result returned by a path finder and is tied to the DestinationAmount used in the input query
+// Path is the result returned by a path finder and is tied to the amount
+// (DestinationAmount or SourceAmount) used in the input query
 type Path struct {
 	Path        []xdr.Asset
 	Source      xdr.Asset
 	Destination xdr.Asset
 	// represents the source assets to be used as `sendMax` field for a `PathPaymentOp` struct
 	Cost xdr.Int64
+	// represents the amount of the destination asset received at the end of
+	// the path, the maximum amount receivable in case of strict send queries
+	DestinationAmount xdr.Int64
 }
 
 // Finder finds paths.
 type Finder interface {
 	// Returns path for a Query of a maximum length `maxLength`
 	Find(q Query, maxLength uint) ([]Path, error)
+	// Returns strict send paths for a Query of a maximum length `maxLength`:
+	// paths sending exactly `q.SourceAmount` of `q.SourceAsset` and the
+	// amount of each destination asset that would be received.
+	FindFixedPaths(q Query, maxLength uint) ([]Path, error)
 }
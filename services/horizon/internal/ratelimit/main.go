@@ -0,0 +1,182 @@
+// Package ratelimit contains the configuration of the rate limiting subsystem
+// for horizon: the per-route rules and the allowlist of clients that are not
+// rate limited.  The limiters themselves are provided by throttled.
+package ratelimit
+
+import (
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/cowry-network/go/support/errors"
+	"github.com/throttled/throttled"
+)
+
+// VaryBy identifies the client a request is counted against, so that each
+// client is limited by its own quota.
+type VaryBy interface {
+	Key(r *http.Request) string
+}
+
+// Rule applies a quota to the requests made to the routes beneath a path,
+// optionally restricted to a single http method.
+type Rule struct {
+	Method string
+	Path   string
+	Quota  throttled.RateQuota
+}
+
+// String returns the rule in the format accepted by ParseRules, without its
+// quota.
+func (rule Rule) String() string {
+	if rule.Method == "" {
+		return rule.Path
+	}
+	return rule.Method + " " + rule.Path
+}
+
+// Matches returns true if the rule applies to `r`.
+func (rule Rule) Matches(r *http.Request) bool {
+	if rule.Method != "" && rule.Method != r.Method {
+		return false
+	}
+
+	path := strings.TrimSuffix(r.URL.Path, "/")
+	return path == rule.Path || strings.HasPrefix(path, rule.Path+"/")
+}
+
+// ParseRules parses a comma separated list of rules such as `POST
+// /transactions=360,/paths=720:20`.  Each rule is an optional http method and a
+// path, followed by the number of requests allowed per hour and optionally by
+// the maximum burst size, which defaults to a tenth of the hourly quota.
+func ParseRules(s string) ([]Rule, error) {
+	var rules []Rule
+
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		rule, err := parseRule(field)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid rate limit rule %q", field)
+		}
+		rules = append(rules, rule)
+	}
+
+	return rules, nil
+}
+
+func parseRule(s string) (Rule, error) {
+	var rule Rule
+
+	parts := strings.SplitN(s, "=", 2)
+	if len(parts) != 2 {
+		return rule, errors.New("missing quota")
+	}
+
+	route := strings.Fields(parts[0])
+	switch len(route) {
+	case 1:
+		rule.Path = route[0]
+	case 2:
+		rule.Method = strings.ToUpper(route[0])
+		rule.Path = route[1]
+	default:
+		return rule, errors.New("invalid route")
+	}
+
+	if !strings.HasPrefix(rule.Path, "/") {
+		return rule, errors.New("path must start with /")
+	}
+	if rule.Path != "/" {
+		rule.Path = strings.TrimSuffix(rule.Path, "/")
+	}
+
+	quota := strings.SplitN(strings.TrimSpace(parts[1]), ":", 2)
+	perHour, err := strconv.Atoi(quota[0])
+	if err != nil || perHour <= 0 {
+		return rule, errors.New("requests per hour must be a positive integer")
+	}
+	rule.Quota = throttled.RateQuota{
+		MaxRate:  throttled.PerHour(perHour),
+		MaxBurst: perHour / 10,
+	}
+
+	if len(quota) == 2 {
+		burst, err := strconv.Atoi(quota[1])
+		if err != nil || burst < 0 {
+			return rule, errors.New("burst must be a non-negative integer")
+		}
+		rule.Quota.MaxBurst = burst
+	}
+
+	return rule, nil
+}
+
+// Allowlist is a set of ip addresses, networks and api keys whose requests are
+// not rate limited.
+type Allowlist struct {
+	networks []*net.IPNet
+	keys     map[string]bool
+}
+
+// NewAllowlist builds an allowlist from a list of entries.  Entries that parse
+// as an ip address or as a network in CIDR notation allow the clients
+// connecting from them, any other entry is an api key.
+func NewAllowlist(entries []string) *Allowlist {
+	list := &Allowlist{keys: map[string]bool{}}
+
+	for _, entry := range entries {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+
+		if _, network, err := net.ParseCIDR(entry); err == nil {
+			list.networks = append(list.networks, network)
+			continue
+		}
+
+		if ip := net.ParseIP(entry); ip != nil {
+			bits := 8 * net.IPv6len
+			if ip.To4() != nil {
+				ip = ip.To4()
+				bits = 8 * net.IPv4len
+			}
+			list.networks = append(list.networks, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+
+		list.keys[entry] = true
+	}
+
+	return list
+}
+
+// Allows returns true if a client connecting from `ip` or presenting `key` is
+// allowed to bypass rate limits.
+func (list *Allowlist) Allows(ip, key string) bool {
+	if list == nil {
+		return false
+	}
+
+	if key != "" && list.keys[key] {
+		return true
+	}
+
+	parsed := net.ParseIP(strings.Trim(ip, "[]"))
+	if parsed == nil {
+		return false
+	}
+
+	for _, network := range list.networks {
+		if network.Contains(parsed) {
+			return true
+		}
+	}
+
+	return false
+}
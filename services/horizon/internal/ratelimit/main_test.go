@@ -0,0 +1,66 @@
+package ratelimit
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled"
+)
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("POST /transactions=360, /paths/=720:20,get /ledgers=5")
+	require.NoError(t, err)
+	assert.Equal(t, []Rule{
+		{Method: "POST", Path: "/transactions", Quota: throttled.RateQuota{MaxRate: throttled.PerHour(360), MaxBurst: 36}},
+		{Path: "/paths", Quota: throttled.RateQuota{MaxRate: throttled.PerHour(720), MaxBurst: 20}},
+		{Method: "GET", Path: "/ledgers", Quota: throttled.RateQuota{MaxRate: throttled.PerHour(5), MaxBurst: 0}},
+	}, rules)
+
+	rules, err = ParseRules("")
+	require.NoError(t, err)
+	assert.Empty(t, rules)
+
+	for _, invalid := range []string{
+		"/paths",
+		"paths=10",
+		"/paths=0",
+		"/paths=ten",
+		"/paths=10:-1",
+		"POST /transactions extra=10",
+	} {
+		_, err = ParseRules(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestRuleMatches(t *testing.T) {
+	rule := Rule{Method: "POST", Path: "/transactions"}
+	assert.True(t, rule.Matches(httptest.NewRequest("POST", "/transactions", nil)))
+	assert.True(t, rule.Matches(httptest.NewRequest("POST", "/transactions/", nil)))
+	assert.False(t, rule.Matches(httptest.NewRequest("GET", "/transactions", nil)))
+	assert.False(t, rule.Matches(httptest.NewRequest("POST", "/transactionsfoo", nil)))
+	assert.Equal(t, "POST /transactions", rule.String())
+
+	rule = Rule{Path: "/paths"}
+	assert.True(t, rule.Matches(httptest.NewRequest("GET", "/paths?destination_account=foo", nil)))
+	assert.True(t, rule.Matches(httptest.NewRequest("GET", "/paths/strict-send", nil)))
+	assert.False(t, rule.Matches(httptest.NewRequest("GET", "/accounts", nil)))
+	assert.Equal(t, "/paths", rule.String())
+}
+
+func TestAllowlist(t *testing.T) {
+	list := NewAllowlist([]string{"127.0.0.2", " 10.0.0.0/8", "::1", "s3cr3t", ""})
+
+	assert.True(t, list.Allows("127.0.0.2", ""))
+	assert.True(t, list.Allows("10.20.30.40", ""))
+	assert.True(t, list.Allows("[::1]", ""))
+	assert.True(t, list.Allows("1.2.3.4", "s3cr3t"))
+	assert.False(t, list.Allows("127.0.0.1", ""))
+	assert.False(t, list.Allows("11.0.0.1", "guess"))
+	assert.False(t, list.Allows("", ""))
+
+	var empty *Allowlist
+	assert.False(t, empty.Allows("127.0.0.2", "s3cr3t"))
+}
@@ -0,0 +1,19 @@
+package ratelimit
+
+import (
+	"github.com/gomodule/redigo/redis"
+	"github.com/throttled/throttled"
+	"github.com/throttled/throttled/store/memstore"
+	"github.com/throttled/throttled/store/redigostore"
+)
+
+// NewStore returns the store keeping the state of the rate limiters.  When
+// `pool` is set, the state is kept in redis with every key prefixed by
+// `prefix`, so that all the horizon servers using it share the same quotas.
+// Otherwise it is kept in memory, holding at most `maxKeys` keys.
+func NewStore(pool *redis.Pool, prefix string, maxKeys int) (throttled.GCRAStore, error) {
+	if pool != nil {
+		return redigostore.New(pool, prefix, 0)
+	}
+	return memstore.New(maxKeys)
+}
@@ -0,0 +1,71 @@
+package ratelimit
+
+import (
+	"testing"
+
+	"github.com/alicebob/miniredis"
+	"github.com/gomodule/redigo/redis"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/throttled/throttled"
+)
+
+// Limiters sharing a redis store share their quotas.
+func TestNewStore_Redis(t *testing.T) {
+	server, err := miniredis.Run()
+	require.NoError(t, err)
+	defer server.Close()
+
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) { return redis.Dial("tcp", server.Addr()) },
+	}
+	quota := throttled.RateQuota{MaxRate: throttled.PerHour(10), MaxBurst: 1}
+
+	newLimiter := func() *throttled.GCRARateLimiter {
+		store, err := NewStore(pool, "horizon:", 0)
+		require.NoError(t, err)
+		limiter, err := throttled.NewGCRARateLimiter(store, quota)
+		require.NoError(t, err)
+		return limiter
+	}
+	first, second := newLimiter(), newLimiter()
+
+	limited, _, err := first.RateLimit("key", 1)
+	require.NoError(t, err)
+	assert.False(t, limited)
+	limited, _, err = second.RateLimit("key", 1)
+	require.NoError(t, err)
+	assert.False(t, limited)
+
+	limited, _, err = first.RateLimit("key", 1)
+	require.NoError(t, err)
+	assert.True(t, limited)
+	limited, _, err = second.RateLimit("key", 1)
+	require.NoError(t, err)
+	assert.True(t, limited)
+
+	// the state is kept under the prefixed key
+	assert.True(t, server.Exists("horizon:key"))
+	limited, _, err = first.RateLimit("other", 1)
+	require.NoError(t, err)
+	assert.False(t, limited)
+}
+
+// Without redis, each store keeps a state of its own.
+func TestNewStore_Memory(t *testing.T) {
+	quota := throttled.RateQuota{MaxRate: throttled.PerHour(10), MaxBurst: 0}
+
+	for i := 0; i < 2; i++ {
+		store, err := NewStore(nil, "", 10)
+		require.NoError(t, err)
+		limiter, err := throttled.NewGCRARateLimiter(store, quota)
+		require.NoError(t, err)
+
+		limited, _, err := limiter.RateLimit("key", 1)
+		require.NoError(t, err)
+		assert.False(t, limited)
+		limited, _, err = limiter.RateLimit("key", 1)
+		require.NoError(t, err)
+		assert.True(t, limited)
+	}
+}
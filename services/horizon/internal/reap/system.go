@@ -3,6 +3,7 @@ package reap
 import (
 	"time"
 
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
 	"github.com/cowry-network/go/services/horizon/internal/errors"
 	"github.com/cowry-network/go/services/horizon/internal/ledger"
 	"github.com/cowry-network/go/services/horizon/internal/toid"
@@ -63,6 +64,9 @@ func (r *System) runOnce() {
 	}
 }
 
+// clearBefore deletes the history of the ledgers before `seq`.  Trades, and
+// the trade rollups aggregating them, are kept, so that /trade_aggregations
+// covers the same periods whichever table it aggregates.
 func (r *System) clearBefore(seq int32) error {
 	log.WithField("new_elder", seq).Info("reaper: clearing")
 
@@ -73,6 +77,13 @@ func (r *System) clearBefore(seq int32) error {
 	if err != nil {
 		return err
 	}
+	// the latest change to each ledger entry is kept, as the state of the
+	// entry at the new elder ledger
+	q := history.Q{Session: r.HorizonDB}
+	err = q.ReapLedgerEntryChanges(end)
+	if err != nil {
+		return err
+	}
 	err = clear(0, end, "history_operation_participants", "history_operation_id")
 	if err != nil {
 		return err
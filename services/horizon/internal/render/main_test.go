@@ -23,8 +23,11 @@ func TestNegotiate(t *testing.T) {
 		// Defaults to HAL
 		{"text/event-stream;q=0.5,application/hal+json", MimeHal},
 		{"", MimeHal},
+		// Prometheus scrapers
+		{"text/plain", MimePrometheus},
+		{"application/openmetrics-text; version=0.0.1,text/plain;version=0.0.4;q=0.5,*/*;q=0.1", MimePrometheus},
 		// Returns empty string for invalid type
-		{"text/plain", ""},
+		{"image/png", ""},
 	}
 	for _, tc := range testCases {
 		t.Run("", func(t *testing.T) {
@@ -12,4 +12,7 @@ const (
 	MimeProblem = "application/problem+json"
 	//MimeRaw is the mime type for "application/octet-stream"
 	MimeRaw = "application/octet-stream"
+	//MimePrometheus is the mime type for "text/plain", used by the Prometheus
+	//text exposition format
+	MimePrometheus = "text/plain"
 )
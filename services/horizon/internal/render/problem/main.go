@@ -29,6 +29,17 @@ var (
 			"headers.",
 	}
 
+	// IngestionDisabled is a well-known problem type.  Use it as a shortcut
+	// in your actions.
+	IngestionDisabled = problem.P{
+		Type:   "ingestion_disabled",
+		Title:  "Ingestion Disabled",
+		Status: http.StatusConflict,
+		Detail: "This horizon server does not run the ingestion subsystem, so " +
+			"ingestion cannot be controlled.  Start horizon with --ingest to " +
+			"enable it.",
+	}
+
 	// NotImplemented is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	NotImplemented = problem.P{
@@ -92,6 +103,18 @@ var (
 			"this horizon instance.",
 	}
 
+	// MissingLedgerEntryChanges is a well-known problem type.  Use it as a
+	// shortcut in your actions.
+	MissingLedgerEntryChanges = problem.P{
+		Type:   "missing_ledger_entry_changes",
+		Title:  "Ledger Entry Changes Not Recorded",
+		Status: http.StatusServiceUnavailable,
+		Detail: "This request depends on the changes made to the ledger by " +
+			"ledgers this horizon instance ingested without the metadata of " +
+			"their transactions, e.g. from a history archive.  The changes will " +
+			"be available once these ledgers are reingested from stellar-core.",
+	}
+
 	// StaleHistory is a well-known problem type.  Use it as a shortcut
 	// in your actions.
 	StaleHistory = problem.P{
@@ -0,0 +1,123 @@
+// Package prometheus renders the metrics of a go-metrics registry in the
+// Prometheus text exposition format (version 0.0.4), so that they can be
+// scraped by a Prometheus server.
+package prometheus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"time"
+
+	metrics "github.com/rcrowley/go-metrics"
+)
+
+// ContentType is the value of the Content-Type header of rendered responses.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// Quantiles are the quantiles reported for timers and histograms.
+var Quantiles = []float64{0.5, 0.75, 0.95, 0.99, 0.999}
+
+// Render writes the metrics in `registry` to `w`, prefixing their names with
+// `namespace`.
+func Render(w http.ResponseWriter, namespace string, registry metrics.Registry) error {
+	w.Header().Set("Content-Type", ContentType)
+	w.WriteHeader(http.StatusOK)
+	return Write(w, namespace, registry)
+}
+
+// Write writes the metrics in `registry` to `out`, prefixing their names with
+// `namespace`.  Metrics are converted as follows, with dots in their names
+// replaced by underscores:
+//
+//   - counters and gauges keep their type
+//   - meters become counters named `<name>_total`
+//   - timers become summaries named `<name>_seconds`
+//   - histograms become summaries
+//
+// Healthchecks are not exported.
+func Write(out io.Writer, namespace string, registry metrics.Registry) error {
+	all := map[string]interface{}{}
+	registry.Each(func(name string, metric interface{}) {
+		all[name] = metric
+	})
+
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	w := bufio.NewWriter(out)
+	for _, name := range names {
+		full := metricName(namespace, name)
+
+		switch metric := all[name].(type) {
+		case metrics.Counter:
+			writeHeader(w, full, "counter")
+			writeSample(w, full, "", float64(metric.Count()))
+		case metrics.Gauge:
+			writeHeader(w, full, "gauge")
+			writeSample(w, full, "", float64(metric.Value()))
+		case metrics.GaugeFloat64:
+			writeHeader(w, full, "gauge")
+			writeSample(w, full, "", metric.Value())
+		case metrics.Meter:
+			full += "_total"
+			writeHeader(w, full, "counter")
+			writeSample(w, full, "", float64(metric.Count()))
+		case metrics.Timer:
+			t := metric.Snapshot()
+			full += "_seconds"
+			writeHeader(w, full, "summary")
+			for i, value := range t.Percentiles(Quantiles) {
+				writeSample(w, full, quantileLabel(Quantiles[i]), seconds(value))
+			}
+			writeSample(w, full+"_sum", "", seconds(float64(t.Sum())))
+			writeSample(w, full+"_count", "", float64(t.Count()))
+		case metrics.Histogram:
+			h := metric.Snapshot()
+			writeHeader(w, full, "summary")
+			for i, value := range h.Percentiles(Quantiles) {
+				writeSample(w, full, quantileLabel(Quantiles[i]), value)
+			}
+			writeSample(w, full+"_sum", "", float64(h.Sum()))
+			writeSample(w, full+"_count", "", float64(h.Count()))
+		}
+	}
+
+	return w.Flush()
+}
+
+var invalidNameChars = regexp.MustCompile(`[^a-zA-Z0-9_:]`)
+
+// metricName converts a go-metrics name such as `requests.total` into a valid
+// Prometheus metric name such as `horizon_requests_total`.
+func metricName(namespace, name string) string {
+	if namespace != "" {
+		name = namespace + "_" + name
+	}
+	return invalidNameChars.ReplaceAllString(name, "_")
+}
+
+func quantileLabel(q float64) string {
+	return fmt.Sprintf(`{quantile="%s"}`, strconv.FormatFloat(q, 'g', -1, 64))
+}
+
+// seconds converts a duration in nanoseconds, as recorded by go-metrics
+// timers, into seconds.
+func seconds(ns float64) float64 {
+	return ns / float64(time.Second)
+}
+
+func writeHeader(w io.Writer, name, typ string) {
+	fmt.Fprintf(w, "# TYPE %s %s\n", name, typ)
+}
+
+func writeSample(w io.Writer, name, labels string, value float64) {
+	fmt.Fprintf(w, "%s%s %s\n", name, labels, strconv.FormatFloat(value, 'g', -1, 64))
+}
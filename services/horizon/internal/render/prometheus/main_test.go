@@ -0,0 +1,69 @@
+package prometheus
+
+import (
+	"bytes"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	metrics "github.com/rcrowley/go-metrics"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWrite(t *testing.T) {
+	registry := metrics.NewRegistry()
+
+	counter := metrics.NewCounter()
+	counter.Inc(3)
+	registry.Register("logging.error", counter)
+
+	gauge := metrics.NewGauge()
+	gauge.Update(42)
+	registry.Register("history.latest_ledger", gauge)
+
+	meter := metrics.NewMeter()
+	meter.Mark(2)
+	registry.Register("requests.succeeded", meter)
+
+	timer := metrics.NewTimer()
+	timer.Update(2 * time.Second)
+	registry.Register("requests.total", timer)
+
+	registry.Register("check", metrics.NewHealthcheck(func(metrics.Healthcheck) {}))
+
+	var out bytes.Buffer
+	err := Write(&out, "horizon", registry)
+	require.NoError(t, err)
+
+	assert.Equal(t, `# TYPE horizon_history_latest_ledger gauge
+horizon_history_latest_ledger 42
+# TYPE horizon_logging_error counter
+horizon_logging_error 3
+# TYPE horizon_requests_succeeded_total counter
+horizon_requests_succeeded_total 2
+# TYPE horizon_requests_total_seconds summary
+horizon_requests_total_seconds{quantile="0.5"} 2
+horizon_requests_total_seconds{quantile="0.75"} 2
+horizon_requests_total_seconds{quantile="0.95"} 2
+horizon_requests_total_seconds{quantile="0.99"} 2
+horizon_requests_total_seconds{quantile="0.999"} 2
+horizon_requests_total_seconds_sum 2
+horizon_requests_total_seconds_count 1
+`, out.String())
+}
+
+func TestRender(t *testing.T) {
+	registry := metrics.NewRegistry()
+	gauge := metrics.NewGauge()
+	gauge.Update(7)
+	registry.Register("goroutines", gauge)
+
+	w := httptest.NewRecorder()
+	err := Render(w, "", registry)
+	require.NoError(t, err)
+
+	assert.Equal(t, 200, w.Code)
+	assert.Equal(t, ContentType, w.Header().Get("Content-Type"))
+	assert.Equal(t, "# TYPE goroutines gauge\ngoroutines 7\n", w.Body.String())
+}
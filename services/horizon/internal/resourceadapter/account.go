@@ -22,6 +22,7 @@ func PopulateAccount(
 	ha history.Account,
 ) (err error) {
 	dest.ID = ca.Accountid
+	dest.PT = ca.Accountid
 	dest.AccountID = ca.Accountid
 	dest.Sequence = ca.Seqnum
 	dest.SubentryCount = ca.Numsubentries
@@ -70,6 +71,7 @@ func PopulateAccount(
 	dest.Links.Effects = lb.PagedLink(self, "effects")
 	dest.Links.Offers = lb.PagedLink(self, "offers")
 	dest.Links.Trades = lb.PagedLink(self, "trades")
+	dest.Links.Changes = lb.PagedLink(self, "changes")
 	dest.Links.Data = lb.Link(self, "data/{key}")
 	dest.Links.Data.PopulateTemplated()
 	return
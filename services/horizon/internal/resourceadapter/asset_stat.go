@@ -6,6 +6,8 @@ import (
 	"github.com/cowry-network/go/amount"
 	. "github.com/cowry-network/go/protocols/horizon"
 	"github.com/cowry-network/go/services/horizon/internal/db2/assets"
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
 	"github.com/cowry-network/go/support/errors"
 	"github.com/cowry-network/go/support/render/hal"
 	"github.com/cowry-network/go/xdr"
@@ -26,13 +28,66 @@ func PopulateAssetStat(
 		return errors.Wrap(err, "Invalid amount in PopulateAssetStat")
 	}
 	res.NumAccounts = row.NumAccounts
+	res.NumUnauthorizedAccounts = row.NumUnauthorizedAccounts
 	res.Flags = AccountFlags{
 		(row.Flags & int8(xdr.AccountFlagsAuthRequiredFlag)) != 0,
 		(row.Flags & int8(xdr.AccountFlagsAuthRevocableFlag)) != 0,
 		(row.Flags & int8(xdr.AccountFlagsAuthImmutableFlag)) != 0,
 	}
+	res.BidDepth, err = amount.IntStringToAmount(row.BidDepth)
+	if err != nil {
+		return errors.Wrap(err, "Invalid bid depth in PopulateAssetStat")
+	}
+	res.AskDepth, err = amount.IntStringToAmount(row.AskDepth)
+	if err != nil {
+		return errors.Wrap(err, "Invalid ask depth in PopulateAssetStat")
+	}
 	res.PT = row.SortKey
 
 	res.Links.Toml = hal.NewLink(row.Toml)
 	return
 }
+
+// PopulateAssetStatDetails fills out the details of a single asset along with
+// its largest holders, its daily supply and its trades of the last 24 hours
+func PopulateAssetStatDetails(
+	ctx context.Context,
+	res *AssetStatDetails,
+	row assets.AssetStatsR,
+	holders []core.Trustline,
+	snapshots []history.AssetStatSnapshot,
+	tradeCount int32,
+	volume string,
+) (err error) {
+	err = PopulateAssetStat(ctx, &res.AssetStat, row)
+	if err != nil {
+		return
+	}
+
+	res.TopHolders = make([]AssetHolder, len(holders))
+	for i, holder := range holders {
+		res.TopHolders[i] = AssetHolder{
+			Account:    holder.Accountid,
+			Balance:    amount.String(holder.Balance),
+			Authorized: holder.Flags&int32(xdr.TrustLineFlagsAuthorizedFlag) != 0,
+		}
+	}
+
+	res.SupplyHistory = make([]AssetSupply, len(snapshots))
+	for i, snapshot := range snapshots {
+		res.SupplyHistory[i].Day = snapshot.Day.Format("2006-01-02")
+		res.SupplyHistory[i].NumAccounts = snapshot.NumAccounts
+		res.SupplyHistory[i].Amount, err = amount.IntStringToAmount(snapshot.Amount)
+		if err != nil {
+			return errors.Wrap(err, "Invalid amount in PopulateAssetStatDetails")
+		}
+	}
+
+	res.TradeCount24h = tradeCount
+	res.Volume24h, err = amount.IntStringToAmount(volume)
+	if err != nil {
+		return errors.Wrap(err, "Invalid volume in PopulateAssetStatDetails")
+	}
+
+	return
+}
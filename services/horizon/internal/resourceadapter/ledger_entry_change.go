@@ -0,0 +1,64 @@
+package resourceadapter
+
+import (
+	"context"
+
+	. "github.com/cowry-network/go/protocols/horizon"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/httpx"
+	"github.com/cowry-network/go/services/horizon/internal/toid"
+	"github.com/cowry-network/go/support/render/hal"
+	"github.com/cowry-network/go/xdr"
+)
+
+// LedgerEntryTypeNames maps ledger entry types to their names in resources.
+var LedgerEntryTypeNames = map[xdr.LedgerEntryType]string{
+	xdr.LedgerEntryTypeAccount:   "account",
+	xdr.LedgerEntryTypeTrustline: "trustline",
+	xdr.LedgerEntryTypeOffer:     "offer",
+	xdr.LedgerEntryTypeData:      "data",
+}
+
+// LedgerEntryChangeTypeNames maps ledger entry change types to their names in
+// resources.
+var LedgerEntryChangeTypeNames = map[xdr.LedgerEntryChangeType]string{
+	xdr.LedgerEntryChangeTypeLedgerEntryCreated: "created",
+	xdr.LedgerEntryChangeTypeLedgerEntryUpdated: "updated",
+	xdr.LedgerEntryChangeTypeLedgerEntryRemoved: "removed",
+}
+
+// PopulateLedgerEntryChange fills out the details of a ledger entry change
+// using a row from the history_ledger_entry_changes table.
+func PopulateLedgerEntryChange(
+	ctx context.Context,
+	dest *LedgerEntryChange,
+	row history.LedgerEntryChange,
+	ledger history.Ledger,
+) {
+	var ok bool
+
+	dest.ID = row.ID()
+	dest.PT = row.PagingToken()
+	dest.Account = row.Account
+	dest.LedgerCloseTime = ledger.ClosedAt
+	dest.EntryBefore = row.EntryBefore.String
+	dest.EntryAfter = row.EntryAfter.String
+
+	dest.EntryType, ok = LedgerEntryTypeNames[row.EntryType]
+	if !ok {
+		dest.EntryType = "unknown"
+	}
+
+	dest.ChangeType, ok = LedgerEntryChangeTypeNames[row.ChangeType]
+	if !ok {
+		dest.ChangeType = "unknown"
+	}
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	// fee and sequence number changes are not made by an operation
+	if toid.Parse(row.HistoryOperationID).OperationOrder != 0 {
+		dest.Links.Operation = lb.Linkf("/operations/%d", row.HistoryOperationID)
+	}
+	dest.Links.Succeeds = lb.Linkf("/accounts/%s/changes?order=desc&cursor=%s", row.Account, dest.PT)
+	dest.Links.Precedes = lb.Linkf("/accounts/%s/changes?order=asc&cursor=%s", row.Account, dest.PT)
+}
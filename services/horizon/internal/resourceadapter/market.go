@@ -0,0 +1,98 @@
+package resourceadapter
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/cowry-network/go/amount"
+	"github.com/cowry-network/go/price"
+	. "github.com/cowry-network/go/protocols/horizon"
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/httpx"
+	"github.com/cowry-network/go/support/render/hal"
+	"github.com/cowry-network/go/xdr"
+)
+
+// PopulateMarket fills out the details of a market using a row from the
+// history_markets table, summarizing its trades, and the summary of its order
+// book in stellar-core.
+func PopulateMarket(
+	ctx context.Context,
+	dest *Market,
+	row history.MarketSummary,
+	orderBook core.OrderBookSummary,
+) {
+	dest.PT = row.PagingToken()
+	dest.BaseAssetType = row.BaseAssetType
+	dest.BaseAssetCode = row.BaseAssetCode
+	dest.BaseAssetIssuer = row.BaseAssetIssuer
+	dest.CounterAssetType = row.CounterAssetType
+	dest.CounterAssetCode = row.CounterAssetCode
+	dest.CounterAssetIssuer = row.CounterAssetIssuer
+	dest.TradeCount = row.TradeCount
+	dest.BaseVolume = amount.StringFromInt64(row.BaseVolume)
+	dest.CounterVolume = amount.StringFromInt64(row.CounterVolume)
+	dest.Open = row.Open.String()
+	dest.OpenR = row.Open
+	dest.High = row.High.String()
+	dest.HighR = row.High
+	dest.Low = row.Low.String()
+	dest.LowR = row.Low
+	dest.Close = row.Close.String()
+	dest.CloseR = row.Close
+	dest.Change = price.StringFromFloat64(priceChange(row.Open, row.Close))
+	dest.LastTradeAt = row.LastTradeAt
+
+	dest.BestBid = nil
+	if bids := orderBook.Bids(); len(bids) > 0 {
+		dest.BestBid = bestPriceLevel(bids[0])
+	}
+	dest.BestAsk = nil
+	if asks := orderBook.Asks(); len(asks) > 0 {
+		dest.BestAsk = bestPriceLevel(asks[0])
+	}
+
+	populateMarketLinks(ctx, dest)
+}
+
+// priceChange returns the relative change from the open price to the
+// close price.
+func priceChange(openPrice, closePrice xdr.Price) float64 {
+	o := float64(openPrice.N) / float64(openPrice.D)
+	c := float64(closePrice.N) / float64(closePrice.D)
+	return (c - o) / o
+}
+
+func bestPriceLevel(row core.OrderBookSummaryPriceLevel) *PriceLevel {
+	return &PriceLevel{
+		Price:  row.PriceAsString(),
+		Amount: row.AmountAsString(),
+		PriceR: Price{
+			N: row.Pricen,
+			D: row.Priced,
+		},
+	}
+}
+
+func populateMarketLinks(ctx context.Context, dest *Market) {
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+
+	trades := url.Values{}
+	addAssetParams(trades, "base_", dest.BaseAssetType, dest.BaseAssetCode, dest.BaseAssetIssuer)
+	addAssetParams(trades, "counter_", dest.CounterAssetType, dest.CounterAssetCode, dest.CounterAssetIssuer)
+	dest.Links.Trades = lb.Linkf("/trades?%s", trades.Encode())
+
+	orderBook := url.Values{}
+	addAssetParams(orderBook, "selling_", dest.BaseAssetType, dest.BaseAssetCode, dest.BaseAssetIssuer)
+	addAssetParams(orderBook, "buying_", dest.CounterAssetType, dest.CounterAssetCode, dest.CounterAssetIssuer)
+	dest.Links.OrderBook = lb.Linkf("/order_book?%s", orderBook.Encode())
+}
+
+func addAssetParams(q url.Values, prefix, typ, code, issuer string) {
+	q.Set(prefix+"asset_type", typ)
+	if typ != "native" {
+		q.Set(prefix+"asset_code", code)
+		q.Set(prefix+"asset_issuer", issuer)
+	}
+}
@@ -4,7 +4,9 @@ import (
 	"context"
 
 	. "github.com/cowry-network/go/protocols/horizon"
+	"github.com/cowry-network/go/services/horizon/internal/txsim"
 	"github.com/cowry-network/go/services/horizon/internal/txsub"
+	"github.com/cowry-network/go/xdr"
 )
 
 // Populate fills out the details
@@ -25,3 +27,27 @@ func PopulateTransactionResultCodes(ctx context.Context,
 
 	return
 }
+
+// PopulateTransactionSimulation fills out the details
+func PopulateTransactionSimulation(ctx context.Context,
+	dest *TransactionSimulation,
+	result txsim.Result,
+) (err error) {
+
+	dest.Hash = result.Hash
+	dest.Successful = result.Successful()
+	dest.FeeCharged = int64(result.Result.FeeCharged)
+
+	dest.Result, err = xdr.MarshalBase64(result.Result)
+	if err != nil {
+		return
+	}
+
+	dest.ResultCodes.TransactionCode, err = result.TransactionResultCode()
+	if err != nil {
+		return
+	}
+
+	dest.ResultCodes.OperationCodes, err = result.OperationResultCodes()
+	return
+}
@@ -21,3 +21,32 @@ func PopulateTransactionSuccess(ctx context.Context, dest *TransactionSuccess, r
 	dest.Links.Transaction = lb.Link("/transactions", result.Hash)
 	return
 }
+
+// PopulateTransactionStatus fills out the details
+func PopulateTransactionStatus(ctx context.Context, dest *TransactionStatus, status txsub.TransactionStatus, result txsub.Result) {
+	dest.Hash = result.Hash
+	dest.Status = string(status)
+
+	if status == txsub.StatusSuccess || status == txsub.StatusFailed {
+		dest.Ledger = result.LedgerSequence
+		dest.Env = result.EnvelopeXDR
+		dest.Result = result.ResultXDR
+		dest.Meta = result.ResultMetaXDR
+	}
+
+	lb := hal.LinkBuilder{httpx.BaseURL(ctx)}
+	dest.Links.Transaction = lb.Link("/transactions", result.Hash)
+	dest.Links.Status = lb.Link("/transactions", result.Hash, "status")
+	return
+}
+
+// PopulateTransactionFeeReport fills out the details
+func PopulateTransactionFeeReport(ctx context.Context, dest *TransactionFeeReport, report txsub.FeeReport) {
+	dest.Stuck = report.Stuck
+	dest.Fee = report.Fee
+	dest.RecommendedFee = report.RecommendedFee
+	dest.RebroadcastHash = report.RebroadcastHash
+	if report.RebroadcastErr != nil {
+		dest.RebroadcastError = report.RebroadcastErr.Error()
+	}
+}
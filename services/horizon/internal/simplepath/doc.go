@@ -35,4 +35,11 @@
 // 2. We start with the last asset (pop the stack), calculate it's cost (if not
 //    cached) and continue towards the source asset (bottom of the stack).
 // 3. We return the final cost.
+//
+// Strict send searches (`Finder.FindFixedPaths`, implemented by `sendSearch`)
+// walk the order books in the opposite direction: the queue is initialized
+// with the source asset and the source amount, and every extension computes the
+// amount of the new asset received in exchange for the amount of the previous
+// one (see `orderBook.AmountReceived`). Paths ending at one of the destination
+// assets are results.
 package simplepath
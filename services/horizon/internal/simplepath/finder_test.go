@@ -162,3 +162,77 @@ func TestFinder(t *testing.T) {
 		}
 	}
 }
+
+func TestFinder_FindFixedPaths(t *testing.T) {
+	tt := test.Start(t).Scenario("paths")
+	defer tt.Finish()
+
+	finder := &Finder{
+		Q: &core.Q{Session: tt.CoreSession()},
+	}
+
+	usd := makeAsset(
+		xdr.AssetTypeAssetTypeCreditAlphanum4,
+		"USD",
+		"GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN")
+	eur := makeAsset(
+		xdr.AssetTypeAssetTypeCreditAlphanum4,
+		"EUR",
+		"GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN")
+	inter1 := makeAsset(
+		xdr.AssetTypeAssetTypeCreditAlphanum4,
+		"1",
+		"GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN")
+	inter21 := makeAsset(
+		xdr.AssetTypeAssetTypeCreditAlphanum4,
+		"21",
+		"GDSBCQO34HWPGUGQSP3QBFEXVTSR2PW46UIGTHVWGWJGQKH3AFNHXHXN")
+
+	query := paths.Query{
+		SourceAsset:       usd,
+		SourceAmount:      xdr.Int64(100000000), // 10.0000000
+		DestinationAssets: []xdr.Asset{eur},
+	}
+
+	p, err := finder.FindFixedPaths(query, MaxPathLength)
+	if tt.Assert.NoError(err) {
+		tt.Assert.Len(p, 4)
+
+		// Consuming offers:
+		// - selling 10 EUR for USD, price = 0.5
+		// - selling 10 EUR for USD, price = 0.5
+		tt.Assert.Equal(p[0].Source.String(), usd.String())
+		tt.Assert.Equal(p[0].Destination.String(), eur.String())
+		tt.Assert.Equal(p[0].Cost, xdr.Int64(100000000))
+		tt.Assert.Equal(p[0].DestinationAmount, xdr.Int64(200000000)) // 20.0000000
+		tt.Assert.Len(p[0].Path, 0)
+
+		tt.Assert.Equal(p[1].DestinationAmount, xdr.Int64(100000000))
+		if tt.Assert.Len(p[1].Path, 1) {
+			tt.Assert.Equal(p[1].Path[0].String(), inter1.String())
+		}
+
+		tt.Assert.Equal(p[2].DestinationAmount, xdr.Int64(100000000))
+		if tt.Assert.Len(p[2].Path, 2) {
+			tt.Assert.Equal(p[2].Path[0].String(), inter21.String())
+		}
+
+		// every step of USD -> 31 -> 32 -> 33 -> EUR halves the amount
+		tt.Assert.Equal(p[3].DestinationAmount, xdr.Int64(6250000))
+		tt.Assert.Len(p[3].Path, 3)
+	}
+
+	// paths without enough liquidity to absorb the source amount are skipped
+	query.SourceAmount = xdr.Int64(250000000)
+	p, err = finder.FindFixedPaths(query, MaxPathLength)
+	if tt.Assert.NoError(err) {
+		if tt.Assert.Len(p, 2) {
+			tt.Assert.Equal(p[0].DestinationAmount, xdr.Int64(250000000))
+			tt.Assert.Equal(p[1].DestinationAmount, xdr.Int64(15625000))
+		}
+	}
+
+	query.DestinationAssets = nil
+	_, err = finder.FindFixedPaths(query, MaxPathLength)
+	tt.Assert.Error(err)
+}
@@ -1,11 +1,8 @@
 package simplepath
 
 import (
-	"math"
 	"testing"
 
-	"github.com/stretchr/testify/assert"
-
 	"github.com/cowry-network/go/services/horizon/internal/db2/core"
 	"github.com/cowry-network/go/services/horizon/internal/test"
 	"github.com/cowry-network/go/xdr"
@@ -54,6 +51,33 @@ func TestOrderBook(t *testing.T) {
 		_, err := ob.CostToConsumeLiquidity(xdr.Int64(300000001))
 		tt.Assert.Error(err)
 	})
+
+	amountCases := []struct {
+		scenario string
+		usd      int64
+		wantEUR  int64
+	}{
+		{"first unit", 1, 2},                              // spending on the first offer (p=0.5)
+		{"first full offer", 50000000, 100000000},         // consuming the first offer (p=0.5)
+		{"first two full offers", 100000000, 200000000},   // consuming the first two offers (p=0.5, p=0.5)
+		{"partial third offer", 150000000, 250000000},     // consuming the first two offers and half of the third one (p=1.0)
+		{"first three full offers", 200000000, 300000000}, // consuming all offers
+	}
+
+	for _, kase := range amountCases {
+		t.Run(kase.scenario, func(t *testing.T) {
+			r, err := ob.AmountReceived(xdr.Int64(kase.usd))
+			if tt.Assert.NoError(err) {
+				tt.Assert.Equal(xdr.Int64(kase.wantEUR), r)
+			}
+		})
+	}
+
+	// spending 1 more than the order book can absorb
+	t.Run("one more than available liquidity", func(t *testing.T) {
+		_, err := ob.AmountReceived(xdr.Int64(200000001))
+		tt.Assert.Equal(ErrNotEnough, err)
+	})
 }
 
 func TestOrderBook_BadCost(t *testing.T) {
@@ -77,59 +101,3 @@ func TestOrderBook_BadCost(t *testing.T) {
 		tt.Assert.Equal(xdr.Int64(10000000), r)
 	}
 }
-
-func TestConvertToBuyingUnits(t *testing.T) {
-	testCases := []struct {
-		sellingOfferAmount int64
-		sellingUnitsNeeded int64
-		pricen             int64
-		priced             int64
-		wantBuyingUnits    int64
-		wantSellingUnits   int64
-	}{
-		{7, 2, 3, 7, 1, 2},
-		{math.MaxInt64, 2, 3, 7, 1, 2},
-		{20, 20, 1, 4, 5, 20},
-		{20, 100, 1, 4, 5, 20},
-		{20, 20, 7, 11, 13, 19},
-		{20, 20, 11, 7, 32, 20},
-		{20, 100, 7, 11, 13, 19},
-		{20, 100, 11, 7, 32, 20},
-		{1, 0, 3, 7, 0, 0},
-		{1, 0, 7, 3, 0, 0},
-		{math.MaxInt64, 0, 3, 7, 0, 0},
-	}
-	for _, kase := range testCases {
-		t.Run(t.Name(), func(t *testing.T) {
-			buyingUnits, sellingUnits, e := convertToBuyingUnits(kase.sellingOfferAmount, kase.sellingUnitsNeeded, kase.pricen, kase.priced)
-			if !assert.Nil(t, e) {
-				return
-			}
-			assert.Equal(t, kase.wantBuyingUnits, buyingUnits)
-			assert.Equal(t, kase.wantSellingUnits, sellingUnits)
-		})
-	}
-}
-
-func TestWillAddOverflow(t *testing.T) {
-	testCases := []struct {
-		a                int64
-		b                int64
-		wantWillOverflow bool
-	}{
-		{1, 2, false},
-		{0, 1, false},
-		{math.MaxInt64, 0, false},
-		{math.MaxInt64 - 1, 1, false},
-		{math.MaxInt64, 1, true},
-		{math.MaxInt64 - 1, 2, true},
-		{math.MaxInt64 - 1, math.MaxInt64, true},
-		{math.MaxInt64, math.MaxInt64, true},
-	}
-	for _, kase := range testCases {
-		t.Run(t.Name(), func(t *testing.T) {
-			r := willAddOverflow(kase.a, kase.b)
-			assert.Equal(t, kase.wantWillOverflow, r)
-		})
-	}
-}
@@ -94,13 +94,26 @@ func (s *search) Run() {
 		return
 	}
 
-	s.Err = s.Q.Begin()
+	s.Err = beginSnapshot(s.Q)
 	if s.Err != nil {
 		return
 	}
 
 	defer s.Q.Rollback()
 
+	for s.hasMore() {
+		s.runOnce()
+	}
+}
+
+// beginSnapshot starts a read only transaction on q. Callers are responsible
+// for rolling the transaction back once done.
+func beginSnapshot(q *core.Q) error {
+	err := q.Begin()
+	if err != nil {
+		return err
+	}
+
 	// We need REPEATABLE READ here to have a stable view of the offers
 	// table. Without it, it's possible that search started in ledger X
 	// and finished in ledger X+1 would give invalid results.
@@ -108,14 +121,13 @@ func (s *search) Run() {
 	// https://www.postgresql.org/docs/9.1/static/transaction-iso.html
 	// > Note that only updating transactions might need to be retried;
 	// > read-only transactions will never have serialization conflicts.
-	_, s.Err = s.Q.ExecRaw("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY")
-	if s.Err != nil {
-		return
+	_, err = q.ExecRaw("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY")
+	if err != nil {
+		q.Rollback()
+		return err
 	}
 
-	for s.hasMore() {
-		s.runOnce()
-	}
+	return nil
 }
 
 // pop removes the head from the search queue, returning it to the caller
@@ -152,7 +164,9 @@ func (s *search) runOnce() {
 	id := cur.path.Asset.String()
 
 	if s.isTarget(id) {
-		s.Results = append(s.Results, cur.asPath())
+		path := cur.asPath()
+		path.DestinationAmount = s.Query.DestinationAmount
+		s.Results = append(s.Results, path)
 	}
 
 	if cur.path.Depth == s.MaxLength {
@@ -0,0 +1,197 @@
+package simplepath
+
+import (
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/services/horizon/internal/paths"
+	"github.com/cowry-network/go/xdr"
+)
+
+// sendSearch represents a single strict send query against the simple
+// finder. It works like search but starts from the source asset and walks
+// the order books towards the destination assets, computing the amount
+// received at every step.
+//
+// The sendSearch struct is used as follows:
+//
+// 1.  Create an instance, ensuring the Query and Q fields are set
+// 2.  Call Init() to populate dependent fields in the struct with their initial values
+// 3.  Call Run() to perform the search.
+//
+type sendSearch struct {
+	Query     paths.Query
+	Q         *core.Q
+	MaxLength uint
+
+	// Fields below are initialized by a call to Init() after
+	// setting the fields above
+	queue   []*sendNode
+	targets map[string]bool
+
+	//This fields below are initialized after the search is run
+	Err     error
+	Results []paths.Path
+}
+
+// sendNode represents a strict send path as a linked list pointing from the
+// last asset reached back to the source asset.
+type sendNode struct {
+	Asset xdr.Asset
+	Prev  *sendNode
+	// Amount is the amount of Asset received after following the path
+	Amount xdr.Int64
+	Depth  uint
+}
+
+// IsOnPath returns true if a given asset is in the path.
+func (n *sendNode) IsOnPath(asset xdr.Asset) bool {
+	for cur := n; cur != nil; cur = cur.Prev {
+		if asset.Equals(cur.Asset) {
+			return true
+		}
+	}
+	return false
+}
+
+// Flatten returns the assets of the path, from source to destination
+func (n *sendNode) Flatten() []xdr.Asset {
+	result := make([]xdr.Asset, n.Depth)
+	for cur := n; cur != nil; cur = cur.Prev {
+		result[cur.Depth-1] = cur.Asset
+	}
+	return result
+}
+
+func (n *sendNode) asPath(sourceAmount xdr.Int64) paths.Path {
+	assets := n.Flatten()
+	var path []xdr.Asset
+	if len(assets) > 2 {
+		path = assets[1 : len(assets)-1]
+	}
+
+	return paths.Path{
+		Path:              path,
+		Source:            assets[0],
+		Destination:       n.Asset,
+		Cost:              sourceAmount,
+		DestinationAmount: n.Amount,
+	}
+}
+
+// Init initialized the search, setting fields on the struct used to
+// hold state needed during the actual search.
+func (s *sendSearch) Init() {
+	s.queue = []*sendNode{
+		&sendNode{
+			Asset:  s.Query.SourceAsset,
+			Amount: s.Query.SourceAmount,
+			Depth:  1,
+		},
+	}
+
+	s.targets = map[string]bool{}
+	for _, a := range s.Query.DestinationAssets {
+		s.targets[a.String()] = true
+	}
+
+	s.Err = nil
+	s.Results = nil
+}
+
+// Run triggers the search, which will populate the Results and Err
+// field for the search after completion.
+func (s *sendSearch) Run() {
+	if s.Err != nil {
+		return
+	}
+
+	s.Err = beginSnapshot(s.Q)
+	if s.Err != nil {
+		return
+	}
+
+	defer s.Q.Rollback()
+
+	for s.hasMore() {
+		s.runOnce()
+	}
+}
+
+// returns false if the search should stop.
+func (s *sendSearch) hasMore() bool {
+	if s.Err != nil {
+		return false
+	}
+
+	if len(s.Results) >= maxResults {
+		return false
+	}
+
+	return len(s.queue) > 0
+}
+
+// isTarget returns true if the asset id provided is one of the destination
+// assets for this search
+func (s *sendSearch) isTarget(id string) bool {
+	_, found := s.targets[id]
+	return found
+}
+
+// runOnce processes the head of the search queue, findings results
+// and extending the search as necessary.
+func (s *sendSearch) runOnce() {
+	cur := s.queue[0]
+	s.queue = s.queue[1:]
+
+	if s.isTarget(cur.Asset.String()) {
+		s.Results = append(s.Results, cur.asPath(s.Query.SourceAmount))
+	}
+
+	if cur.Depth == s.MaxLength {
+		return
+	}
+
+	s.extendSearch(cur)
+}
+
+func (s *sendSearch) extendSearch(n *sendNode) {
+	// find the assets offered in exchange for the current asset
+	var connected []xdr.Asset
+	s.Err = s.Q.ConnectedBuyingAssets(&connected, n.Asset)
+	if s.Err != nil {
+		return
+	}
+
+	for _, a := range connected {
+		// See search.extendSearch, the same pruning rules apply.
+		if n.IsOnPath(a) {
+			continue
+		}
+
+		if n.Depth == s.MaxLength-1 && !s.isTarget(a.String()) {
+			continue
+		}
+
+		ob := orderBook{
+			Selling: a,
+			Buying:  n.Asset,
+			Q:       s.Q,
+		}
+
+		var received xdr.Int64
+		received, s.Err = ob.AmountReceived(n.Amount)
+		if s.Err == ErrNotEnough {
+			s.Err = nil
+			continue
+		}
+		if s.Err != nil {
+			return
+		}
+
+		s.queue = append(s.queue, &sendNode{
+			Asset:  a,
+			Prev:   n,
+			Amount: received,
+			Depth:  n.Depth + 1,
+		})
+	}
+}
@@ -14,23 +14,46 @@ SET client_min_messages = warning;
 
 SET search_path = public, pg_catalog;
 
+ALTER TABLE IF EXISTS ONLY public.history_trades_1m DROP CONSTRAINT IF EXISTS history_trades_1m_counter_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_trades_1m DROP CONSTRAINT IF EXISTS history_trades_1m_base_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_trades_1h DROP CONSTRAINT IF EXISTS history_trades_1h_counter_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_trades_1h DROP CONSTRAINT IF EXISTS history_trades_1h_base_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_trades_1d DROP CONSTRAINT IF EXISTS history_trades_1d_counter_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_trades_1d DROP CONSTRAINT IF EXISTS history_trades_1d_base_asset_id_fkey;
 ALTER TABLE IF EXISTS ONLY public.history_trades DROP CONSTRAINT IF EXISTS history_trades_counter_asset_id_fkey;
 ALTER TABLE IF EXISTS ONLY public.history_trades DROP CONSTRAINT IF EXISTS history_trades_counter_account_id_fkey;
 ALTER TABLE IF EXISTS ONLY public.history_trades DROP CONSTRAINT IF EXISTS history_trades_base_asset_id_fkey;
 ALTER TABLE IF EXISTS ONLY public.history_trades DROP CONSTRAINT IF EXISTS history_trades_base_account_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_markets DROP CONSTRAINT IF EXISTS history_markets_counter_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.history_markets DROP CONSTRAINT IF EXISTS history_markets_base_asset_id_fkey;
+ALTER TABLE IF EXISTS ONLY public.asset_stats_snapshots DROP CONSTRAINT IF EXISTS asset_stats_snapshots_id_fkey;
 ALTER TABLE IF EXISTS ONLY public.asset_stats DROP CONSTRAINT IF EXISTS asset_stats_id_fkey;
+DROP INDEX IF EXISTS public.txsub_open_submissions_by_hash;
 DROP INDEX IF EXISTS public.trade_effects_by_order_book;
+DROP INDEX IF EXISTS public.ingestion_jobs_by_range;
+DROP INDEX IF EXISTS public.index_history_transactions_on_memo;
 DROP INDEX IF EXISTS public.index_history_transactions_on_id;
+DROP INDEX IF EXISTS public.index_history_operations_on_type_and_id;
 DROP INDEX IF EXISTS public.index_history_operations_on_type;
 DROP INDEX IF EXISTS public.index_history_operations_on_transaction_id;
+DROP INDEX IF EXISTS public.index_history_operations_on_source_asset;
+DROP INDEX IF EXISTS public.index_history_operations_on_selling_asset;
 DROP INDEX IF EXISTS public.index_history_operations_on_id;
+DROP INDEX IF EXISTS public.index_history_operations_on_buying_asset;
+DROP INDEX IF EXISTS public.index_history_operations_on_asset;
+DROP INDEX IF EXISTS public.index_history_operations_on_amount;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_sequence;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_previous_ledger_hash;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_ledger_hash;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_importer_version;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_id;
 DROP INDEX IF EXISTS public.index_history_ledgers_on_closed_at;
+DROP INDEX IF EXISTS public.index_history_effects_on_type_and_id;
 DROP INDEX IF EXISTS public.index_history_effects_on_type;
+DROP INDEX IF EXISTS public.index_history_effects_on_sold_asset;
+DROP INDEX IF EXISTS public.index_history_effects_on_bought_asset;
+DROP INDEX IF EXISTS public.index_history_effects_on_asset;
+DROP INDEX IF EXISTS public.index_history_effects_on_amount;
 DROP INDEX IF EXISTS public.index_history_accounts_on_id;
 DROP INDEX IF EXISTS public.index_history_accounts_on_address;
 DROP INDEX IF EXISTS public.htrd_time_lookup;
@@ -42,42 +65,62 @@ DROP INDEX IF EXISTS public.htrd_by_counter_offer;
 DROP INDEX IF EXISTS public.htrd_by_counter_account;
 DROP INDEX IF EXISTS public.htrd_by_base_offer;
 DROP INDEX IF EXISTS public.htrd_by_base_account;
+DROP INDEX IF EXISTS public.htrd_1m_by_pair_and_time;
+DROP INDEX IF EXISTS public.htrd_1h_by_pair_and_time;
+DROP INDEX IF EXISTS public.htrd_1d_by_pair_and_time;
 DROP INDEX IF EXISTS public.htp_by_htid;
 DROP INDEX IF EXISTS public.hs_transaction_by_id;
 DROP INDEX IF EXISTS public.hs_ledger_by_id;
 DROP INDEX IF EXISTS public.hop_by_hoid;
+DROP INDEX IF EXISTS public.hmkt_by_pair;
+DROP INDEX IF EXISTS public.hmkt_by_last_trade;
 DROP INDEX IF EXISTS public.hist_tx_p_id;
 DROP INDEX IF EXISTS public.hist_op_p_id;
+DROP INDEX IF EXISTS public.hist_lec_id;
+DROP INDEX IF EXISTS public.hist_lec_by_order;
+DROP INDEX IF EXISTS public.hist_lec_by_key;
 DROP INDEX IF EXISTS public.hist_e_id;
 DROP INDEX IF EXISTS public.hist_e_by_order;
 DROP INDEX IF EXISTS public.by_ledger;
 DROP INDEX IF EXISTS public.by_hash;
 DROP INDEX IF EXISTS public.by_account;
+DROP INDEX IF EXISTS public.asset_stats_snapshots_by_asset_and_day;
 DROP INDEX IF EXISTS public.asset_by_issuer;
 DROP INDEX IF EXISTS public.asset_by_code;
+ALTER TABLE IF EXISTS ONLY public.ingestion_jobs DROP CONSTRAINT IF EXISTS ingestion_jobs_pkey;
 ALTER TABLE IF EXISTS ONLY public.history_transaction_participants DROP CONSTRAINT IF EXISTS history_transaction_participants_pkey;
 ALTER TABLE IF EXISTS ONLY public.history_operation_participants DROP CONSTRAINT IF EXISTS history_operation_participants_pkey;
 ALTER TABLE IF EXISTS ONLY public.history_assets DROP CONSTRAINT IF EXISTS history_assets_pkey;
 ALTER TABLE IF EXISTS ONLY public.history_assets DROP CONSTRAINT IF EXISTS history_assets_asset_code_asset_type_asset_issuer_key;
 ALTER TABLE IF EXISTS ONLY public.gorp_migrations DROP CONSTRAINT IF EXISTS gorp_migrations_pkey;
 ALTER TABLE IF EXISTS ONLY public.asset_stats DROP CONSTRAINT IF EXISTS asset_stats_pkey;
+ALTER TABLE IF EXISTS public.ingestion_jobs ALTER COLUMN id DROP DEFAULT;
 ALTER TABLE IF EXISTS public.history_transaction_participants ALTER COLUMN id DROP DEFAULT;
 ALTER TABLE IF EXISTS public.history_operation_participants ALTER COLUMN id DROP DEFAULT;
 ALTER TABLE IF EXISTS public.history_assets ALTER COLUMN id DROP DEFAULT;
+DROP SEQUENCE IF EXISTS public.ingestion_jobs_id_seq;
+DROP TABLE IF EXISTS public.txsub_open_submissions;
+DROP TABLE IF EXISTS public.ingestion_jobs;
 DROP TABLE IF EXISTS public.history_transactions;
 DROP SEQUENCE IF EXISTS public.history_transaction_participants_id_seq;
 DROP TABLE IF EXISTS public.history_transaction_participants;
+DROP TABLE IF EXISTS public.history_trades_1m;
+DROP TABLE IF EXISTS public.history_trades_1h;
+DROP TABLE IF EXISTS public.history_trades_1d;
 DROP TABLE IF EXISTS public.history_trades;
 DROP TABLE IF EXISTS public.history_operations;
 DROP SEQUENCE IF EXISTS public.history_operation_participants_id_seq;
 DROP TABLE IF EXISTS public.history_operation_participants;
+DROP TABLE IF EXISTS public.history_markets;
 DROP TABLE IF EXISTS public.history_ledgers;
+DROP TABLE IF EXISTS public.history_ledger_entry_changes;
 DROP TABLE IF EXISTS public.history_effects;
 DROP SEQUENCE IF EXISTS public.history_assets_id_seq;
 DROP TABLE IF EXISTS public.history_assets;
 DROP TABLE IF EXISTS public.history_accounts;
 DROP SEQUENCE IF EXISTS public.history_accounts_id_seq;
 DROP TABLE IF EXISTS public.gorp_migrations;
+DROP TABLE IF EXISTS public.asset_stats_snapshots;
 DROP TABLE IF EXISTS public.asset_stats;
 DROP AGGREGATE IF EXISTS public.min_price(numeric[]);
 DROP AGGREGATE IF EXISTS public.max_price(numeric[]);
@@ -210,7 +253,22 @@ CREATE TABLE asset_stats (
     amount character varying NOT NULL,
     num_accounts integer NOT NULL,
     flags smallint NOT NULL,
-    toml character varying(255) NOT NULL
+    toml character varying(255) NOT NULL,
+    num_unauthorized_accounts integer DEFAULT 0 NOT NULL,
+    bid_depth character varying DEFAULT '0'::character varying NOT NULL,
+    ask_depth character varying DEFAULT '0'::character varying NOT NULL
+);
+
+
+--
+-- Name: asset_stats_snapshots; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE asset_stats_snapshots (
+    id bigint NOT NULL,
+    day date NOT NULL,
+    amount character varying NOT NULL,
+    num_accounts integer NOT NULL
 );
 
 
@@ -290,6 +348,22 @@ CREATE TABLE history_effects (
 );
 
 
+--
+-- Name: history_ledger_entry_changes; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_ledger_entry_changes (
+    history_account_id bigint NOT NULL,
+    history_operation_id bigint NOT NULL,
+    "order" integer NOT NULL,
+    entry_type integer NOT NULL,
+    change_type integer NOT NULL,
+    entry_before text,
+    entry_after text,
+    ledger_key text
+);
+
+
 --
 -- Name: history_ledgers; Type: TABLE; Schema: public; Owner: -
 --
@@ -317,6 +391,19 @@ CREATE TABLE history_ledgers (
 );
 
 
+--
+-- Name: history_markets; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_markets (
+    base_asset_id bigint NOT NULL,
+    counter_asset_id bigint NOT NULL,
+    first_trade_at timestamp without time zone NOT NULL,
+    last_trade_at timestamp without time zone NOT NULL,
+    CONSTRAINT history_markets_check CHECK ((base_asset_id < counter_asset_id))
+);
+
+
 --
 -- Name: history_operation_participants; Type: TABLE; Schema: public; Owner: -
 --
@@ -387,6 +474,72 @@ CREATE TABLE history_trades (
 );
 
 
+--
+-- Name: history_trades_1d; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_trades_1d (
+    base_asset_id bigint NOT NULL,
+    counter_asset_id bigint NOT NULL,
+    "timestamp" bigint NOT NULL,
+    count integer NOT NULL,
+    base_volume numeric NOT NULL,
+    counter_volume numeric NOT NULL,
+    high numeric[] NOT NULL,
+    low numeric[] NOT NULL,
+    open numeric[] NOT NULL,
+    open_operation_id bigint NOT NULL,
+    open_order integer NOT NULL,
+    close numeric[] NOT NULL,
+    close_operation_id bigint NOT NULL,
+    close_order integer NOT NULL
+);
+
+
+--
+-- Name: history_trades_1h; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_trades_1h (
+    base_asset_id bigint NOT NULL,
+    counter_asset_id bigint NOT NULL,
+    "timestamp" bigint NOT NULL,
+    count integer NOT NULL,
+    base_volume numeric NOT NULL,
+    counter_volume numeric NOT NULL,
+    high numeric[] NOT NULL,
+    low numeric[] NOT NULL,
+    open numeric[] NOT NULL,
+    open_operation_id bigint NOT NULL,
+    open_order integer NOT NULL,
+    close numeric[] NOT NULL,
+    close_operation_id bigint NOT NULL,
+    close_order integer NOT NULL
+);
+
+
+--
+-- Name: history_trades_1m; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE history_trades_1m (
+    base_asset_id bigint NOT NULL,
+    counter_asset_id bigint NOT NULL,
+    "timestamp" bigint NOT NULL,
+    count integer NOT NULL,
+    base_volume numeric NOT NULL,
+    counter_volume numeric NOT NULL,
+    high numeric[] NOT NULL,
+    low numeric[] NOT NULL,
+    open numeric[] NOT NULL,
+    open_operation_id bigint NOT NULL,
+    open_order integer NOT NULL,
+    close numeric[] NOT NULL,
+    close_operation_id bigint NOT NULL,
+    close_order integer NOT NULL
+);
+
+
 --
 -- Name: history_transaction_participants; Type: TABLE; Schema: public; Owner: -
 --
@@ -444,6 +597,52 @@ CREATE TABLE history_transactions (
 );
 
 
+--
+-- Name: ingestion_jobs; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE ingestion_jobs (
+    id bigint NOT NULL,
+    kind character varying(32) NOT NULL,
+    first_ledger integer NOT NULL,
+    last_ledger integer NOT NULL,
+    last_ingested_ledger integer,
+    status character varying(16) NOT NULL,
+    error text,
+    created_at timestamp without time zone NOT NULL,
+    updated_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: txsub_open_submissions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE txsub_open_submissions (
+    hash character varying(64) NOT NULL,
+    submitted_at timestamp without time zone NOT NULL
+);
+
+
+--
+-- Name: ingestion_jobs_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE ingestion_jobs_id_seq
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: ingestion_jobs_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE ingestion_jobs_id_seq OWNED BY ingestion_jobs.id;
+
+
 --
 -- Name: history_assets id; Type: DEFAULT; Schema: public; Owner: -
 --
@@ -465,12 +664,25 @@ ALTER TABLE ONLY history_operation_participants ALTER COLUMN id SET DEFAULT next
 ALTER TABLE ONLY history_transaction_participants ALTER COLUMN id SET DEFAULT nextval('history_transaction_participants_id_seq'::regclass);
 
 
+--
+-- Name: ingestion_jobs id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY ingestion_jobs ALTER COLUMN id SET DEFAULT nextval('ingestion_jobs_id_seq'::regclass);
+
+
 --
 -- Data for Name: asset_stats; Type: TABLE DATA; Schema: public; Owner: -
 --
 
 
 
+--
+-- Data for Name: asset_stats_snapshots; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: gorp_migrations; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -492,6 +704,16 @@ INSERT INTO gorp_migrations VALUES ('13_trade_offer_ids.sql', '2019-02-21 13:54:
 INSERT INTO gorp_migrations VALUES ('14_fix_asset_toml_field.sql', '2019-02-21 13:54:34.151707+01');
 INSERT INTO gorp_migrations VALUES ('15_ledger_failed_txs.sql', '2019-02-21 13:54:34.154129+01');
 INSERT INTO gorp_migrations VALUES ('16_ingest_failed_transactions.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('17_add_ledger_entry_changes.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('18_add_ledger_entry_changes_key.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('19_add_ingestion_jobs.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('20_add_txsub_open_submissions.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('21_add_history_filter_indexes.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('22_add_transactions_memo_index.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('23_extend_asset_stats.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('24_create_history_markets.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('25_add_trade_rollups.sql', '2019-02-21 13:54:34.155663+01');
+INSERT INTO gorp_migrations VALUES ('26_add_history_asset_and_amount_indexes.sql', '2019-02-21 13:54:34.155663+01');
 
 
 --
@@ -538,6 +760,12 @@ INSERT INTO history_effects VALUES (3, 8589942785, 2, 3, '{"amount": "1000.00000
 INSERT INTO history_effects VALUES (2, 8589942785, 3, 10, '{"weight": 1, "public_key": "GA5WBPYA5Y4WAEHXWR2UKO2UO4BUGHUQ74EUPKON2QHV4WRHOIRNKKH2"}');
 
 
+--
+-- Data for Name: history_ledger_entry_changes; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_ledgers; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -547,6 +775,12 @@ INSERT INTO history_ledgers VALUES (2, '70bde26820fe023a6497890bdd4eba67ac6ab782
 INSERT INTO history_ledgers VALUES (1, '63d98f536ee68d1b27b5b89f23af5311b7569a24faf1403ad0b52b633b07be99', NULL, 0, 0, '1970-01-01 00:00:00', '2019-02-21 12:56:51.228243', '2019-02-21 12:56:51.228243', 4294967296, 16, 1000000000000000000, 0, 100, 100000000, 100, 0, 'AAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAABXKi4y/ySKB7DnD9H20xjB+s0gtswIwz1XdSWYaBJaFgAAAAEN4Lazp2QAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAZAX14QAAAABkAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAAA', 0, 0);
 
 
+--
+-- Data for Name: history_markets; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_operation_participants; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -581,6 +815,24 @@ INSERT INTO history_operations VALUES (8589942785, 8589942784, 1, 0, '{"funder":
 
 
 
+--
+-- Data for Name: history_trades_1d; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
+--
+-- Data for Name: history_trades_1h; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
+--
+-- Data for Name: history_trades_1m; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
 --
 -- Data for Name: history_transaction_participants; Type: TABLE DATA; Schema: public; Owner: -
 --
@@ -609,6 +861,25 @@ INSERT INTO history_transactions VALUES ('b2a227c39c64a44fc7abd4c96819456f039990
 INSERT INTO history_transactions VALUES ('36be70fb7782f9801cdcedc1206e21f99293c99860a15e441f4749747a0a37ab', 2, 2, 'GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H', 2, 100, 1, '2019-02-21 12:56:51.212604', '2019-02-21 12:56:51.212617', 8589942784, 'AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAAZAAAAAAAAAACAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAAO2C/AO45YBD3tHVFO1R3A0MekP8JR6nN1A9eWidyItUAAAACVAvkAAAAAAAAAAABVvwF9wAAAEA3xWbxPObnZMiBGFKLJQufJLguTsHJxyAsPP5F9Zj561aXnvN/HVRJbFsEcitGbgi9dWVdKRYvmVWCizIdmLID', 'AAAAAAAAAGQAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAA=', 'AAAAAQAAAAAAAAABAAAAAwAAAAMAAAACAAAAAAAAAABi/B0L0JGythwN1lY0aypo19NHxvLCyO5tBEcCVvwF9w3gtrFTWBs4AAAAAAAAAAIAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAEAAAACAAAAAAAAAABi/B0L0JGythwN1lY0aypo19NHxvLCyO5tBEcCVvwF9w3gtq7/TDc4AAAAAAAAAAIAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAAAAAACAAAAAAAAAAA7YL8A7jlgEPe0dUU7VHcDQx6Q/wlHqc3UD15aJ3Ii1QAAAAJUC+QAAAAAAgAAAAAAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAA==', 'AAAAAgAAAAMAAAACAAAAAAAAAABi/B0L0JGythwN1lY0aypo19NHxvLCyO5tBEcCVvwF9w3gtrOnY/+cAAAAAAAAAAEAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAAAAAAEAAAACAAAAAAAAAABi/B0L0JGythwN1lY0aypo19NHxvLCyO5tBEcCVvwF9w3gtrOnY/84AAAAAAAAAAIAAAAAAAAAAAAAAAAAAAAAAQAAAAAAAAAAAAAAAAAAAA==', '{N8Vm8Tzm52TIgRhSiyULnyS4Lk7ByccgLDz+RfWY+etWl57zfx1USWxbBHIrRm4IvXVlXSkWL5lVgosyHZiyAw==}', 'none', NULL, NULL, true);
 
 
+--
+-- Data for Name: ingestion_jobs; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
+--
+-- Data for Name: txsub_open_submissions; Type: TABLE DATA; Schema: public; Owner: -
+--
+
+
+
+--
+-- Name: ingestion_jobs_id_seq; Type: SEQUENCE SET; Schema: public; Owner: -
+--
+
+SELECT pg_catalog.setval('ingestion_jobs_id_seq', 1, false);
+
+
 --
 -- Name: asset_stats asset_stats_pkey; Type: CONSTRAINT; Schema: public; Owner: -
 --
@@ -657,6 +928,14 @@ ALTER TABLE ONLY history_transaction_participants
     ADD CONSTRAINT history_transaction_participants_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: ingestion_jobs ingestion_jobs_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY ingestion_jobs
+    ADD CONSTRAINT ingestion_jobs_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: asset_by_code; Type: INDEX; Schema: public; Owner: -
 --
@@ -671,6 +950,13 @@ CREATE INDEX asset_by_code ON history_assets USING btree (asset_code);
 CREATE INDEX asset_by_issuer ON history_assets USING btree (asset_issuer);
 
 
+--
+-- Name: asset_stats_snapshots_by_asset_and_day; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX asset_stats_snapshots_by_asset_and_day ON asset_stats_snapshots USING btree (id, day);
+
+
 --
 -- Name: by_account; Type: INDEX; Schema: public; Owner: -
 --
@@ -706,6 +992,27 @@ CREATE UNIQUE INDEX hist_e_by_order ON history_effects USING btree (history_oper
 CREATE UNIQUE INDEX hist_e_id ON history_effects USING btree (history_account_id, history_operation_id, "order");
 
 
+--
+-- Name: hist_lec_by_key; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hist_lec_by_key ON history_ledger_entry_changes USING btree (history_account_id, ledger_key, history_operation_id, "order");
+
+
+--
+-- Name: hist_lec_by_order; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hist_lec_by_order ON history_ledger_entry_changes USING btree (history_operation_id, "order");
+
+
+--
+-- Name: hist_lec_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hist_lec_id ON history_ledger_entry_changes USING btree (history_account_id, history_operation_id, "order");
+
+
 --
 -- Name: hist_op_p_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -720,6 +1027,20 @@ CREATE UNIQUE INDEX hist_op_p_id ON history_operation_participants USING btree (
 CREATE UNIQUE INDEX hist_tx_p_id ON history_transaction_participants USING btree (history_account_id, history_transaction_id);
 
 
+--
+-- Name: hmkt_by_last_trade; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX hmkt_by_last_trade ON history_markets USING btree (last_trade_at);
+
+
+--
+-- Name: hmkt_by_pair; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX hmkt_by_pair ON history_markets USING btree (base_asset_id, counter_asset_id);
+
+
 --
 -- Name: hop_by_hoid; Type: INDEX; Schema: public; Owner: -
 --
@@ -748,6 +1069,27 @@ CREATE UNIQUE INDEX hs_transaction_by_id ON history_transactions USING btree (id
 CREATE INDEX htp_by_htid ON history_transaction_participants USING btree (history_transaction_id);
 
 
+--
+-- Name: htrd_1d_by_pair_and_time; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX htrd_1d_by_pair_and_time ON history_trades_1d USING btree (base_asset_id, counter_asset_id, "timestamp");
+
+
+--
+-- Name: htrd_1h_by_pair_and_time; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX htrd_1h_by_pair_and_time ON history_trades_1h USING btree (base_asset_id, counter_asset_id, "timestamp");
+
+
+--
+-- Name: htrd_1m_by_pair_and_time; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX htrd_1m_by_pair_and_time ON history_trades_1m USING btree (base_asset_id, counter_asset_id, "timestamp");
+
+
 --
 -- Name: htrd_by_base_account; Type: INDEX; Schema: public; Owner: -
 --
@@ -825,6 +1167,34 @@ CREATE UNIQUE INDEX index_history_accounts_on_address ON history_accounts USING
 CREATE UNIQUE INDEX index_history_accounts_on_id ON history_accounts USING btree (id);
 
 
+--
+-- Name: index_history_effects_on_amount; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_effects_on_amount ON history_effects USING btree (((COALESCE((details ->> 'amount'::text), (details ->> 'starting_balance'::text)))::numeric), history_operation_id, "order");
+
+
+--
+-- Name: index_history_effects_on_asset; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_effects_on_asset ON history_effects USING btree (((details ->> 'asset_type'::text)), ((details ->> 'asset_code'::text)), ((details ->> 'asset_issuer'::text)), history_operation_id, "order");
+
+
+--
+-- Name: index_history_effects_on_bought_asset; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_effects_on_bought_asset ON history_effects USING btree (((details ->> 'bought_asset_type'::text)), ((details ->> 'bought_asset_code'::text)), ((details ->> 'bought_asset_issuer'::text)), history_operation_id, "order");
+
+
+--
+-- Name: index_history_effects_on_sold_asset; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_effects_on_sold_asset ON history_effects USING btree (((details ->> 'sold_asset_type'::text)), ((details ->> 'sold_asset_code'::text)), ((details ->> 'sold_asset_issuer'::text)), history_operation_id, "order");
+
+
 --
 -- Name: index_history_effects_on_type; Type: INDEX; Schema: public; Owner: -
 --
@@ -832,6 +1202,13 @@ CREATE UNIQUE INDEX index_history_accounts_on_id ON history_accounts USING btree
 CREATE INDEX index_history_effects_on_type ON history_effects USING btree (type);
 
 
+--
+-- Name: index_history_effects_on_type_and_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_effects_on_type_and_id ON history_effects USING btree (type, history_operation_id, "order");
+
+
 --
 -- Name: index_history_ledgers_on_closed_at; Type: INDEX; Schema: public; Owner: -
 --
@@ -874,6 +1251,27 @@ CREATE UNIQUE INDEX index_history_ledgers_on_previous_ledger_hash ON history_led
 CREATE UNIQUE INDEX index_history_ledgers_on_sequence ON history_ledgers USING btree (sequence);
 
 
+--
+-- Name: index_history_operations_on_amount; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_operations_on_amount ON history_operations USING btree (((COALESCE((details ->> 'amount'::text), (details ->> 'starting_balance'::text)))::numeric), id);
+
+
+--
+-- Name: index_history_operations_on_asset; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_operations_on_asset ON history_operations USING btree (((details ->> 'asset_type'::text)), ((details ->> 'asset_code'::text)), ((details ->> 'asset_issuer'::text)), id);
+
+
+--
+-- Name: index_history_operations_on_buying_asset; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_operations_on_buying_asset ON history_operations USING btree (((details ->> 'buying_asset_type'::text)), ((details ->> 'buying_asset_code'::text)), ((details ->> 'buying_asset_issuer'::text)), id);
+
+
 --
 -- Name: index_history_operations_on_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -881,6 +1279,20 @@ CREATE UNIQUE INDEX index_history_ledgers_on_sequence ON history_ledgers USING b
 CREATE UNIQUE INDEX index_history_operations_on_id ON history_operations USING btree (id);
 
 
+--
+-- Name: index_history_operations_on_selling_asset; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_operations_on_selling_asset ON history_operations USING btree (((details ->> 'selling_asset_type'::text)), ((details ->> 'selling_asset_code'::text)), ((details ->> 'selling_asset_issuer'::text)), id);
+
+
+--
+-- Name: index_history_operations_on_source_asset; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_operations_on_source_asset ON history_operations USING btree (((details ->> 'source_asset_type'::text)), ((details ->> 'source_asset_code'::text)), ((details ->> 'source_asset_issuer'::text)), id);
+
+
 --
 -- Name: index_history_operations_on_transaction_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -895,6 +1307,13 @@ CREATE INDEX index_history_operations_on_transaction_id ON history_operations US
 CREATE INDEX index_history_operations_on_type ON history_operations USING btree (type);
 
 
+--
+-- Name: index_history_operations_on_type_and_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_operations_on_type_and_id ON history_operations USING btree (type, id);
+
+
 --
 -- Name: index_history_transactions_on_id; Type: INDEX; Schema: public; Owner: -
 --
@@ -902,6 +1321,20 @@ CREATE INDEX index_history_operations_on_type ON history_operations USING btree
 CREATE UNIQUE INDEX index_history_transactions_on_id ON history_transactions USING btree (id);
 
 
+--
+-- Name: index_history_transactions_on_memo; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX index_history_transactions_on_memo ON history_transactions USING btree (memo, memo_type, id) WHERE (memo IS NOT NULL);
+
+
+--
+-- Name: ingestion_jobs_by_range; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX ingestion_jobs_by_range ON ingestion_jobs USING btree (kind, first_ledger, last_ledger);
+
+
 --
 -- Name: trade_effects_by_order_book; Type: INDEX; Schema: public; Owner: -
 --
@@ -909,6 +1342,13 @@ CREATE UNIQUE INDEX index_history_transactions_on_id ON history_transactions USI
 CREATE INDEX trade_effects_by_order_book ON history_effects USING btree (((details ->> 'sold_asset_type'::text)), ((details ->> 'sold_asset_code'::text)), ((details ->> 'sold_asset_issuer'::text)), ((details ->> 'bought_asset_type'::text)), ((details ->> 'bought_asset_code'::text)), ((details ->> 'bought_asset_issuer'::text))) WHERE (type = 33);
 
 
+--
+-- Name: txsub_open_submissions_by_hash; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX txsub_open_submissions_by_hash ON txsub_open_submissions USING btree (hash);
+
+
 --
 -- Name: asset_stats asset_stats_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -917,6 +1357,30 @@ ALTER TABLE ONLY asset_stats
     ADD CONSTRAINT asset_stats_id_fkey FOREIGN KEY (id) REFERENCES history_assets(id) ON UPDATE RESTRICT ON DELETE CASCADE;
 
 
+--
+-- Name: asset_stats_snapshots asset_stats_snapshots_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY asset_stats_snapshots
+    ADD CONSTRAINT asset_stats_snapshots_id_fkey FOREIGN KEY (id) REFERENCES history_assets(id) ON UPDATE RESTRICT ON DELETE CASCADE;
+
+
+--
+-- Name: history_markets history_markets_base_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_markets
+    ADD CONSTRAINT history_markets_base_asset_id_fkey FOREIGN KEY (base_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_markets history_markets_counter_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_markets
+    ADD CONSTRAINT history_markets_counter_asset_id_fkey FOREIGN KEY (counter_asset_id) REFERENCES history_assets(id);
+
+
 --
 -- Name: history_trades history_trades_base_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -949,6 +1413,54 @@ ALTER TABLE ONLY history_trades
     ADD CONSTRAINT history_trades_counter_asset_id_fkey FOREIGN KEY (counter_asset_id) REFERENCES history_assets(id);
 
 
+--
+-- Name: history_trades_1d history_trades_1d_base_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trades_1d
+    ADD CONSTRAINT history_trades_1d_base_asset_id_fkey FOREIGN KEY (base_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_trades_1d history_trades_1d_counter_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trades_1d
+    ADD CONSTRAINT history_trades_1d_counter_asset_id_fkey FOREIGN KEY (counter_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_trades_1h history_trades_1h_base_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trades_1h
+    ADD CONSTRAINT history_trades_1h_base_asset_id_fkey FOREIGN KEY (base_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_trades_1h history_trades_1h_counter_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trades_1h
+    ADD CONSTRAINT history_trades_1h_counter_asset_id_fkey FOREIGN KEY (counter_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_trades_1m history_trades_1m_base_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trades_1m
+    ADD CONSTRAINT history_trades_1m_base_asset_id_fkey FOREIGN KEY (base_asset_id) REFERENCES history_assets(id);
+
+
+--
+-- Name: history_trades_1m history_trades_1m_counter_asset_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY history_trades_1m
+    ADD CONSTRAINT history_trades_1m_counter_asset_id_fkey FOREIGN KEY (counter_asset_id) REFERENCES history_assets(id);
+
+
 --
 -- PostgreSQL database dump complete
 --
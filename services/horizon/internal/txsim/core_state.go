@@ -0,0 +1,99 @@
+package txsim
+
+import (
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/support/errors"
+	"github.com/cowry-network/go/xdr"
+)
+
+// CoreState implements LedgerState by reading the stellar-core database.
+type CoreState struct {
+	Q *core.Q
+}
+
+// ensure the struct is LedgerState compliant
+var _ LedgerState = &CoreState{}
+
+// Header implements LedgerState
+func (s *CoreState) Header() (xdr.LedgerHeader, error) {
+	var latest int32
+	err := s.Q.LatestLedger(&latest)
+	if err != nil {
+		return xdr.LedgerHeader{}, err
+	}
+
+	var header core.LedgerHeader
+	err = s.Q.LedgerHeaderBySequence(&header, latest)
+	if err != nil {
+		return xdr.LedgerHeader{}, errors.Wrap(err, "failed to load latest ledger header")
+	}
+
+	return header.Data, nil
+}
+
+// Account implements LedgerState
+func (s *CoreState) Account(address string) (*core.Account, error) {
+	var account core.Account
+	err := s.Q.AccountByAddress(&account, address)
+	if s.Q.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
+
+// Signers implements LedgerState
+func (s *CoreState) Signers(address string) ([]core.Signer, error) {
+	var signers []core.Signer
+	err := s.Q.SignersByAddress(&signers, address)
+	if s.Q.NoRows(err) {
+		return nil, nil
+	}
+
+	return signers, err
+}
+
+// Trustline implements LedgerState
+func (s *CoreState) Trustline(address string, asset xdr.Asset) (*core.Trustline, error) {
+	var tl core.Trustline
+	err := s.Q.TrustlineByAddressAndAsset(&tl, address, asset)
+	if s.Q.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &tl, nil
+}
+
+// Offer implements LedgerState
+func (s *CoreState) Offer(id int64) (*core.Offer, error) {
+	var offer core.Offer
+	err := s.Q.OfferByID(&offer, id)
+	if s.Q.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &offer, nil
+}
+
+// Data implements LedgerState
+func (s *CoreState) Data(address, name string) (*core.AccountData, error) {
+	var data core.AccountData
+	err := s.Q.AccountDataByKey(&data, address, name)
+	if s.Q.NoRows(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return &data, nil
+}
@@ -0,0 +1,342 @@
+package txsim
+
+import (
+	"math"
+	"strconv"
+
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/support/errors"
+	"github.com/cowry-network/go/xdr"
+)
+
+// account is an account entry being simulated on.
+type account struct {
+	core.Account
+	Seq     int64
+	Signers []core.Signer
+}
+
+// minBalance returns the minimum balance of the account once it has `extra`
+// more sub entries.
+func (a *account) minBalance(header xdr.LedgerHeader, extra int32) int64 {
+	return int64(2+a.Numsubentries+extra) * int64(header.BaseReserve)
+}
+
+// available returns the amount of lumens the account can spend.
+func (a *account) available(header xdr.LedgerHeader) int64 {
+	return int64(a.Balance) - a.minBalance(header, 0) - int64(a.SellingLiabilities)
+}
+
+// canAddSubEntry returns true if the account can afford the reserve of one
+// more sub entry.
+func (a *account) canAddSubEntry(header xdr.LedgerHeader) bool {
+	return int64(a.Balance)-int64(a.SellingLiabilities) >= a.minBalance(header, 1)
+}
+
+// authorized returns true if the trustline is authorized by its issuer.
+func authorized(tl *core.Trustline) bool {
+	return tl.Flags&int32(xdr.TrustLineFlagsAuthorizedFlag) != 0
+}
+
+// ledger overlays the changes made by the simulated operations on top of a
+// LedgerState.  Entries are loaded on first use and cached, nil entries are
+// entries known not to exist.
+type ledger struct {
+	State  LedgerState
+	Header xdr.LedgerHeader
+
+	accounts   map[string]*account
+	trustlines map[string]*core.Trustline // address + asset => trustline
+	data       map[string]bool            // address + name => exists
+	offers     map[int64]*core.Offer
+}
+
+func newLedger(state LedgerState, header xdr.LedgerHeader) *ledger {
+	return &ledger{
+		State:      state,
+		Header:     header,
+		accounts:   map[string]*account{},
+		trustlines: map[string]*core.Trustline{},
+		data:       map[string]bool{},
+		offers:     map[int64]*core.Offer{},
+	}
+}
+
+// Account returns the account at `address`, or nil if it does not exist.
+func (l *ledger) Account(address string) (*account, error) {
+	if acc, ok := l.accounts[address]; ok {
+		return acc, nil
+	}
+
+	row, err := l.State.Account(address)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load account")
+	}
+
+	var acc *account
+	if row != nil {
+		seq, err := strconv.ParseInt(row.Seqnum, 10, 64)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid account sequence")
+		}
+
+		signers, err := l.State.Signers(address)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to load signers")
+		}
+
+		acc = &account{Account: *row, Seq: seq, Signers: signers}
+	}
+
+	l.accounts[address] = acc
+	return acc, nil
+}
+
+// Trustline returns the trustline of `address` for `asset`, or nil if it does
+// not exist.
+func (l *ledger) Trustline(address string, asset xdr.Asset) (*core.Trustline, error) {
+	key := address + ":" + asset.String()
+	if tl, ok := l.trustlines[key]; ok {
+		return tl, nil
+	}
+
+	tl, err := l.State.Trustline(address, asset)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load trustline")
+	}
+
+	l.trustlines[key] = tl
+	return tl, nil
+}
+
+// SetTrustline creates, updates or removes (when `tl` is nil) the trustline of
+// `address` for `asset`.
+func (l *ledger) SetTrustline(address string, asset xdr.Asset, tl *core.Trustline) {
+	l.trustlines[address+":"+asset.String()] = tl
+}
+
+// HasData returns true if the account at `address` has a data entry named
+// `name`.
+func (l *ledger) HasData(address, name string) (bool, error) {
+	key := address + ":" + name
+	if exists, ok := l.data[key]; ok {
+		return exists, nil
+	}
+
+	row, err := l.State.Data(address, name)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to load data")
+	}
+
+	l.data[key] = row != nil
+	return row != nil, nil
+}
+
+// SetData records whether the account at `address` has a data entry named
+// `name`.
+func (l *ledger) SetData(address, name string, exists bool) {
+	l.data[address+":"+name] = exists
+}
+
+// Offer returns the offer with id `id`, or nil if it does not exist.
+func (l *ledger) Offer(id int64) (*core.Offer, error) {
+	if offer, ok := l.offers[id]; ok {
+		return offer, nil
+	}
+
+	offer, err := l.State.Offer(id)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load offer")
+	}
+
+	l.offers[id] = offer
+	return offer, nil
+}
+
+// RemoveOffer records that the offer with id `id` no longer exists.
+func (l *ledger) RemoveOffer(id int64) {
+	l.offers[id] = nil
+}
+
+// RemoveAccount records that the account at `address` no longer exists.
+func (l *ledger) RemoveAccount(address string) {
+	l.accounts[address] = nil
+}
+
+// CreateAccount records a new account at `address`.
+func (l *ledger) CreateAccount(address string, balance int64) {
+	l.accounts[address] = &account{
+		Account: core.Account{
+			Accountid:  address,
+			Balance:    xdr.Int64(balance),
+			Thresholds: xdr.Thresholds{1, 0, 0, 0},
+		},
+		// new accounts start with the sequence number of the ledger they are
+		// created in
+		Seq: int64(l.Header.LedgerSeq+1) << 32,
+	}
+}
+
+// Snapshot returns a copy of the entries loaded so far, see Restore.
+func (l *ledger) Snapshot() *ledger {
+	snapshot := newLedger(l.State, l.Header)
+
+	for k, v := range l.accounts {
+		if v != nil {
+			acc := *v
+			acc.Signers = append([]core.Signer(nil), v.Signers...)
+			v = &acc
+		}
+		snapshot.accounts[k] = v
+	}
+	for k, v := range l.trustlines {
+		if v != nil {
+			tl := *v
+			v = &tl
+		}
+		snapshot.trustlines[k] = v
+	}
+	for k, v := range l.data {
+		snapshot.data[k] = v
+	}
+	for k, v := range l.offers {
+		snapshot.offers[k] = v
+	}
+
+	return snapshot
+}
+
+// Restore discards the changes made since `snapshot` was taken.
+func (l *ledger) Restore(snapshot *ledger) {
+	l.accounts = snapshot.accounts
+	l.trustlines = snapshot.trustlines
+	l.data = snapshot.data
+	l.offers = snapshot.offers
+}
+
+// balanceError describes why a balance cannot be debited or credited.
+type balanceError int
+
+const (
+	balanceOK balanceError = iota
+	balanceNoTrust
+	balanceNotAuthorized
+	// balanceExceeded is returned when debiting more than the available
+	// balance, or when crediting more than the trustline limit.
+	balanceExceeded
+)
+
+// Debit removes `amount` of `asset` from the balance of `acc`.  Issuers have an
+// unlimited balance of their own assets.
+func (l *ledger) Debit(acc *account, asset xdr.Asset, amount int64) (balanceError, error) {
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		if acc.available(l.Header) < amount {
+			return balanceExceeded, nil
+		}
+		acc.Balance -= xdr.Int64(amount)
+		return balanceOK, nil
+	}
+
+	if isIssuer(acc, asset) {
+		return balanceOK, nil
+	}
+
+	tl, err := l.Trustline(acc.Accountid, asset)
+	if err != nil {
+		return balanceOK, err
+	}
+
+	switch {
+	case tl == nil:
+		return balanceNoTrust, nil
+	case !authorized(tl):
+		return balanceNotAuthorized, nil
+	case int64(tl.Balance)-int64(tl.SellingLiabilities) < amount:
+		return balanceExceeded, nil
+	}
+
+	tl.Balance -= xdr.Int64(amount)
+	return balanceOK, nil
+}
+
+// Credit adds `amount` of `asset` to the balance of `acc`.  Assets sent to
+// their issuer are burnt.
+func (l *ledger) Credit(acc *account, asset xdr.Asset, amount int64) (balanceError, error) {
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		if math.MaxInt64-int64(acc.Balance)-int64(acc.BuyingLiabilities) < amount {
+			return balanceExceeded, nil
+		}
+		acc.Balance += xdr.Int64(amount)
+		return balanceOK, nil
+	}
+
+	if isIssuer(acc, asset) {
+		return balanceOK, nil
+	}
+
+	tl, err := l.Trustline(acc.Accountid, asset)
+	if err != nil {
+		return balanceOK, err
+	}
+
+	switch {
+	case tl == nil:
+		return balanceNoTrust, nil
+	case !authorized(tl):
+		return balanceNotAuthorized, nil
+	case int64(tl.Tlimit)-int64(tl.Balance)-int64(tl.BuyingLiabilities) < amount:
+		return balanceExceeded, nil
+	}
+
+	tl.Balance += xdr.Int64(amount)
+	return balanceOK, nil
+}
+
+// Holds returns the same errors as Debit for a positive amount, without
+// changing any balance.
+func (l *ledger) Holds(acc *account, asset xdr.Asset) (balanceError, error) {
+	if asset.Type == xdr.AssetTypeAssetTypeNative {
+		if acc.available(l.Header) <= 0 {
+			return balanceExceeded, nil
+		}
+		return balanceOK, nil
+	}
+
+	if isIssuer(acc, asset) {
+		return balanceOK, nil
+	}
+
+	tl, err := l.Trustline(acc.Accountid, asset)
+	if err != nil {
+		return balanceOK, err
+	}
+
+	switch {
+	case tl == nil:
+		return balanceNoTrust, nil
+	case !authorized(tl):
+		return balanceNotAuthorized, nil
+	case int64(tl.Balance)-int64(tl.SellingLiabilities) <= 0:
+		return balanceExceeded, nil
+	}
+
+	return balanceOK, nil
+}
+
+// isIssuer returns true if `acc` issues the credit `asset`.
+func isIssuer(acc *account, asset xdr.Asset) bool {
+	return asset.Type != xdr.AssetTypeAssetTypeNative && issuerOf(asset) == acc.Accountid
+}
+
+// issuerOf returns the address of the issuer of `asset`, or an empty string
+// for lumens.
+func issuerOf(asset xdr.Asset) string {
+	var (
+		typ    xdr.AssetType
+		issuer string
+	)
+	if err := asset.Extract(&typ, nil, &issuer); err != nil {
+		return ""
+	}
+	return issuer
+}
@@ -0,0 +1,134 @@
+// Package txsim predicts the results of transactions without submitting them
+// to the network.
+//
+// A simulation follows the checks stellar-core runs on a transaction, against
+// the ledger entries provided by a LedgerState (usually the stellar-core
+// database, see CoreState).  First, the transaction is validated: time bounds,
+// fee, source account, sequence number, signatures and the well-formedness of
+// its operations.  Failures at this stage are reported with the transaction
+// result code only, or with `tx_failed` and the result code of each operation.
+// Then the fee is charged, the sequence number consumed and every operation is
+// applied in order to an in-memory copy of the entries it touches, so that
+// later operations observe the effects of earlier ones.  Operations that fail
+// leave the copy untouched.
+//
+// The simulation does not cross offers: path payments and offers are checked
+// for trustlines, authorization and balances, but not for order book depth
+// (e.g. `op_too_few_offers` or `op_cross_self` are never predicted).  Ledger
+// entries may also change between a simulation and the inclusion of the
+// transaction in a ledger, so results are predictions and not guarantees.
+package txsim
+
+import (
+	"encoding/hex"
+	"time"
+
+	"github.com/cowry-network/go/network"
+	"github.com/cowry-network/go/services/horizon/internal/codes"
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/support/errors"
+	"github.com/cowry-network/go/xdr"
+)
+
+// LedgerState provides the ledger entries transactions are simulated against.
+// Entries that do not exist are returned as nil, without error.
+type LedgerState interface {
+	// Header returns the header of the latest closed ledger.
+	Header() (xdr.LedgerHeader, error)
+	Account(address string) (*core.Account, error)
+	Signers(address string) ([]core.Signer, error)
+	Trustline(address string, asset xdr.Asset) (*core.Trustline, error)
+	Offer(id int64) (*core.Offer, error)
+	Data(address, name string) (*core.AccountData, error)
+}
+
+// Simulator predicts the results of transactions against the current state of
+// the ledger.
+type Simulator struct {
+	State             LedgerState
+	NetworkPassphrase string
+
+	// Now returns the time time bounds are checked against, it defaults to
+	// time.Now.
+	Now func() time.Time
+}
+
+// Result is the predicted result of a transaction.
+type Result struct {
+	// Hash is the hex encoded hash of the transaction.
+	Hash string
+
+	// Result has the transaction result code and, when the transaction is
+	// applied or fails on one of its operations, the result code of each
+	// operation.
+	Result xdr.TransactionResult
+}
+
+// Successful returns true if the transaction is predicted to succeed.
+func (r Result) Successful() bool {
+	return r.Result.Result.Code == xdr.TransactionResultCodeTxSuccess
+}
+
+// TransactionResultCode returns the result code of the transaction, using the
+// same names as the results of submitted transactions.
+func (r Result) TransactionResultCode() (string, error) {
+	return codes.String(r.Result.Result.Code)
+}
+
+// OperationResultCodes returns the result code of each operation, if any.
+func (r Result) OperationResultCodes() ([]string, error) {
+	if r.Result.Result.Results == nil {
+		return nil, nil
+	}
+
+	results := *r.Result.Result.Results
+	ret := make([]string, len(results))
+	for i, opr := range results {
+		code, err := codes.ForOperationResult(opr)
+		if err != nil {
+			return nil, err
+		}
+		ret[i] = code
+	}
+
+	return ret, nil
+}
+
+// Simulate predicts the result of `env`.
+func (s *Simulator) Simulate(env xdr.TransactionEnvelope) (Result, error) {
+	hash, err := network.HashTransaction(&env.Tx, s.NetworkPassphrase)
+	if err != nil {
+		return Result{}, errors.Wrap(err, "failed to hash transaction")
+	}
+
+	header, err := s.State.Header()
+	if err != nil {
+		return Result{}, errors.Wrap(err, "failed to load ledger header")
+	}
+
+	now := time.Now
+	if s.Now != nil {
+		now = s.Now
+	}
+
+	sim := &simulation{
+		Env:    env,
+		Now:    now(),
+		Ledger: newLedger(s.State, header),
+		Signatures: signatureChecker{
+			Hash:       hash,
+			Signatures: env.Signatures,
+			Used:       make([]bool, len(env.Signatures)),
+		},
+	}
+
+	result, err := sim.Run()
+	if err != nil {
+		return Result{}, err
+	}
+
+	return Result{
+		Hash:   hex.EncodeToString(hash[:]),
+		Result: result,
+	}, nil
+}
@@ -0,0 +1,284 @@
+package txsim
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/build"
+	"github.com/cowry-network/go/keypair"
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/xdr"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulate_Payment(t *testing.T) {
+	state := newTestState()
+	alice := state.addAccount(t, 1000000000)
+	bob := state.addAccount(t, 1000000000)
+
+	tx := transaction(t, alice, 1,
+		build.Payment(
+			build.Destination{AddressOrSeed: bob.Address()},
+			build.NativeAmount{Amount: "10"},
+		),
+	)
+	result := simulate(t, state, tx, alice)
+	assert.True(t, result.Successful())
+	assert.Equal(t, xdr.Int64(100), result.Result.FeeCharged)
+	assertCodes(t, result, "tx_success", "op_success")
+
+	hash, err := tx.HashHex()
+	require.NoError(t, err)
+	assert.Equal(t, hash, result.Hash)
+
+	tx = transaction(t, alice, 1,
+		build.Payment(
+			build.Destination{AddressOrSeed: bob.Address()},
+			build.NativeAmount{Amount: "1000"},
+		),
+	)
+	result = simulate(t, state, tx, alice)
+	assert.False(t, result.Successful())
+	assertCodes(t, result, "tx_failed", "op_underfunded")
+
+	tx = transaction(t, alice, 1,
+		build.Payment(
+			build.Destination{AddressOrSeed: randomKey(t).Address()},
+			build.NativeAmount{Amount: "10"},
+		),
+	)
+	assertCodes(t, simulate(t, state, tx, alice), "tx_failed", "op_no_destination")
+}
+
+func TestSimulate_TransactionCodes(t *testing.T) {
+	state := newTestState()
+	alice := state.addAccount(t, 1000000000)
+	bob := state.addAccount(t, 1000000000)
+
+	payment := build.Payment(
+		build.Destination{AddressOrSeed: bob.Address()},
+		build.NativeAmount{Amount: "10"},
+	)
+
+	testCases := []struct {
+		name    string
+		source  *keypair.Full
+		seq     int64
+		mut     build.TransactionMutator
+		signers []*keypair.Full
+		code    string
+	}{
+		{"bad seq", alice, 3, nil, []*keypair.Full{alice}, "tx_bad_seq"},
+		{"no source account", randomKey(t), 1, nil, nil, "tx_no_source_account"},
+		{"insufficient fee", alice, 1, build.BaseFee{Amount: 10}, []*keypair.Full{alice}, "tx_insufficient_fee"},
+		{"too late", alice, 1, build.Timebounds{MaxTime: 1}, []*keypair.Full{alice}, "tx_too_late"},
+		{"bad auth", alice, 1, nil, []*keypair.Full{bob}, "tx_bad_auth"},
+		{"bad auth extra", alice, 1, nil, []*keypair.Full{alice, bob}, "tx_bad_auth_extra"},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			muts := []build.TransactionMutator{payment}
+			if tc.mut != nil {
+				muts = append(muts, tc.mut)
+			}
+
+			signers := tc.signers
+			if signers == nil {
+				signers = []*keypair.Full{tc.source}
+			}
+
+			tx := transaction(t, tc.source, tc.seq, muts...)
+			result := simulate(t, state, tx, signers...)
+			assert.False(t, result.Successful())
+			assertCodes(t, result, tc.code)
+		})
+	}
+}
+
+func TestSimulate_SequentialOperations(t *testing.T) {
+	state := newTestState()
+	alice := state.addAccount(t, 1000000000)
+	issuer := state.addAccount(t, 1000000000)
+
+	// the trustline created by the first operation receives the payment of the
+	// second one
+	tx := transaction(t, alice, 1,
+		build.Trust("USD", issuer.Address()),
+		build.Payment(
+			build.SourceAccount{AddressOrSeed: issuer.Address()},
+			build.Destination{AddressOrSeed: alice.Address()},
+			build.CreditAmount{Code: "USD", Issuer: issuer.Address(), Amount: "10"},
+		),
+	)
+	assertCodes(t, simulate(t, state, tx, alice, issuer), "tx_success", "op_success", "op_success")
+
+	tx = transaction(t, alice, 1,
+		build.Payment(
+			build.SourceAccount{AddressOrSeed: issuer.Address()},
+			build.Destination{AddressOrSeed: alice.Address()},
+			build.CreditAmount{Code: "USD", Issuer: issuer.Address(), Amount: "10"},
+		),
+	)
+	assertCodes(t, simulate(t, state, tx, alice, issuer), "tx_failed", "op_no_trust")
+
+	// the changes of failed operations are discarded
+	pay := func(amount string) build.PaymentBuilder {
+		return build.Payment(
+			build.Destination{AddressOrSeed: issuer.Address()},
+			build.NativeAmount{Amount: amount},
+		)
+	}
+	tx = transaction(t, alice, 1, pay("50"), pay("60"), pay("40"))
+	assertCodes(t, simulate(t, state, tx, alice),
+		"tx_failed", "op_success", "op_underfunded", "op_success",
+	)
+}
+
+func TestSimulate_Signers(t *testing.T) {
+	state := newTestState()
+	alice := state.addAccount(t, 1000000000)
+	bob := randomKey(t)
+
+	// a payment needs both the master key and bob
+	state.accounts[alice.Address()].Thresholds = xdr.Thresholds{1, 0, 2, 2}
+	state.signers[alice.Address()] = []core.Signer{
+		{Accountid: alice.Address(), Publickey: bob.Address(), Weight: 1},
+	}
+
+	tx := transaction(t, alice, 1,
+		build.Payment(
+			build.Destination{AddressOrSeed: alice.Address()},
+			build.NativeAmount{Amount: "10"},
+		),
+	)
+	assertCodes(t, simulate(t, state, tx, alice), "tx_failed", "op_bad_auth")
+	assertCodes(t, simulate(t, state, tx, alice, bob), "tx_success", "op_success")
+}
+
+// testState is an in-memory LedgerState.
+type testState struct {
+	header     xdr.LedgerHeader
+	accounts   map[string]*core.Account
+	signers    map[string][]core.Signer
+	trustlines map[string]*core.Trustline
+}
+
+func newTestState() *testState {
+	return &testState{
+		header: xdr.LedgerHeader{
+			LedgerSeq:   10,
+			BaseFee:     100,
+			BaseReserve: 5000000,
+		},
+		accounts:   map[string]*core.Account{},
+		signers:    map[string][]core.Signer{},
+		trustlines: map[string]*core.Trustline{},
+	}
+}
+
+// addAccount adds an account with sequence number 0 and `balance` stroops.
+func (s *testState) addAccount(t *testing.T, balance int64) *keypair.Full {
+	kp := randomKey(t)
+	s.accounts[kp.Address()] = &core.Account{
+		Accountid:  kp.Address(),
+		Balance:    xdr.Int64(balance),
+		Seqnum:     "0",
+		Thresholds: xdr.Thresholds{1, 0, 0, 0},
+	}
+	return kp
+}
+
+func (s *testState) Header() (xdr.LedgerHeader, error) {
+	return s.header, nil
+}
+
+func (s *testState) Account(address string) (*core.Account, error) {
+	acc, ok := s.accounts[address]
+	if !ok {
+		return nil, nil
+	}
+	row := *acc
+	return &row, nil
+}
+
+func (s *testState) Signers(address string) ([]core.Signer, error) {
+	return append([]core.Signer(nil), s.signers[address]...), nil
+}
+
+func (s *testState) Trustline(address string, asset xdr.Asset) (*core.Trustline, error) {
+	tl, ok := s.trustlines[address+":"+asset.String()]
+	if !ok {
+		return nil, nil
+	}
+	row := *tl
+	return &row, nil
+}
+
+func (s *testState) Offer(id int64) (*core.Offer, error) {
+	return nil, nil
+}
+
+func (s *testState) Data(address, name string) (*core.AccountData, error) {
+	return nil, nil
+}
+
+func transaction(
+	t *testing.T,
+	source *keypair.Full,
+	seq int64,
+	muts ...build.TransactionMutator,
+) *build.TransactionBuilder {
+	muts = append([]build.TransactionMutator{
+		build.SourceAccount{AddressOrSeed: source.Address()},
+		build.Sequence{Sequence: uint64(seq)},
+		build.TestNetwork,
+	}, muts...)
+
+	tx, err := build.Transaction(muts...)
+	require.NoError(t, err)
+	return tx
+}
+
+func simulate(
+	t *testing.T,
+	state LedgerState,
+	tx *build.TransactionBuilder,
+	signers ...*keypair.Full,
+) Result {
+	seeds := make([]string, len(signers))
+	for i, signer := range signers {
+		seeds[i] = signer.Seed()
+	}
+
+	txe, err := tx.Sign(seeds...)
+	require.NoError(t, err)
+
+	simulator := &Simulator{
+		State:             state,
+		NetworkPassphrase: build.TestNetwork.Passphrase,
+	}
+	result, err := simulator.Simulate(*txe.E)
+	require.NoError(t, err)
+	return result
+}
+
+func assertCodes(t *testing.T, result Result, txCode string, opCodes ...string) {
+	code, err := result.TransactionResultCode()
+	require.NoError(t, err)
+	assert.Equal(t, txCode, code)
+
+	ops, err := result.OperationResultCodes()
+	require.NoError(t, err)
+	if len(opCodes) == 0 {
+		assert.Empty(t, ops)
+	} else {
+		assert.Equal(t, opCodes, ops)
+	}
+}
+
+func randomKey(t *testing.T) *keypair.Full {
+	kp, err := keypair.Random()
+	require.NoError(t, err)
+	return kp
+}
@@ -0,0 +1,592 @@
+package txsim
+
+import (
+	"math"
+	"strings"
+
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/xdr"
+)
+
+// inflationStart and inflationFrequency schedule inflation runs, in seconds.
+const (
+	inflationStart     = 1404172800 // 2014-07-01T00:00:00Z
+	inflationFrequency = 60 * 60 * 24 * 7
+)
+
+// maxSigners is the maximum number of signers of an account, aside from its
+// master key.
+const maxSigners = 20
+
+// validate returns the result code of `op` when it is malformed, or its
+// success code, without looking at the ledger.
+func validate(op xdr.Operation, source string) interface{} {
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		ca := op.Body.MustCreateAccountOp()
+		if ca.StartingBalance <= 0 || ca.Destination.Address() == source {
+			return xdr.CreateAccountResultCodeCreateAccountMalformed
+		}
+		return xdr.CreateAccountResultCodeCreateAccountSuccess
+	case xdr.OperationTypePayment:
+		if op.Body.MustPaymentOp().Amount <= 0 {
+			return xdr.PaymentResultCodePaymentMalformed
+		}
+		return xdr.PaymentResultCodePaymentSuccess
+	case xdr.OperationTypePathPayment:
+		pp := op.Body.MustPathPaymentOp()
+		if pp.SendMax <= 0 || pp.DestAmount <= 0 {
+			return xdr.PathPaymentResultCodePathPaymentMalformed
+		}
+		return xdr.PathPaymentResultCodePathPaymentSuccess
+	case xdr.OperationTypeManageOffer:
+		mo := op.Body.MustManageOfferOp()
+		if mo.Amount < 0 ||
+			(mo.Amount == 0 && mo.OfferId == 0) ||
+			mo.Price.N <= 0 || mo.Price.D <= 0 ||
+			mo.Selling.Equals(mo.Buying) {
+			return xdr.ManageOfferResultCodeManageOfferMalformed
+		}
+		return xdr.ManageOfferResultCodeManageOfferSuccess
+	case xdr.OperationTypeCreatePassiveOffer:
+		po := op.Body.MustCreatePassiveOfferOp()
+		if po.Amount <= 0 ||
+			po.Price.N <= 0 || po.Price.D <= 0 ||
+			po.Selling.Equals(po.Buying) {
+			return xdr.ManageOfferResultCodeManageOfferMalformed
+		}
+		return xdr.ManageOfferResultCodeManageOfferSuccess
+	case xdr.OperationTypeSetOptions:
+		return validateSetOptions(op.Body.MustSetOptionsOp(), source)
+	case xdr.OperationTypeChangeTrust:
+		ct := op.Body.MustChangeTrustOp()
+		if ct.Limit < 0 ||
+			ct.Line.Type == xdr.AssetTypeAssetTypeNative ||
+			issuerOf(ct.Line) == source {
+			return xdr.ChangeTrustResultCodeChangeTrustMalformed
+		}
+		return xdr.ChangeTrustResultCodeChangeTrustSuccess
+	case xdr.OperationTypeAllowTrust:
+		at := op.Body.MustAllowTrustOp()
+		if at.Asset.Type == xdr.AssetTypeAssetTypeNative || at.Trustor.Address() == source {
+			return xdr.AllowTrustResultCodeAllowTrustMalformed
+		}
+		return xdr.AllowTrustResultCodeAllowTrustSuccess
+	case xdr.OperationTypeAccountMerge:
+		dest := op.Body.MustDestination()
+		if dest.Address() == source {
+			return xdr.AccountMergeResultCodeAccountMergeMalformed
+		}
+		return xdr.AccountMergeResultCodeAccountMergeSuccess
+	case xdr.OperationTypeInflation:
+		return xdr.InflationResultCodeInflationSuccess
+	case xdr.OperationTypeManageData:
+		if len(op.Body.MustManageDataOp().DataName) == 0 {
+			return xdr.ManageDataResultCodeManageDataInvalidName
+		}
+		return xdr.ManageDataResultCodeManageDataSuccess
+	case xdr.OperationTypeBumpSequence:
+		if op.Body.MustBumpSequenceOp().BumpTo < 0 {
+			return xdr.BumpSequenceResultCodeBumpSequenceBadSeq
+		}
+		return xdr.BumpSequenceResultCodeBumpSequenceSuccess
+	}
+
+	return nil
+}
+
+func validateSetOptions(so xdr.SetOptionsOp, source string) xdr.SetOptionsResultCode {
+	var setFlags, clearFlags uint32
+	if so.SetFlags != nil {
+		setFlags = uint32(*so.SetFlags)
+	}
+	if so.ClearFlags != nil {
+		clearFlags = uint32(*so.ClearFlags)
+	}
+
+	allFlags := uint32(xdr.AccountFlagsAuthRequiredFlag |
+		xdr.AccountFlagsAuthRevocableFlag |
+		xdr.AccountFlagsAuthImmutableFlag)
+
+	switch {
+	case (setFlags|clearFlags)&^allFlags != 0:
+		return xdr.SetOptionsResultCodeSetOptionsUnknownFlag
+	case setFlags&clearFlags != 0:
+		return xdr.SetOptionsResultCodeSetOptionsBadFlags
+	}
+
+	for _, threshold := range []*xdr.Uint32{
+		so.MasterWeight,
+		so.LowThreshold,
+		so.MedThreshold,
+		so.HighThreshold,
+	} {
+		if threshold != nil && *threshold > 255 {
+			return xdr.SetOptionsResultCodeSetOptionsThresholdOutOfRange
+		}
+	}
+
+	if so.Signer != nil {
+		if so.Signer.Key.Address() == source {
+			return xdr.SetOptionsResultCodeSetOptionsBadSigner
+		}
+		if so.Signer.Weight > 255 {
+			return xdr.SetOptionsResultCodeSetOptionsThresholdOutOfRange
+		}
+	}
+
+	return xdr.SetOptionsResultCodeSetOptionsSuccess
+}
+
+// apply applies `op` to the ledger, on behalf of `source`, and returns its
+// result code.
+func (sim *simulation) apply(op xdr.Operation, source *account) (interface{}, error) {
+	switch op.Body.Type {
+	case xdr.OperationTypeCreateAccount:
+		return sim.createAccount(op.Body.MustCreateAccountOp(), source)
+	case xdr.OperationTypePayment:
+		return sim.payment(op.Body.MustPaymentOp(), source)
+	case xdr.OperationTypePathPayment:
+		return sim.pathPayment(op.Body.MustPathPaymentOp(), source)
+	case xdr.OperationTypeManageOffer:
+		mo := op.Body.MustManageOfferOp()
+		return sim.manageOffer(mo.Selling, mo.Buying, int64(mo.Amount), int64(mo.OfferId), source)
+	case xdr.OperationTypeCreatePassiveOffer:
+		po := op.Body.MustCreatePassiveOfferOp()
+		return sim.manageOffer(po.Selling, po.Buying, int64(po.Amount), 0, source)
+	case xdr.OperationTypeSetOptions:
+		return sim.setOptions(op.Body.MustSetOptionsOp(), source)
+	case xdr.OperationTypeChangeTrust:
+		return sim.changeTrust(op.Body.MustChangeTrustOp(), source)
+	case xdr.OperationTypeAllowTrust:
+		return sim.allowTrust(op.Body.MustAllowTrustOp(), source)
+	case xdr.OperationTypeAccountMerge:
+		return sim.accountMerge(op.Body.MustDestination(), source)
+	case xdr.OperationTypeInflation:
+		return sim.inflation()
+	case xdr.OperationTypeManageData:
+		return sim.manageData(op.Body.MustManageDataOp(), source)
+	case xdr.OperationTypeBumpSequence:
+		bs := op.Body.MustBumpSequenceOp()
+		if int64(bs.BumpTo) > source.Seq {
+			source.Seq = int64(bs.BumpTo)
+		}
+		return xdr.BumpSequenceResultCodeBumpSequenceSuccess, nil
+	}
+
+	return nil, nil
+}
+
+func (sim *simulation) createAccount(op xdr.CreateAccountOp, source *account) (interface{}, error) {
+	address := op.Destination.Address()
+	dest, err := sim.Ledger.Account(address)
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case dest != nil:
+		return xdr.CreateAccountResultCodeCreateAccountAlreadyExist, nil
+	case int64(op.StartingBalance) < 2*int64(sim.Ledger.Header.BaseReserve):
+		return xdr.CreateAccountResultCodeCreateAccountLowReserve, nil
+	case source.available(sim.Ledger.Header) < int64(op.StartingBalance):
+		return xdr.CreateAccountResultCodeCreateAccountUnderfunded, nil
+	}
+
+	source.Balance -= op.StartingBalance
+	sim.Ledger.CreateAccount(address, int64(op.StartingBalance))
+	return xdr.CreateAccountResultCodeCreateAccountSuccess, nil
+}
+
+func (sim *simulation) payment(op xdr.PaymentOp, source *account) (interface{}, error) {
+	dest, err := sim.Ledger.Account(op.Destination.Address())
+	if err != nil {
+		return nil, err
+	}
+	if dest == nil {
+		return xdr.PaymentResultCodePaymentNoDestination, nil
+	}
+
+	credited, err := sim.Ledger.Credit(dest, op.Asset, int64(op.Amount))
+	if err != nil {
+		return nil, err
+	}
+	switch credited {
+	case balanceNoTrust:
+		return xdr.PaymentResultCodePaymentNoTrust, nil
+	case balanceNotAuthorized:
+		return xdr.PaymentResultCodePaymentNotAuthorized, nil
+	case balanceExceeded:
+		return xdr.PaymentResultCodePaymentLineFull, nil
+	}
+
+	debited, err := sim.Ledger.Debit(source, op.Asset, int64(op.Amount))
+	if err != nil {
+		return nil, err
+	}
+	switch debited {
+	case balanceNoTrust:
+		return xdr.PaymentResultCodePaymentSrcNoTrust, nil
+	case balanceNotAuthorized:
+		return xdr.PaymentResultCodePaymentSrcNotAuthorized, nil
+	case balanceExceeded:
+		return xdr.PaymentResultCodePaymentUnderfunded, nil
+	}
+
+	return xdr.PaymentResultCodePaymentSuccess, nil
+}
+
+// pathPayment checks both ends of a path payment.  Offers are not crossed: the
+// source is debited the destination amount when both assets are the same, and
+// is only checked for holding the send asset otherwise.
+func (sim *simulation) pathPayment(op xdr.PathPaymentOp, source *account) (interface{}, error) {
+	dest, err := sim.Ledger.Account(op.Destination.Address())
+	if err != nil {
+		return nil, err
+	}
+	if dest == nil {
+		return xdr.PathPaymentResultCodePathPaymentNoDestination, nil
+	}
+
+	credited, err := sim.Ledger.Credit(dest, op.DestAsset, int64(op.DestAmount))
+	if err != nil {
+		return nil, err
+	}
+	switch credited {
+	case balanceNoTrust:
+		return xdr.PathPaymentResultCodePathPaymentNoTrust, nil
+	case balanceNotAuthorized:
+		return xdr.PathPaymentResultCodePathPaymentNotAuthorized, nil
+	case balanceExceeded:
+		return xdr.PathPaymentResultCodePathPaymentLineFull, nil
+	}
+
+	var debited balanceError
+	if op.SendAsset.Equals(op.DestAsset) && len(op.Path) == 0 {
+		if op.DestAmount > op.SendMax {
+			return xdr.PathPaymentResultCodePathPaymentOverSendmax, nil
+		}
+		debited, err = sim.Ledger.Debit(source, op.SendAsset, int64(op.DestAmount))
+	} else {
+		debited, err = sim.Ledger.Holds(source, op.SendAsset)
+	}
+	if err != nil {
+		return nil, err
+	}
+	switch debited {
+	case balanceNoTrust:
+		return xdr.PathPaymentResultCodePathPaymentSrcNoTrust, nil
+	case balanceNotAuthorized:
+		return xdr.PathPaymentResultCodePathPaymentSrcNotAuthorized, nil
+	case balanceExceeded:
+		return xdr.PathPaymentResultCodePathPaymentUnderfunded, nil
+	}
+
+	return xdr.PathPaymentResultCodePathPaymentSuccess, nil
+}
+
+// manageOffer checks the creation, update or removal of an offer.  Offers are
+// not crossed, new offers are assumed to remain in the order book.
+func (sim *simulation) manageOffer(
+	selling, buying xdr.Asset,
+	amount, offerID int64,
+	source *account,
+) (interface{}, error) {
+	if offerID != 0 {
+		offer, err := sim.Ledger.Offer(offerID)
+		if err != nil {
+			return nil, err
+		}
+		if offer == nil || offer.SellerID != source.Accountid {
+			return xdr.ManageOfferResultCodeManageOfferNotFound, nil
+		}
+	}
+
+	if amount == 0 {
+		sim.Ledger.RemoveOffer(offerID)
+		source.Numsubentries--
+		return xdr.ManageOfferResultCodeManageOfferSuccess, nil
+	}
+
+	held, err := sim.Ledger.Holds(source, selling)
+	if err != nil {
+		return nil, err
+	}
+	switch held {
+	case balanceNoTrust:
+		return xdr.ManageOfferResultCodeManageOfferSellNoTrust, nil
+	case balanceNotAuthorized:
+		return xdr.ManageOfferResultCodeManageOfferSellNotAuthorized, nil
+	}
+
+	if buying.Type != xdr.AssetTypeAssetTypeNative && !isIssuer(source, buying) {
+		tl, err := sim.Ledger.Trustline(source.Accountid, buying)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case tl == nil:
+			return xdr.ManageOfferResultCodeManageOfferBuyNoTrust, nil
+		case !authorized(tl):
+			return xdr.ManageOfferResultCodeManageOfferBuyNotAuthorized, nil
+		}
+	}
+
+	if offerID == 0 && !source.canAddSubEntry(sim.Ledger.Header) {
+		return xdr.ManageOfferResultCodeManageOfferLowReserve, nil
+	}
+
+	if held == balanceExceeded {
+		return xdr.ManageOfferResultCodeManageOfferUnderfunded, nil
+	}
+
+	if offerID == 0 {
+		source.Numsubentries++
+	}
+	return xdr.ManageOfferResultCodeManageOfferSuccess, nil
+}
+
+func (sim *simulation) setOptions(op xdr.SetOptionsOp, source *account) (interface{}, error) {
+	if op.InflationDest != nil {
+		dest, err := sim.Ledger.Account(op.InflationDest.Address())
+		if err != nil {
+			return nil, err
+		}
+		if dest == nil {
+			return xdr.SetOptionsResultCodeSetOptionsInvalidInflation, nil
+		}
+	}
+
+	if (op.SetFlags != nil || op.ClearFlags != nil) && source.IsAuthImmutable() {
+		return xdr.SetOptionsResultCodeSetOptionsCantChange, nil
+	}
+	if op.SetFlags != nil {
+		source.Flags |= xdr.AccountFlags(*op.SetFlags)
+	}
+	if op.ClearFlags != nil {
+		source.Flags &^= xdr.AccountFlags(*op.ClearFlags)
+	}
+
+	if op.MasterWeight != nil {
+		source.Thresholds[0] = byte(*op.MasterWeight)
+	}
+	if op.LowThreshold != nil {
+		source.Thresholds[thresholdLow] = byte(*op.LowThreshold)
+	}
+	if op.MedThreshold != nil {
+		source.Thresholds[thresholdMedium] = byte(*op.MedThreshold)
+	}
+	if op.HighThreshold != nil {
+		source.Thresholds[thresholdHigh] = byte(*op.HighThreshold)
+	}
+
+	if op.Signer == nil {
+		return xdr.SetOptionsResultCodeSetOptionsSuccess, nil
+	}
+
+	key := op.Signer.Key.Address()
+	weight := int32(op.Signer.Weight)
+
+	for i, signer := range source.Signers {
+		if signer.Publickey != key {
+			continue
+		}
+
+		if weight == 0 {
+			source.Signers = append(source.Signers[:i], source.Signers[i+1:]...)
+			source.Numsubentries--
+		} else {
+			source.Signers[i].Weight = weight
+		}
+		return xdr.SetOptionsResultCodeSetOptionsSuccess, nil
+	}
+
+	if weight == 0 {
+		// removing a signer the account does not have
+		return xdr.SetOptionsResultCodeSetOptionsSuccess, nil
+	}
+
+	if len(source.Signers) >= maxSigners {
+		return xdr.SetOptionsResultCodeSetOptionsTooManySigners, nil
+	}
+	if !source.canAddSubEntry(sim.Ledger.Header) {
+		return xdr.SetOptionsResultCodeSetOptionsLowReserve, nil
+	}
+
+	source.Signers = append(source.Signers, core.Signer{
+		Accountid: source.Accountid,
+		Publickey: key,
+		Weight:    weight,
+	})
+	source.Numsubentries++
+	return xdr.SetOptionsResultCodeSetOptionsSuccess, nil
+}
+
+func (sim *simulation) changeTrust(op xdr.ChangeTrustOp, source *account) (interface{}, error) {
+	tl, err := sim.Ledger.Trustline(source.Accountid, op.Line)
+	if err != nil {
+		return nil, err
+	}
+
+	if tl != nil {
+		switch {
+		case op.Limit == 0 && (tl.Balance != 0 || tl.BuyingLiabilities != 0):
+			return xdr.ChangeTrustResultCodeChangeTrustInvalidLimit, nil
+		case op.Limit == 0:
+			sim.Ledger.SetTrustline(source.Accountid, op.Line, nil)
+			source.Numsubentries--
+			return xdr.ChangeTrustResultCodeChangeTrustSuccess, nil
+		case op.Limit < tl.Balance+tl.BuyingLiabilities:
+			return xdr.ChangeTrustResultCodeChangeTrustInvalidLimit, nil
+		}
+
+		tl.Tlimit = op.Limit
+		return xdr.ChangeTrustResultCodeChangeTrustSuccess, nil
+	}
+
+	if op.Limit == 0 {
+		// removing a trustline the account does not have
+		return xdr.ChangeTrustResultCodeChangeTrustInvalidLimit, nil
+	}
+
+	issuer, err := sim.Ledger.Account(issuerOf(op.Line))
+	if err != nil {
+		return nil, err
+	}
+	if issuer == nil {
+		return xdr.ChangeTrustResultCodeChangeTrustNoIssuer, nil
+	}
+
+	if !source.canAddSubEntry(sim.Ledger.Header) {
+		return xdr.ChangeTrustResultCodeChangeTrustLowReserve, nil
+	}
+
+	var (
+		typ  xdr.AssetType
+		code string
+	)
+	if err := op.Line.Extract(&typ, &code, nil); err != nil {
+		return nil, err
+	}
+
+	tl = &core.Trustline{
+		Accountid: source.Accountid,
+		Assettype: typ,
+		Issuer:    issuer.Accountid,
+		Assetcode: code,
+		Tlimit:    op.Limit,
+	}
+	if !issuer.IsAuthRequired() {
+		tl.Flags = int32(xdr.TrustLineFlagsAuthorizedFlag)
+	}
+
+	sim.Ledger.SetTrustline(source.Accountid, op.Line, tl)
+	source.Numsubentries++
+	return xdr.ChangeTrustResultCodeChangeTrustSuccess, nil
+}
+
+func (sim *simulation) allowTrust(op xdr.AllowTrustOp, source *account) (interface{}, error) {
+	if !source.IsAuthRequired() {
+		return xdr.AllowTrustResultCodeAllowTrustTrustNotRequired, nil
+	}
+	if !op.Authorize && !source.IsAuthRevocable() {
+		return xdr.AllowTrustResultCodeAllowTrustCantRevoke, nil
+	}
+
+	var (
+		issuer xdr.AccountId
+		asset  xdr.Asset
+	)
+	if err := issuer.SetAddress(source.Accountid); err != nil {
+		return nil, err
+	}
+	if err := asset.SetCredit(allowTrustCode(op.Asset), issuer); err != nil {
+		return xdr.AllowTrustResultCodeAllowTrustMalformed, nil
+	}
+
+	tl, err := sim.Ledger.Trustline(op.Trustor.Address(), asset)
+	if err != nil {
+		return nil, err
+	}
+	if tl == nil {
+		return xdr.AllowTrustResultCodeAllowTrustNoTrustLine, nil
+	}
+
+	if op.Authorize {
+		tl.Flags |= int32(xdr.TrustLineFlagsAuthorizedFlag)
+	} else {
+		tl.Flags &^= int32(xdr.TrustLineFlagsAuthorizedFlag)
+	}
+	return xdr.AllowTrustResultCodeAllowTrustSuccess, nil
+}
+
+// allowTrustCode returns the asset code of an allow trust operation.
+func allowTrustCode(asset xdr.AllowTrustOpAsset) string {
+	var code []byte
+	switch asset.Type {
+	case xdr.AssetTypeAssetTypeCreditAlphanum4:
+		raw := asset.MustAssetCode4()
+		code = raw[:]
+	case xdr.AssetTypeAssetTypeCreditAlphanum12:
+		raw := asset.MustAssetCode12()
+		code = raw[:]
+	}
+	return strings.TrimRight(string(code), "\x00")
+}
+
+func (sim *simulation) accountMerge(destination xdr.AccountId, source *account) (interface{}, error) {
+	dest, err := sim.Ledger.Account(destination.Address())
+	if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case dest == nil:
+		return xdr.AccountMergeResultCodeAccountMergeNoAccount, nil
+	case source.IsAuthImmutable():
+		return xdr.AccountMergeResultCodeAccountMergeImmutableSet, nil
+	case int(source.Numsubentries) > len(source.Signers):
+		return xdr.AccountMergeResultCodeAccountMergeHasSubEntries, nil
+	case int64(dest.Balance) > math.MaxInt64-int64(source.Balance):
+		return xdr.AccountMergeResultCodeAccountMergeDestFull, nil
+	}
+
+	dest.Balance += source.Balance
+	sim.Ledger.RemoveAccount(source.Accountid)
+	return xdr.AccountMergeResultCodeAccountMergeSuccess, nil
+}
+
+func (sim *simulation) inflation() (interface{}, error) {
+	header := sim.Ledger.Header
+	next := int64(inflationStart) + int64(header.InflationSeq)*inflationFrequency
+	if int64(header.ScpValue.CloseTime) < next {
+		return xdr.InflationResultCodeInflationNotTime, nil
+	}
+	return xdr.InflationResultCodeInflationSuccess, nil
+}
+
+func (sim *simulation) manageData(op xdr.ManageDataOp, source *account) (interface{}, error) {
+	name := string(op.DataName)
+	exists, err := sim.Ledger.HasData(source.Accountid, name)
+	if err != nil {
+		return nil, err
+	}
+
+	if op.DataValue == nil {
+		if !exists {
+			return xdr.ManageDataResultCodeManageDataNameNotFound, nil
+		}
+		sim.Ledger.SetData(source.Accountid, name, false)
+		source.Numsubentries--
+		return xdr.ManageDataResultCodeManageDataSuccess, nil
+	}
+
+	if !exists {
+		if !source.canAddSubEntry(sim.Ledger.Header) {
+			return xdr.ManageDataResultCodeManageDataLowReserve, nil
+		}
+		sim.Ledger.SetData(source.Accountid, name, true)
+		source.Numsubentries++
+	}
+	return xdr.ManageDataResultCodeManageDataSuccess, nil
+}
@@ -0,0 +1,143 @@
+package txsim
+
+import (
+	"bytes"
+	"crypto/sha256"
+
+	"github.com/cowry-network/go/keypair"
+	"github.com/cowry-network/go/services/horizon/internal/db2/core"
+	"github.com/cowry-network/go/strkey"
+	"github.com/cowry-network/go/xdr"
+)
+
+// Threshold levels, as indexes of xdr.Thresholds.
+const (
+	thresholdLow    = 1
+	thresholdMedium = 2
+	thresholdHigh   = 3
+)
+
+// signatureChecker checks the signatures of a transaction against the signers
+// of the accounts it acts on, and keeps track of the signatures used.
+type signatureChecker struct {
+	Hash       [32]byte
+	Signatures []xdr.DecoratedSignature
+	Used       []bool
+}
+
+// Check returns true if the signers of `acc` that signed the transaction meet
+// the threshold at `level`.
+func (c *signatureChecker) Check(acc *account, level int) bool {
+	needed := int32(acc.Thresholds[level])
+
+	signers := make([]core.Signer, 0, len(acc.Signers)+1)
+	if master := int32(acc.Thresholds[0]); master > 0 {
+		signers = append(signers, core.Signer{
+			Accountid: acc.Accountid,
+			Publickey: acc.Accountid,
+			Weight:    master,
+		})
+	}
+	signers = append(signers, acc.Signers...)
+
+	var total int32
+	for _, signer := range signers {
+		if !c.signedBy(signer.Publickey) {
+			continue
+		}
+
+		total += signer.Weight
+		if total > 255 {
+			total = 255
+		}
+		if total >= needed {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AllUsed returns true if every signature of the transaction was used by a
+// Check.
+func (c *signatureChecker) AllUsed() bool {
+	for _, used := range c.Used {
+		if !used {
+			return false
+		}
+	}
+	return true
+}
+
+// signedBy returns true if the transaction is signed by the signer `key`:
+// ed25519 keys need a valid signature, hash(x) keys the preimage of their hash
+// and pre-authorized transaction keys are the hash of the transaction.
+func (c *signatureChecker) signedBy(key string) bool {
+	version, err := strkey.Version(key)
+	if err != nil {
+		return false
+	}
+
+	switch version {
+	case strkey.VersionByteHashTx:
+		raw, err := strkey.Decode(strkey.VersionByteHashTx, key)
+		return err == nil && bytes.Equal(raw, c.Hash[:])
+	case strkey.VersionByteHashX:
+		raw, err := strkey.Decode(strkey.VersionByteHashX, key)
+		if err != nil || len(raw) != 32 {
+			return false
+		}
+
+		for i, sig := range c.Signatures {
+			if !bytes.Equal(sig.Hint[:], raw[28:]) {
+				continue
+			}
+			if hash := sha256.Sum256(sig.Signature); bytes.Equal(hash[:], raw) {
+				c.Used[i] = true
+				return true
+			}
+		}
+	case strkey.VersionByteAccountID:
+		kp, err := keypair.Parse(key)
+		if err != nil {
+			return false
+		}
+
+		hint := kp.Hint()
+		for i, sig := range c.Signatures {
+			if sig.Hint != xdr.SignatureHint(hint) {
+				continue
+			}
+			if kp.Verify(c.Hash[:], sig.Signature) == nil {
+				c.Used[i] = true
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// thresholdLevel returns the threshold level the source account of `op` needs
+// to meet.
+func thresholdLevel(op xdr.Operation) int {
+	switch op.Body.Type {
+	case xdr.OperationTypeAllowTrust,
+		xdr.OperationTypeBumpSequence,
+		xdr.OperationTypeInflation:
+		return thresholdLow
+	case xdr.OperationTypeAccountMerge:
+		return thresholdHigh
+	case xdr.OperationTypeSetOptions:
+		so := op.Body.MustSetOptionsOp()
+		if so.MasterWeight != nil ||
+			so.LowThreshold != nil ||
+			so.MedThreshold != nil ||
+			so.HighThreshold != nil ||
+			so.Signer != nil {
+			return thresholdHigh
+		}
+	}
+
+	return thresholdMedium
+}
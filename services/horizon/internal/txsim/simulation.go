@@ -0,0 +1,234 @@
+package txsim
+
+import (
+	"time"
+
+	"github.com/cowry-network/go/services/horizon/internal/codes"
+	"github.com/cowry-network/go/xdr"
+)
+
+// simulation is the state of a single call to Simulator.Simulate.
+type simulation struct {
+	Env        xdr.TransactionEnvelope
+	Now        time.Time
+	Ledger     *ledger
+	Signatures signatureChecker
+}
+
+// Run returns the predicted result of the transaction.
+func (sim *simulation) Run() (xdr.TransactionResult, error) {
+	tx := sim.Env.Tx
+	fee := int64(sim.Ledger.Header.BaseFee) * int64(len(tx.Operations))
+	if fee > int64(tx.Fee) {
+		fee = int64(tx.Fee)
+	}
+	result := xdr.TransactionResult{FeeCharged: xdr.Int64(fee)}
+
+	code, err := sim.checkTransaction()
+	if err != nil {
+		return result, err
+	}
+	if code != xdr.TransactionResultCodeTxSuccess {
+		result.Result.Code = code
+		return result, nil
+	}
+
+	results, ok, err := sim.checkOperations()
+	if err != nil {
+		return result, err
+	}
+	if !ok {
+		result.Result.Code = xdr.TransactionResultCodeTxFailed
+		result.Result.Results = &results
+		return result, nil
+	}
+
+	if !sim.Signatures.AllUsed() {
+		result.Result.Code = xdr.TransactionResultCodeTxBadAuthExtra
+		return result, nil
+	}
+
+	// the fee is charged and the sequence number consumed even if an operation
+	// fails
+	source, err := sim.Ledger.Account(tx.SourceAccount.Address())
+	if err != nil {
+		return result, err
+	}
+	source.Balance -= xdr.Int64(fee)
+	source.Seq = int64(tx.SeqNum)
+
+	results, ok, err = sim.applyOperations()
+	if err != nil {
+		return result, err
+	}
+
+	result.Result.Code = xdr.TransactionResultCodeTxSuccess
+	if !ok {
+		result.Result.Code = xdr.TransactionResultCodeTxFailed
+	}
+	result.Result.Results = &results
+	return result, nil
+}
+
+// checkTransaction runs the checks on the transaction itself, and returns
+// TransactionResultCodeTxSuccess if they pass.
+func (sim *simulation) checkTransaction() (xdr.TransactionResultCode, error) {
+	tx := sim.Env.Tx
+	header := sim.Ledger.Header
+
+	if len(tx.Operations) == 0 {
+		return xdr.TransactionResultCodeTxMissingOperation, nil
+	}
+
+	if tb := tx.TimeBounds; tb != nil {
+		now := uint64(sim.Now.Unix())
+		if uint64(tb.MinTime) > now {
+			return xdr.TransactionResultCodeTxTooEarly, nil
+		}
+		if tb.MaxTime != 0 && uint64(tb.MaxTime) < now {
+			return xdr.TransactionResultCodeTxTooLate, nil
+		}
+	}
+
+	if int64(tx.Fee) < int64(header.BaseFee)*int64(len(tx.Operations)) {
+		return xdr.TransactionResultCodeTxInsufficientFee, nil
+	}
+
+	source, err := sim.Ledger.Account(tx.SourceAccount.Address())
+	if err != nil {
+		return 0, err
+	}
+	if source == nil {
+		return xdr.TransactionResultCodeTxNoAccount, nil
+	}
+
+	if int64(tx.SeqNum) != source.Seq+1 {
+		return xdr.TransactionResultCodeTxBadSeq, nil
+	}
+
+	if !sim.Signatures.Check(source, thresholdLow) {
+		return xdr.TransactionResultCodeTxBadAuth, nil
+	}
+
+	if source.available(header) < int64(tx.Fee) {
+		return xdr.TransactionResultCodeTxInsufficientBalance, nil
+	}
+
+	return xdr.TransactionResultCodeTxSuccess, nil
+}
+
+// checkOperations checks the source account, the signatures and the
+// well-formedness of every operation.  It returns false if any of them fails.
+func (sim *simulation) checkOperations() ([]xdr.OperationResult, bool, error) {
+	results := make([]xdr.OperationResult, len(sim.Env.Tx.Operations))
+	ok := true
+
+	for i, op := range sim.Env.Tx.Operations {
+		source, err := sim.Ledger.Account(sim.sourceAddress(op))
+		if err != nil {
+			return nil, false, err
+		}
+
+		switch {
+		case source == nil:
+			results[i] = xdr.OperationResult{Code: xdr.OperationResultCodeOpNoAccount}
+		case !sim.Signatures.Check(source, thresholdLevel(op)):
+			results[i] = xdr.OperationResult{Code: xdr.OperationResultCodeOpBadAuth}
+		default:
+			results[i] = operationResult(op.Body.Type, validate(op, source.Accountid))
+		}
+
+		if !succeeded(results[i]) {
+			ok = false
+		}
+	}
+
+	return results, ok, nil
+}
+
+// applyOperations applies every operation in order.  The changes of failed
+// operations are discarded.  It returns false if any operation fails.
+func (sim *simulation) applyOperations() ([]xdr.OperationResult, bool, error) {
+	results := make([]xdr.OperationResult, len(sim.Env.Tx.Operations))
+	ok := true
+
+	for i, op := range sim.Env.Tx.Operations {
+		snapshot := sim.Ledger.Snapshot()
+
+		source, err := sim.Ledger.Account(sim.sourceAddress(op))
+		if err != nil {
+			return nil, false, err
+		}
+
+		if source == nil {
+			// merged by a previous operation
+			results[i] = xdr.OperationResult{Code: xdr.OperationResultCodeOpNoAccount}
+		} else {
+			code, err := sim.apply(op, source)
+			if err != nil {
+				return nil, false, err
+			}
+			results[i] = operationResult(op.Body.Type, code)
+		}
+
+		if !succeeded(results[i]) {
+			sim.Ledger.Restore(snapshot)
+			ok = false
+		}
+	}
+
+	return results, ok, nil
+}
+
+// sourceAddress returns the address of the source account of `op`.
+func (sim *simulation) sourceAddress(op xdr.Operation) string {
+	if op.SourceAccount != nil {
+		return op.SourceAccount.Address()
+	}
+	return sim.Env.Tx.SourceAccount.Address()
+}
+
+// succeeded returns true if `r` is the result of a successful operation.
+func succeeded(r xdr.OperationResult) bool {
+	code, err := codes.ForOperationResult(r)
+	return err == nil && code == codes.OpSuccess
+}
+
+// operationResult returns the result of an operation of type `typ` with the
+// result code `code`, which must be of the result code type of `typ`.
+func operationResult(typ xdr.OperationType, code interface{}) xdr.OperationResult {
+	tr := xdr.OperationResultTr{Type: typ}
+
+	switch code := code.(type) {
+	case xdr.CreateAccountResultCode:
+		tr.CreateAccountResult = &xdr.CreateAccountResult{Code: code}
+	case xdr.PaymentResultCode:
+		tr.PaymentResult = &xdr.PaymentResult{Code: code}
+	case xdr.PathPaymentResultCode:
+		tr.PathPaymentResult = &xdr.PathPaymentResult{Code: code}
+	case xdr.ManageOfferResultCode:
+		if typ == xdr.OperationTypeCreatePassiveOffer {
+			tr.CreatePassiveOfferResult = &xdr.ManageOfferResult{Code: code}
+		} else {
+			tr.ManageOfferResult = &xdr.ManageOfferResult{Code: code}
+		}
+	case xdr.SetOptionsResultCode:
+		tr.SetOptionsResult = &xdr.SetOptionsResult{Code: code}
+	case xdr.ChangeTrustResultCode:
+		tr.ChangeTrustResult = &xdr.ChangeTrustResult{Code: code}
+	case xdr.AllowTrustResultCode:
+		tr.AllowTrustResult = &xdr.AllowTrustResult{Code: code}
+	case xdr.AccountMergeResultCode:
+		tr.AccountMergeResult = &xdr.AccountMergeResult{Code: code}
+	case xdr.InflationResultCode:
+		tr.InflationResult = &xdr.InflationResult{Code: code}
+	case xdr.ManageDataResultCode:
+		tr.ManageDataResult = &xdr.ManageDataResult{Code: code}
+	case xdr.BumpSequenceResultCode:
+		tr.BumpSeqResult = &xdr.BumpSequenceResult{Code: code}
+	default:
+		return xdr.OperationResult{Code: xdr.OperationResultCodeOpNotSupported}
+	}
+
+	return xdr.OperationResult{Code: xdr.OperationResultCodeOpInner, Tr: &tr}
+}
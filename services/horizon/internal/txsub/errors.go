@@ -13,6 +13,10 @@ var (
 	ErrCanceled  = errors.New("canceled")
 	ErrTimeout   = errors.New("timeout")
 
+	// ErrPending is the result of an asynchronous submission accepted by
+	// stellar-core, whose transaction is not included in a ledger yet.
+	ErrPending = errors.New("pending")
+
 	// ErrBadSequence is a canned error response for transactions whose sequence
 	// number is wrong.
 	ErrBadSequence = &FailedTransactionError{"AAAAAAAAAAD////7AAAAAA=="}
@@ -21,6 +25,18 @@ var (
 	ErrNoAccount = &FailedTransactionError{"AAAAAAAAAAD////4AAAAAA=="}
 )
 
+// RebroadcastError is the result of an open submission that was stuck because
+// of its fee, and that was rebroadcast with a higher fee as a new transaction
+// whose hash is Hash.  See FeeEscalation.
+type RebroadcastError struct {
+	Hash string
+	Fee  int64
+}
+
+func (err *RebroadcastError) Error() string {
+	return fmt.Sprintf("tx rebroadcast with fee %d: %s", err.Fee, err.Hash)
+}
+
 // FailedTransactionError represent an error that occurred because
 // stellar-core rejected the transaction.  ResultXDR is a base64
 // encoded TransactionResult struct
@@ -0,0 +1,267 @@
+package txsub
+
+import (
+	"context"
+	"encoding/hex"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/cowry-network/go/keypair"
+	"github.com/cowry-network/go/network"
+	"github.com/cowry-network/go/services/horizon/internal/operationfeestats"
+	"github.com/cowry-network/go/support/errors"
+	"github.com/cowry-network/go/support/log"
+	"github.com/cowry-network/go/xdr"
+)
+
+// FeeEscalation configures a System to monitor its open submissions while the
+// network is surge pricing.  At each tick, submissions whose fee per operation
+// is lower than the lowest one included in recent ledgers are reported as
+// stuck, along with a recommended fee.  Stuck submissions whose source account
+// is Source are rebroadcast with the recommended fee and signed by Source:
+// the fee of other transactions cannot be raised without their signers.
+type FeeEscalation struct {
+	// Source is the key of the fee source account.
+	Source *keypair.Full
+
+	// MaxOperationFee caps the fee per operation of rebroadcast transactions.
+	MaxOperationFee int64
+
+	// FeeStats returns the current operation fee stats, it defaults to
+	// operationfeestats.CurrentState.
+	FeeStats func() operationfeestats.State
+
+	lock        sync.Mutex
+	submissions map[string]*feeSubmission // hash => `*feeSubmission`
+}
+
+// FeeReport describes the fee of an open submission relative to the fees
+// paid by the transactions included in recent ledgers.
+type FeeReport struct {
+	// Stuck is true when the fee per operation of the transaction is lower than
+	// the lowest one included in recent ledgers.
+	Stuck bool
+
+	// Fee is the fee of the transaction.
+	Fee int64
+
+	// RecommendedFee is the fee the transaction is likely to be included in a
+	// ledger with: the 90th percentile of the fees per operation paid in
+	// recent ledgers, times the operation count of the transaction.
+	RecommendedFee int64
+
+	// RebroadcastHash is the hash of the transaction rebroadcast with the
+	// recommended fee in place of this one, if any.
+	RebroadcastHash string
+
+	// RebroadcastErr is the error of the last attempt to rebroadcast the
+	// transaction, if it failed.
+	RebroadcastErr error
+}
+
+// feeSubmission tracks the fee of an open submission.
+type feeSubmission struct {
+	Envelope   xdr.TransactionEnvelope
+	Source     string
+	Operations int64
+	Report     FeeReport
+
+	// Rebroadcasting is true while the submission is being rebroadcast
+	Rebroadcasting bool
+
+	// ClosedAt is when the submission, and its rebroadcast if any, left the
+	// open submission list.  Closed submissions are reported for another
+	// submission timeout, so that clients can find out about rebroadcasts.
+	ClosedAt time.Time
+}
+
+// Report returns the fee report of the submission with the provided hash, or
+// false if it is not monitored.
+func (fe *FeeEscalation) Report(hash string) (FeeReport, bool) {
+	if fe == nil {
+		return FeeReport{}, false
+	}
+
+	fe.lock.Lock()
+	defer fe.lock.Unlock()
+
+	s, ok := fe.submissions[hash]
+	if !ok {
+		return FeeReport{}, false
+	}
+
+	return s.Report, true
+}
+
+// track starts monitoring the fee of the submission of `env`.
+func (fe *FeeEscalation) track(hash, env string) {
+	if fe == nil {
+		return
+	}
+
+	var tx xdr.TransactionEnvelope
+	err := xdr.SafeUnmarshalBase64(env, &tx)
+	if err != nil || len(tx.Tx.Operations) == 0 {
+		return
+	}
+
+	fe.lock.Lock()
+	defer fe.lock.Unlock()
+
+	if fe.submissions == nil {
+		fe.submissions = map[string]*feeSubmission{}
+	}
+
+	if _, ok := fe.submissions[hash]; ok {
+		return
+	}
+
+	fe.submissions[hash] = &feeSubmission{
+		Envelope:   tx,
+		Source:     tx.Tx.SourceAccount.Address(),
+		Operations: int64(len(tx.Tx.Operations)),
+		Report:     FeeReport{Fee: int64(tx.Tx.Fee)},
+	}
+}
+
+// escalateFees updates the fee reports of the open submissions against the
+// current operation fee stats, and rebroadcasts the stuck submissions that can
+// be signed by the fee source account.
+func (sys *System) escalateFees(ctx context.Context) {
+	fe := sys.FeeEscalation
+	if fe == nil {
+		return
+	}
+
+	state := fe.FeeStats()
+	if state.LastLedger == 0 {
+		// no fee stats yet
+		return
+	}
+
+	recommended := state.FeeP90
+	if recommended < state.LastBaseFee {
+		recommended = state.LastBaseFee
+	}
+
+	open := map[string]bool{}
+	for _, hash := range sys.Pending.Pending(ctx) {
+		open[hash] = true
+	}
+
+	rebroadcasts := map[string]*feeSubmission{}
+
+	fe.lock.Lock()
+	for hash, s := range fe.submissions {
+		if !open[hash] {
+			if s.Report.RebroadcastHash != "" && open[s.Report.RebroadcastHash] {
+				continue
+			}
+
+			if s.ClosedAt.IsZero() {
+				s.ClosedAt = time.Now()
+			} else if time.Since(s.ClosedAt) > sys.SubmissionTimeout {
+				delete(fe.submissions, hash)
+			}
+			continue
+		}
+
+		stuck := s.Report.Fee/s.Operations < state.FeeMin
+		if stuck && !s.Report.Stuck {
+			sys.Metrics.StuckSubmissionsMeter.Mark(1)
+		}
+
+		s.Report.Stuck = stuck
+		s.Report.RecommendedFee = recommended * s.Operations
+
+		if stuck &&
+			!s.Rebroadcasting &&
+			fe.Source != nil &&
+			s.Source == fe.Source.Address() &&
+			recommended <= fe.MaxOperationFee &&
+			s.Report.RecommendedFee <= math.MaxUint32 {
+			s.Rebroadcasting = true
+			rebroadcasts[hash] = s
+		}
+	}
+	fe.lock.Unlock()
+
+	// submitting to stellar-core is done without holding the lock, so that fee
+	// reports remain available meanwhile
+	for hash, s := range rebroadcasts {
+		sys.rebroadcast(ctx, hash, s)
+	}
+}
+
+// rebroadcast submits the transaction of `s` again with its recommended fee,
+// signed by the fee source account.  Once stellar-core accepts it, the
+// rebroadcast transaction replaces the submission of `hash` in the open
+// submission list, whose listeners receive a RebroadcastError.
+func (sys *System) rebroadcast(ctx context.Context, hash string, s *feeSubmission) {
+	fe := sys.FeeEscalation
+	logger := sys.Log.Ctx(ctx).WithField("hash", hash)
+
+	fe.lock.Lock()
+	env := s.Envelope
+	fee := s.Report.RecommendedFee
+	fe.lock.Unlock()
+
+	newHash, newEnv, err := fe.sign(env, fee, sys.NetworkPassphrase)
+	if err == nil {
+		sr := sys.submitOnce(ctx, newEnv)
+		err = sr.Err
+	}
+
+	fe.lock.Lock()
+	s.Rebroadcasting = false
+	if err != nil {
+		// the rebroadcast is attempted again at the next tick, as long as the
+		// submission is stuck
+		s.Report.RebroadcastErr = err
+		fe.lock.Unlock()
+		logger.WithField("err", err.Error()).Warn("Failed to rebroadcast stuck submission")
+		return
+	}
+	s.Report.RebroadcastHash = newHash
+	s.Report.RebroadcastErr = nil
+	fe.lock.Unlock()
+
+	logger.WithFields(log.F{
+		"rebroadcast_hash": newHash,
+		"fee":              fee,
+	}).Info("Rebroadcast stuck submission")
+	sys.Metrics.RebroadcastSubmissionsMeter.Mark(1)
+
+	// nobody reads this listener, it keeps the rebroadcast submission open
+	// until Tick finds its result
+	sys.Pending.Add(ctx, newHash, make(chan Result, 1))
+	sys.Pending.Finish(ctx, Result{
+		Err:  &RebroadcastError{Hash: newHash, Fee: fee},
+		Hash: hash,
+	})
+}
+
+// sign returns the hash and the base64 encoded envelope of `env` with its fee
+// set to `fee`, signed by the fee source account only.
+func (fe *FeeEscalation) sign(env xdr.TransactionEnvelope, fee int64, passphrase string) (string, string, error) {
+	env.Tx.Fee = xdr.Uint32(fee)
+
+	hash, err := network.HashTransaction(&env.Tx, passphrase)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to hash transaction")
+	}
+
+	sig, err := fe.Source.SignDecorated(hash[:])
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to sign transaction")
+	}
+	env.Signatures = []xdr.DecoratedSignature{sig}
+
+	encoded, err := xdr.MarshalBase64(env)
+	if err != nil {
+		return "", "", errors.Wrap(err, "failed to encode transaction")
+	}
+
+	return hex.EncodeToString(hash[:]), encoded, nil
+}
@@ -0,0 +1,168 @@
+package txsub
+
+import (
+	"testing"
+
+	"github.com/cowry-network/go/build"
+	"github.com/cowry-network/go/keypair"
+	"github.com/cowry-network/go/services/horizon/internal/operationfeestats"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/cowry-network/go/services/horizon/internal/txsub/sequence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFeeEscalation(t *testing.T) {
+	ctx := test.Context()
+	feeSource := randomKey(t)
+	other := randomKey(t)
+	submitter := &MockSubmitter{}
+
+	sys := &System{
+		Pending:           NewDefaultSubmissionList(),
+		Submitter:         submitter,
+		Results:           &MockResultProvider{},
+		SubmissionQueue:   sequence.NewManager(),
+		NetworkPassphrase: build.TestNetwork.Passphrase,
+		FeeEscalation: &FeeEscalation{
+			Source:          feeSource,
+			MaxOperationFee: 1000,
+			FeeStats: func() operationfeestats.State {
+				return operationfeestats.State{
+					FeeMin:      200,
+					FeeP90:      500,
+					LastBaseFee: 100,
+					LastLedger:  10,
+				}
+			},
+		},
+	}
+
+	cheapHash, cheapEnv := feeEnvelope(t, feeSource, 100)
+	otherHash, otherEnv := feeEnvelope(t, other, 100)
+	paidHash, paidEnv := feeEnvelope(t, other, 300)
+
+	cheap := make(chan Result, 1)
+	for hash, env := range map[string]string{cheapHash: cheapEnv, otherHash: otherEnv, paidHash: paidEnv} {
+		l := make(chan Result, 1)
+		if hash == cheapHash {
+			l = cheap
+		}
+		require.NoError(t, sys.Pending.Add(ctx, hash, l))
+		sys.FeeEscalation.track(hash, env)
+	}
+
+	sys.Tick(ctx)
+
+	// stuck and sent from the fee source account: rebroadcast
+	report, ok := sys.FeeEscalation.Report(cheapHash)
+	require.True(t, ok)
+	assert.True(t, report.Stuck)
+	assert.Equal(t, int64(100), report.Fee)
+	assert.Equal(t, int64(500), report.RecommendedFee)
+	assert.NoError(t, report.RebroadcastErr)
+	assert.NotEmpty(t, report.RebroadcastHash)
+	assert.True(t, submitter.WasSubmittedTo)
+
+	r := <-cheap
+	assert.Equal(t, &RebroadcastError{Hash: report.RebroadcastHash, Fee: 500}, r.Err)
+	assert.ElementsMatch(t, []string{report.RebroadcastHash, otherHash, paidHash}, sys.Pending.Pending(ctx))
+
+	// stuck and sent from another account: only reported
+	report, ok = sys.FeeEscalation.Report(otherHash)
+	require.True(t, ok)
+	assert.True(t, report.Stuck)
+	assert.Equal(t, int64(500), report.RecommendedFee)
+	assert.Empty(t, report.RebroadcastHash)
+
+	// paying enough
+	report, ok = sys.FeeEscalation.Report(paidHash)
+	require.True(t, ok)
+	assert.False(t, report.Stuck)
+
+	assert.Equal(t, int64(2), sys.Metrics.StuckSubmissionsMeter.Count())
+	assert.Equal(t, int64(1), sys.Metrics.RebroadcastSubmissionsMeter.Count())
+
+	// stuck submissions are only counted once
+	sys.Tick(ctx)
+	assert.Equal(t, int64(2), sys.Metrics.StuckSubmissionsMeter.Count())
+	assert.Equal(t, int64(1), sys.Metrics.RebroadcastSubmissionsMeter.Count())
+
+	_, ok = sys.FeeEscalation.Report("not_monitored")
+	assert.False(t, ok)
+}
+
+func TestFeeEscalation_RebroadcastFailure(t *testing.T) {
+	ctx := test.Context()
+	feeSource := randomKey(t)
+	submitter := &MockSubmitter{R: SubmissionResult{Err: ErrBadSequence}}
+
+	sys := &System{
+		Pending:           NewDefaultSubmissionList(),
+		Submitter:         submitter,
+		Results:           &MockResultProvider{},
+		SubmissionQueue:   sequence.NewManager(),
+		NetworkPassphrase: build.TestNetwork.Passphrase,
+		FeeEscalation: &FeeEscalation{
+			Source:          feeSource,
+			MaxOperationFee: 1000,
+			FeeStats: func() operationfeestats.State {
+				return operationfeestats.State{FeeMin: 200, FeeP90: 500, LastLedger: 10}
+			},
+		},
+	}
+
+	hash, env := feeEnvelope(t, feeSource, 100)
+	l := make(chan Result, 1)
+	require.NoError(t, sys.Pending.Add(ctx, hash, l))
+	sys.FeeEscalation.track(hash, env)
+
+	sys.Tick(ctx)
+
+	report, ok := sys.FeeEscalation.Report(hash)
+	require.True(t, ok)
+	assert.True(t, report.Stuck)
+	assert.Equal(t, ErrBadSequence, report.RebroadcastErr)
+	assert.Empty(t, report.RebroadcastHash)
+	assert.Equal(t, []string{hash}, sys.Pending.Pending(ctx))
+	assert.Equal(t, 0, len(l))
+	assert.Equal(t, int64(0), sys.Metrics.RebroadcastSubmissionsMeter.Count())
+
+	// the recommended fee exceeds the maximum: no rebroadcast
+	submitter.WasSubmittedTo = false
+	sys.FeeEscalation.MaxOperationFee = 400
+	sys.Tick(ctx)
+	assert.False(t, submitter.WasSubmittedTo)
+}
+
+// feeEnvelope returns the hash and the envelope of a payment from `source`
+// with the provided fee.
+func feeEnvelope(t *testing.T, source *keypair.Full, fee uint64) (string, string) {
+	tx, err := build.Transaction(
+		build.SourceAccount{AddressOrSeed: source.Seed()},
+		build.Sequence{Sequence: 1},
+		build.TestNetwork,
+		build.BaseFee{Amount: fee},
+		build.Payment(
+			build.Destination{AddressOrSeed: randomKey(t).Address()},
+			build.NativeAmount{Amount: "1"},
+		),
+	)
+	require.NoError(t, err)
+
+	hash, err := tx.HashHex()
+	require.NoError(t, err)
+
+	txe, err := tx.Sign(source.Seed())
+	require.NoError(t, err)
+	env, err := txe.Base64()
+	require.NoError(t, err)
+
+	return hash, env
+}
+
+func randomKey(t *testing.T) *keypair.Full {
+	kp, err := keypair.Random()
+	require.NoError(t, err)
+	return kp
+}
@@ -25,6 +25,24 @@ type SequenceProvider interface {
 	Get(addresses []string) (map[string]uint64, error)
 }
 
+// TransactionStatus describes how far a submitted transaction got, as
+// reported by System.Status.
+type TransactionStatus string
+
+const (
+	// StatusPending is the status of a transaction accepted by stellar-core
+	// and waiting to be included in a ledger.
+	StatusPending TransactionStatus = "pending"
+	// StatusSuccess is the status of a transaction that succeeded.
+	StatusSuccess TransactionStatus = "success"
+	// StatusFailed is the status of a transaction included in a ledger that
+	// failed.
+	StatusFailed TransactionStatus = "failed"
+	// StatusDropped is the status of a transaction that is neither in a ledger
+	// nor waiting to be included in one.
+	StatusDropped TransactionStatus = "dropped"
+)
+
 // Listener represents some client who is interested in retrieving the result
 // of a specific transaction.
 type Listener chan<- Result
@@ -52,6 +70,10 @@ type OpenSubmissionList interface {
 	// Pending return a list of transaction hashes that have at least one
 	// listener registered to them in this list.
 	Pending(context.Context) []string
+
+	// TickPending returns the transaction hashes whose results are resolved by
+	// this server on every tick of the submission system.
+	TickPending(context.Context) []string
 }
 
 // Submitter represents the low-level "submit a transaction to stellar-core"
@@ -143,6 +143,7 @@ func (suite *SubmissionListTestSuite) TestSubmissionList_Pending() {
 	assert.Equal(suite.T(), 1, len(suite.list.Pending(suite.ctx)))
 	suite.list.Add(suite.ctx, suite.hashes[1], suite.listeners[1])
 	assert.Equal(suite.T(), 2, len(suite.list.Pending(suite.ctx)))
+	assert.ElementsMatch(suite.T(), suite.list.Pending(suite.ctx), suite.list.TickPending(suite.ctx))
 }
 
 func TestSubmissionListTestSuite(t *testing.T) {
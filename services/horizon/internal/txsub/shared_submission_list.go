@@ -0,0 +1,131 @@
+package txsub
+
+import (
+	"context"
+	"time"
+
+	"github.com/cowry-network/go/support/log"
+)
+
+// SubmissionStore represents the storage of the open submissions shared by a
+// cluster of horizon servers.
+type SubmissionStore interface {
+	// Add records the transaction with the provided hash as open.  Adding an
+	// open transaction again is a no-op.
+	Add(ctx context.Context, hash string, submittedAt time.Time) error
+
+	// Remove removes the transaction with the provided hash from the open
+	// submissions.
+	Remove(ctx context.Context, hash string) error
+
+	// RemoveBefore removes the open submissions submitted before the provided
+	// time.
+	RemoveBefore(ctx context.Context, t time.Time) error
+
+	// Hashes returns the hashes of the open submissions.
+	Hashes(ctx context.Context) ([]string, error)
+
+	// Lead returns true if this server is the leader of the cluster.  A single
+	// server is the leader at a time.
+	Lead(ctx context.Context) (bool, error)
+}
+
+// NewSharedSubmissionList returns a list that shares its open submissions with
+// the other horizon servers using `store`.
+//
+// Listeners are notified by the server they were added to, so that every
+// server resolves its own open submissions at each tick.  The leader of the
+// cluster also resolves and cleans up the open submissions of the others,
+// which may have stopped before finding their results.
+func NewSharedSubmissionList(store SubmissionStore) OpenSubmissionList {
+	return &sharedSubmissionList{
+		local: &submissionList{
+			submissions: map[string]*openSubmission{},
+			log:         log.DefaultLogger.WithField("service", "txsub.submissionList"),
+		},
+		store: store,
+		log:   log.DefaultLogger.WithField("service", "txsub.sharedSubmissionList"),
+	}
+}
+
+type sharedSubmissionList struct {
+	local *submissionList
+	store SubmissionStore
+	log   *log.Entry
+}
+
+func (s *sharedSubmissionList) Add(ctx context.Context, hash string, l Listener) error {
+	err := s.local.Add(ctx, hash, l)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Add(ctx, hash, time.Now())
+}
+
+func (s *sharedSubmissionList) Finish(ctx context.Context, r Result) error {
+	err := s.local.Finish(ctx, r)
+	if err != nil {
+		return err
+	}
+
+	return s.store.Remove(ctx, r.Hash)
+}
+
+func (s *sharedSubmissionList) Clean(ctx context.Context, maxAge time.Duration) (int, error) {
+	open, err := s.local.Clean(ctx, maxAge)
+	if err != nil {
+		return open, err
+	}
+
+	if !s.lead(ctx) {
+		return open, nil
+	}
+
+	return open, s.store.RemoveBefore(ctx, time.Now().Add(-maxAge))
+}
+
+// Pending returns the open submissions of the whole cluster.  If they cannot
+// be loaded, only the ones of this server are returned.
+func (s *sharedSubmissionList) Pending(ctx context.Context) []string {
+	local := s.local.Pending(ctx)
+
+	shared, err := s.store.Hashes(ctx)
+	if err != nil {
+		s.log.Ctx(ctx).WithStack(err).Error("Failed to load shared open submissions")
+		return local
+	}
+
+	results := shared
+	seen := make(map[string]bool, len(shared))
+	for _, hash := range shared {
+		seen[hash] = true
+	}
+	for _, hash := range local {
+		if !seen[hash] {
+			results = append(results, hash)
+		}
+	}
+
+	return results
+}
+
+// TickPending returns the open submissions of the whole cluster on the
+// leader, the ones of this server otherwise.
+func (s *sharedSubmissionList) TickPending(ctx context.Context) []string {
+	if s.lead(ctx) {
+		return s.Pending(ctx)
+	}
+
+	return s.local.Pending(ctx)
+}
+
+func (s *sharedSubmissionList) lead(ctx context.Context) bool {
+	leader, err := s.store.Lead(ctx)
+	if err != nil {
+		s.log.Ctx(ctx).WithStack(err).Error("Failed to find out the leader of the cluster")
+		return false
+	}
+
+	return leader
+}
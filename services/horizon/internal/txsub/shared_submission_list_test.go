@@ -0,0 +1,125 @@
+package txsub
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/cowry-network/go/build"
+	"github.com/cowry-network/go/services/horizon/internal/test"
+	"github.com/cowry-network/go/services/horizon/internal/txsub/sequence"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// follower reports its server as a follower of the cluster.
+type follower struct {
+	*MockSubmissionStore
+}
+
+func (follower) Lead(ctx context.Context) (bool, error) {
+	return false, nil
+}
+
+// newClusterSystem returns a system whose open submission list is shared
+// using `store`.
+func newClusterSystem(store SubmissionStore, results *MockResultProvider) *System {
+	sequences := &MockSequenceProvider{}
+	sequences.On("Get", []string{"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H"}).
+		Return(map[string]uint64{"GBRPYHIL2CI3FNQ4BXLFMNDLFJUNPU2HY3ZMFSHONUCEOASW7QC7OX2H": 0}, nil)
+
+	return &System{
+		Pending:           NewSharedSubmissionList(store),
+		Submitter:         &MockSubmitter{},
+		Results:           results,
+		Sequences:         sequences,
+		SubmissionQueue:   sequence.NewManager(),
+		NetworkPassphrase: build.TestNetwork.Passphrase,
+	}
+}
+
+func TestSharedSubmissionList(t *testing.T) {
+	ctx := test.Context()
+	store := &MockSubmissionStore{}
+	leader := NewSharedSubmissionList(store)
+	other := NewSharedSubmissionList(follower{store})
+
+	hashes := []string{
+		"0000000000000000000000000000000000000000000000000000000000000000",
+		"0000000000000000000000000000000000000000000000000000000000000001",
+	}
+
+	l := make(chan Result, 1)
+	require.NoError(t, leader.Add(ctx, hashes[0], l))
+	require.NoError(t, other.Add(ctx, hashes[1], make(chan Result, 1)))
+
+	// the open submissions of the cluster are pending on every server
+	assert.ElementsMatch(t, hashes, leader.Pending(ctx))
+	assert.ElementsMatch(t, hashes, other.Pending(ctx))
+
+	// but Tick only resolves the ones of the other servers on the leader
+	assert.ElementsMatch(t, hashes, leader.TickPending(ctx))
+	assert.Equal(t, []string{hashes[1]}, other.TickPending(ctx))
+
+	// finishing a submission notifies the listeners of its server and removes
+	// it from the cluster
+	require.NoError(t, other.Finish(ctx, Result{Hash: hashes[0]}))
+	assert.Equal(t, []string{hashes[1]}, other.Pending(ctx))
+	assert.Len(t, l, 0)
+
+	require.NoError(t, leader.Finish(ctx, Result{Hash: hashes[0]}))
+	assert.Len(t, l, 1)
+
+	// only the leader cleans up the submissions of the other servers
+	time.Sleep(10 * time.Millisecond)
+	open, err := other.Clean(ctx, 5*time.Millisecond)
+	require.NoError(t, err)
+	assert.Equal(t, 0, open)
+	assert.Equal(t, []string{hashes[1]}, other.Pending(ctx))
+
+	_, err = leader.Clean(ctx, 5*time.Millisecond)
+	require.NoError(t, err)
+	assert.Empty(t, other.Pending(ctx))
+}
+
+// A transaction submitted to several servers of a cluster is submitted to
+// stellar-core once, and its result is sent to every submitter.
+func TestSharedSubmissionList_Deduplication(t *testing.T) {
+	ctx := test.Context()
+	store := &MockSubmissionStore{}
+	results := &MockResultProvider{}
+	a := newClusterSystem(store, results)
+	b := newClusterSystem(follower{store}, results)
+
+	tx := Result{
+		Hash:           "2374e99349b9ef7dba9a5db3339b78fda8f34777b1af33ba468ad5c0df946d4d",
+		LedgerSequence: 2,
+		EnvelopeXDR:    "AAAAAGL8HQvQkbK2HA3WVjRrKmjX00fG8sLI7m0ERwJW/AX3AAAAZAAAAAAAAAABAAAAAAAAAAAAAAABAAAAAAAAAAAAAAAArqN6LeOagjxMaUP96Bzfs9e0corNZXzBWJkFoK7kvkwAAAAAO5rKAAAAAAAAAAABVvwF9wAAAECDzqvkQBQoNAJifPRXDoLhvtycT3lFPCQ51gkdsFHaBNWw05S/VhW0Xgkr0CBPE4NaFV2Kmcs3ZwLmib4TRrML",
+		ResultXDR:      "I3Tpk0m57326ml2zM5t4/ajzR3exrzO6RorVwN+UbU0AAAAAAAAAZAAAAAAAAAABAAAAAAAAAAAAAAAAAAAAAA==",
+	}
+
+	ra := a.Submit(ctx, tx.EnvelopeXDR)
+	rb := b.Submit(ctx, tx.EnvelopeXDR)
+	assert.True(t, a.Submitter.(*MockSubmitter).WasSubmittedTo)
+	assert.False(t, b.Submitter.(*MockSubmitter).WasSubmittedTo)
+
+	status, _, err := b.Status(ctx, tx.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, StatusPending, status)
+
+	for _, sys := range []*System{a, b} {
+		results.Results = []Result{tx}
+		sys.Tick(ctx)
+	}
+
+	for _, r := range []<-chan Result{ra, rb} {
+		select {
+		case result := <-r:
+			assert.NoError(t, result.Err)
+			assert.Equal(t, tx.LedgerSequence, result.LedgerSequence)
+		default:
+			t.Fatal("submission not finished")
+		}
+	}
+	assert.Empty(t, store.Submissions)
+}
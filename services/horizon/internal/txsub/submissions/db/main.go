@@ -0,0 +1,98 @@
+// Package submissions provides an implementation of the txsub.SubmissionStore
+// interface backed by the horizon database, so that the horizon servers
+// sharing a database share their open submissions.
+package submissions
+
+import (
+	"context"
+	"database/sql"
+	"sync"
+	"time"
+
+	"github.com/cowry-network/go/services/horizon/internal/db2/history"
+	"github.com/cowry-network/go/services/horizon/internal/txsub"
+	"github.com/cowry-network/go/support/errors"
+)
+
+// leaderLockID identifies the postgres advisory lock held by the leader of
+// the horizon servers sharing a database.
+const leaderLockID = 7235090483294716
+
+// DB stores open submissions in the horizon database.  The leader is the
+// server holding the leader advisory lock, which is held for as long as the
+// database connection that acquired it is open.
+type DB struct {
+	History *history.Q
+
+	lock sync.Mutex
+	conn *sql.Conn
+}
+
+var _ txsub.SubmissionStore = &DB{}
+
+// Add implements txsub.SubmissionStore
+func (s *DB) Add(ctx context.Context, hash string, submittedAt time.Time) error {
+	err := s.History.AddTxSubOpenSubmission(hash, submittedAt)
+	return errors.Wrap(err, "failed to add open submission")
+}
+
+// Remove implements txsub.SubmissionStore
+func (s *DB) Remove(ctx context.Context, hash string) error {
+	err := s.History.RemoveTxSubOpenSubmission(hash)
+	return errors.Wrap(err, "failed to remove open submission")
+}
+
+// RemoveBefore implements txsub.SubmissionStore
+func (s *DB) RemoveBefore(ctx context.Context, t time.Time) error {
+	_, err := s.History.RemoveTxSubOpenSubmissionsBefore(t)
+	return errors.Wrap(err, "failed to remove open submissions")
+}
+
+// Hashes implements txsub.SubmissionStore
+func (s *DB) Hashes(ctx context.Context) ([]string, error) {
+	var hashes []string
+	err := s.History.TxSubOpenSubmissionHashes(&hashes)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to load open submissions")
+	}
+
+	return hashes, nil
+}
+
+// Lead implements txsub.SubmissionStore.  It tries to acquire the leader
+// lock unless this server holds it already.
+func (s *DB) Lead(ctx context.Context) (bool, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	if s.conn != nil {
+		err := s.conn.PingContext(ctx)
+		if err == nil {
+			return true, nil
+		}
+
+		// the lock was released along with the connection
+		s.conn.Close()
+		s.conn = nil
+	}
+
+	conn, err := s.History.DB.Conn(ctx)
+	if err != nil {
+		return false, errors.Wrap(err, "failed to open connection")
+	}
+
+	var locked bool
+	err = conn.QueryRowContext(ctx, "SELECT pg_try_advisory_lock($1)", leaderLockID).Scan(&locked)
+	if err != nil {
+		conn.Close()
+		return false, errors.Wrap(err, "failed to acquire leader lock")
+	}
+
+	if !locked {
+		conn.Close()
+		return false, nil
+	}
+
+	s.conn = conn
+	return true, nil
+}
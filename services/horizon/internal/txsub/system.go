@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/rcrowley/go-metrics"
+	"github.com/cowry-network/go/services/horizon/internal/operationfeestats"
 	"github.com/cowry-network/go/services/horizon/internal/txsub/sequence"
 	"github.com/cowry-network/go/support/log"
 )
@@ -29,6 +30,10 @@ type System struct {
 	SubmissionTimeout time.Duration
 	Log               *log.Entry
 
+	// FeeEscalation enables the monitoring of the fees of open submissions
+	// when set, see FeeEscalation.
+	FeeEscalation *FeeEscalation
+
 	Metrics struct {
 		// SubmissionTimer exposes timing metrics about the rate and latency of
 		// submissions to stellar-core
@@ -49,12 +54,33 @@ type System struct {
 		// SuccessfulSubmissionsMeter tracks the rate of successful transactions that
 		// have been submitted to this process
 		SuccessfulSubmissionsMeter metrics.Meter
+
+		// StuckSubmissionsMeter tracks the rate of open submissions found stuck
+		// because of their fee, when FeeEscalation is enabled
+		StuckSubmissionsMeter metrics.Meter
+
+		// RebroadcastSubmissionsMeter tracks the rate of stuck submissions
+		// rebroadcast with a higher fee, when FeeEscalation is enabled
+		RebroadcastSubmissionsMeter metrics.Meter
 	}
 }
 
 // Submit submits the provided base64 encoded transaction envelope to the
 // network using this submission system.
-func (sys *System) Submit(ctx context.Context, env string) (result <-chan Result) {
+func (sys *System) Submit(ctx context.Context, env string) <-chan Result {
+	return sys.submit(ctx, env, false)
+}
+
+// SubmitAsync submits the provided base64 encoded transaction envelope like
+// Submit, but does not wait for the transaction to be included in a ledger:
+// once stellar-core accepts the envelope, the returned channel emits a result
+// whose error is ErrPending.  The transaction stays in the open submission
+// list, so that its progress can be followed with Status.
+func (sys *System) SubmitAsync(ctx context.Context, env string) <-chan Result {
+	return sys.submit(ctx, env, true)
+}
+
+func (sys *System) submit(ctx context.Context, env string, async bool) (result <-chan Result) {
 	sys.Init()
 	response := make(chan Result, 1)
 	result = response
@@ -88,6 +114,19 @@ func (sys *System) Submit(ctx context.Context, env string) (result <-chan Result
 
 	// From now: r.Err == ErrNoResults
 
+	// the transaction may already be open, submitted earlier to this server
+	// or, when the open submission list is shared, to another one: wait for
+	// its result instead of submitting it again
+	if sys.isPending(ctx, info.Hash) {
+		sys.Log.Ctx(ctx).WithField("hash", info.Hash).Info("Found open submission")
+		if async {
+			sys.finish(ctx, response, Result{Err: ErrPending, Hash: info.Hash, EnvelopeXDR: env})
+		} else {
+			sys.Pending.Add(ctx, info.Hash, response)
+		}
+		return
+	}
+
 	curSeq, err := sys.Sequences.Get([]string{info.SourceAddress})
 	if err != nil {
 		sys.finish(ctx, response, Result{Err: err, EnvelopeXDR: env})
@@ -126,7 +165,15 @@ func (sys *System) Submit(ctx context.Context, env string) (result <-chan Result
 		// if submission succeeded
 		if sr.Err == nil {
 			// add transactions to open list
-			sys.Pending.Add(ctx, info.Hash, response)
+			sys.FeeEscalation.track(info.Hash, env)
+			if async {
+				// nobody reads this listener, it keeps the submission open
+				// until Tick finds its result
+				sys.Pending.Add(ctx, info.Hash, make(chan Result, 1))
+				sys.finish(ctx, response, Result{Err: ErrPending, Hash: info.Hash, EnvelopeXDR: env})
+			} else {
+				sys.Pending.Add(ctx, info.Hash, response)
+			}
 			// update the submission queue, allowing the next submission to proceed
 			sys.SubmissionQueue.Update(map[string]uint64{info.SourceAddress: info.Sequence})
 			return
@@ -162,6 +209,50 @@ func (sys *System) Submit(ctx context.Context, env string) (result <-chan Result
 	return
 }
 
+// Status reports the status of the transaction with the provided hash, along
+// with its result once it is included in a ledger.  A transaction that is
+// neither in a ledger nor in the open submission list is reported as
+// dropped: stellar-core did not include it before the submission timed out,
+// or it was not submitted through this system.
+func (sys *System) Status(ctx context.Context, hash string) (TransactionStatus, Result, error) {
+	sys.Init()
+
+	// Tick finishes an open submission only after its result can be found, so
+	// the open submission list must be checked first for a transaction that
+	// is not open to be guaranteed to have its result found below.
+	pending := sys.isPending(ctx, hash)
+
+	r := sys.Results.ResultByHash(ctx, hash)
+	if r.Err == nil {
+		return StatusSuccess, r, nil
+	}
+
+	if _, ok := r.Err.(*FailedTransactionError); ok {
+		return StatusFailed, r, nil
+	}
+
+	if r.Err != ErrNoResults {
+		return "", Result{}, r.Err
+	}
+
+	if pending {
+		return StatusPending, Result{Hash: hash}, nil
+	}
+
+	return StatusDropped, Result{Hash: hash}, nil
+}
+
+// isPending returns true if the transaction with the provided hash is in the
+// open submission list.
+func (sys *System) isPending(ctx context.Context, hash string) bool {
+	for _, h := range sys.Pending.Pending(ctx) {
+		if h == hash {
+			return true
+		}
+	}
+	return false
+}
+
 // Submit submits the provided base64 encoded transaction envelope to the
 // network using this submission system.
 func (sys *System) submitOnce(ctx context.Context, env string) SubmissionResult {
@@ -229,7 +320,9 @@ func (sys *System) Tick(ctx context.Context) {
 		}
 	}
 
-	for _, hash := range sys.Pending.Pending(ctx) {
+	// when open submissions are shared, only the leader resolves the ones of
+	// the whole cluster
+	for _, hash := range sys.Pending.TickPending(ctx) {
 		r := sys.Results.ResultByHash(ctx, hash)
 
 		if r.Err == nil {
@@ -251,6 +344,8 @@ func (sys *System) Tick(ctx context.Context) {
 		}
 	}
 
+	sys.escalateFees(ctx)
+
 	stillOpen, err := sys.Pending.Clean(ctx, sys.SubmissionTimeout)
 	if err != nil {
 		logger.WithStack(err).Error(err)
@@ -271,6 +366,12 @@ func (sys *System) Init() {
 		sys.Metrics.SubmissionTimer = metrics.NewTimer()
 		sys.Metrics.OpenSubmissionsGauge = metrics.NewGauge()
 		sys.Metrics.BufferedSubmissionsGauge = metrics.NewGauge()
+		sys.Metrics.StuckSubmissionsMeter = metrics.NewMeter()
+		sys.Metrics.RebroadcastSubmissionsMeter = metrics.NewMeter()
+
+		if sys.FeeEscalation != nil && sys.FeeEscalation.FeeStats == nil {
+			sys.FeeEscalation.FeeStats = operationfeestats.CurrentState
+		}
 
 		if sys.SubmissionTimeout == 0 {
 			// HTTP clients in SDKs usually timeout in 60 seconds. We want SubmissionTimeout
@@ -113,6 +113,73 @@ func (suite *SystemTestSuite) TestSubmit_OpenTransactionList() {
 	assert.Equal(suite.T(), int64(1), suite.system.Metrics.SubmissionTimer.Count())
 }
 
+// An asynchronous submission returns once stellar-core accepts the transaction
+// and leaves it in the open transaction list.
+func (suite *SystemTestSuite) TestSubmitAsync_Pending() {
+	r := <-suite.system.SubmitAsync(suite.ctx, suite.successTx.EnvelopeXDR)
+
+	assert.Equal(suite.T(), ErrPending, r.Err)
+	assert.Equal(suite.T(), suite.successTx.Hash, r.Hash)
+	assert.True(suite.T(), suite.submitter.WasSubmittedTo)
+	pending := suite.system.Pending.Pending(suite.ctx)
+	assert.Equal(suite.T(), []string{suite.successTx.Hash}, pending)
+
+	// the submission is finished like any other once its result is found
+	suite.results.Results = []Result{suite.successTx}
+	suite.system.Tick(suite.ctx)
+	assert.Equal(suite.T(), 0, len(suite.system.Pending.Pending(suite.ctx)))
+}
+
+// An asynchronous submission of a transaction already in a ledger returns its
+// result.
+func (suite *SystemTestSuite) TestSubmitAsync_Existing() {
+	suite.results.Results = []Result{suite.successTx}
+	r := <-suite.system.SubmitAsync(suite.ctx, suite.successTx.EnvelopeXDR)
+
+	assert.Nil(suite.T(), r.Err)
+	assert.Equal(suite.T(), suite.successTx.LedgerSequence, r.LedgerSequence)
+	assert.False(suite.T(), suite.submitter.WasSubmittedTo)
+}
+
+// Status reports the transactions in the open transaction list as pending.
+func (suite *SystemTestSuite) TestStatus_Pending() {
+	suite.system.Pending.Add(suite.ctx, suite.successTx.Hash, make(chan Result, 1))
+
+	status, r, err := suite.system.Status(suite.ctx, suite.successTx.Hash)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusPending, status)
+	assert.Equal(suite.T(), suite.successTx.Hash, r.Hash)
+}
+
+// Status reports the result of transactions included in a ledger.
+func (suite *SystemTestSuite) TestStatus_Result() {
+	failedTx := suite.successTx
+	failedTx.Err = ErrBadSequence
+	suite.results.Results = []Result{suite.successTx, failedTx}
+
+	status, r, err := suite.system.Status(suite.ctx, suite.successTx.Hash)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusSuccess, status)
+	assert.Equal(suite.T(), suite.successTx.LedgerSequence, r.LedgerSequence)
+
+	status, r, err = suite.system.Status(suite.ctx, suite.successTx.Hash)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusFailed, status)
+	assert.Equal(suite.T(), suite.successTx.ResultXDR, r.ResultXDR)
+}
+
+// Status reports transactions that are neither open nor in a ledger as
+// dropped, and returns errors of the result provider.
+func (suite *SystemTestSuite) TestStatus_Dropped() {
+	status, _, err := suite.system.Status(suite.ctx, suite.successTx.Hash)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), StatusDropped, status)
+
+	suite.results.Results = []Result{{Err: errors.New("busted for some reason")}}
+	_, _, err = suite.system.Status(suite.ctx, suite.successTx.Hash)
+	assert.EqualError(suite.T(), err, "busted for some reason")
+}
+
 // Tick should be a no-op if there are no open submissions.
 func (suite *SystemTestSuite) TestTick_Noop() {
 	suite.system.Tick(suite.ctx)
@@ -8,6 +8,8 @@ package txsub
 
 import (
 	"context"
+	"sync"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -53,3 +55,64 @@ func (o *MockSequenceProvider) Get(addresses []string) (map[string]uint64, error
 	args := o.Called(addresses)
 	return args.Get(0).(map[string]uint64), args.Error(1)
 }
+
+// MockSubmissionStore is a test helper that implements the SubmissionStore
+// interface in memory.  It can be shared by the lists of several systems to
+// simulate a cluster of horizon servers.
+type MockSubmissionStore struct {
+	sync.Mutex
+	Submissions map[string]time.Time
+}
+
+// Add implements `txsub.SubmissionStore`
+func (s *MockSubmissionStore) Add(ctx context.Context, hash string, submittedAt time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	if s.Submissions == nil {
+		s.Submissions = map[string]time.Time{}
+	}
+	if _, ok := s.Submissions[hash]; !ok {
+		s.Submissions[hash] = submittedAt
+	}
+	return nil
+}
+
+// Remove implements `txsub.SubmissionStore`
+func (s *MockSubmissionStore) Remove(ctx context.Context, hash string) error {
+	s.Lock()
+	defer s.Unlock()
+
+	delete(s.Submissions, hash)
+	return nil
+}
+
+// RemoveBefore implements `txsub.SubmissionStore`
+func (s *MockSubmissionStore) RemoveBefore(ctx context.Context, t time.Time) error {
+	s.Lock()
+	defer s.Unlock()
+
+	for hash, submittedAt := range s.Submissions {
+		if submittedAt.Before(t) {
+			delete(s.Submissions, hash)
+		}
+	}
+	return nil
+}
+
+// Hashes implements `txsub.SubmissionStore`
+func (s *MockSubmissionStore) Hashes(ctx context.Context) ([]string, error) {
+	s.Lock()
+	defer s.Unlock()
+
+	hashes := make([]string, 0, len(s.Submissions))
+	for hash := range s.Submissions {
+		hashes = append(hashes, hash)
+	}
+	return hashes, nil
+}
+
+// Lead implements `txsub.SubmissionStore`, every server is the leader
+func (s *MockSubmissionStore) Lead(ctx context.Context) (bool, error) {
+	return true, nil
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/rs/cors"
 	"github.com/sebest/xff"
 	"github.com/cowry-network/go/services/horizon/internal/db2"
+	"github.com/cowry-network/go/services/horizon/internal/ratelimit"
 	hProblem "github.com/cowry-network/go/services/horizon/internal/render/problem"
 	"github.com/cowry-network/go/services/horizon/internal/render/sse"
 	"github.com/cowry-network/go/services/horizon/internal/txsub/sequence"
@@ -23,8 +24,10 @@ import (
 // Web contains the http server related fields for horizon: the router,
 // rate limiter, etc.
 type Web struct {
-	router      *chi.Mux
-	rateLimiter *throttled.HTTPRateLimiter
+	router             *chi.Mux
+	rateLimiter        *throttled.HTTPRateLimiter
+	routeRateLimiters  []routeRateLimiter
+	rateLimitAllowlist *ratelimit.Allowlist
 
 	requestTimer metrics.Timer
 	failureMeter metrics.Meter
@@ -96,6 +99,7 @@ func initWebActions(app *App) {
 
 	// account actions
 	r.Route("/accounts", func(r chi.Router) {
+		r.Get("/", AccountIndexAction{}.Handle)
 		r.Route("/{account_id}", func(r chi.Router) {
 			r.Get("/", AccountShowAction{}.Handle)
 			r.Get("/transactions", TransactionIndexAction{}.Handle)
@@ -104,6 +108,7 @@ func initWebActions(app *App) {
 			r.Get("/effects", EffectIndexAction{}.Handle)
 			r.Get("/offers", OffersByAccountAction{}.Handle)
 			r.Get("/trades", TradeIndexAction{}.Handle)
+			r.Get("/changes", LedgerEntryChangeIndexAction{}.Handle)
 			r.Get("/data/{key}", DataShowAction{}.Handle)
 		})
 	})
@@ -113,6 +118,7 @@ func initWebActions(app *App) {
 		r.Get("/", TransactionIndexAction{}.Handle)
 		r.Route("/{tx_id}", func(r chi.Router) {
 			r.Get("/", TransactionShowAction{}.Handle)
+			r.Get("/status", TransactionStatusAction{}.Handle)
 			r.Get("/operations", OperationIndexAction{}.Handle)
 			r.Get("/payments", PaymentsIndexAction{}.Handle)
 			r.Get("/effects", EffectIndexAction{}.Handle)
@@ -135,19 +141,26 @@ func initWebActions(app *App) {
 	// trading related endpoints
 	r.Get("/trades", TradeIndexAction{}.Handle)
 	r.Get("/trade_aggregations", TradeAggregateIndexAction{}.Handle)
+	r.Get("/markets", MarketIndexAction{}.Handle)
 	r.Route("/offers", func(r chi.Router) {
-		r.Get("/{id}", NotImplementedAction{}.Handle)
-		r.Get("/{offer_id}/trades", TradeIndexAction{}.Handle)
+		r.Get("/", OffersAction{}.Handle)
+		r.Route("/{offer_id}", func(r chi.Router) {
+			r.Get("/", OfferShowAction{}.Handle)
+			r.Get("/trades", TradeIndexAction{}.Handle)
+		})
 	})
 	r.Get("/order_book", OrderBookShowAction{}.Handle)
 
 	// Transaction submission API
 	r.Post("/transactions", TransactionCreateAction{}.Handle)
+	r.Post("/transactions/simulate", TransactionSimulateAction{}.Handle)
 	r.Get("/paths", PathIndexAction{}.Handle)
+	r.Get("/paths/strict-send", StrictSendPathIndexAction{}.Handle)
 
 	if app.config.EnableAssetStats {
 		// Asset related endpoints
 		r.Get("/assets", AssetsAction{}.Handle)
+		r.Get("/assets/{asset}/stats", AssetStatsShowAction{}.Handle)
 	}
 
 	// Network state related endpoints
@@ -168,23 +181,73 @@ func initWebActions(app *App) {
 	r.NotFound(NotFoundAction{}.Handle)
 }
 
+// routeRateLimiter is the rate limiter enforcing a per-route quota.
+type routeRateLimiter struct {
+	rule    ratelimit.Rule
+	limiter *throttled.HTTPRateLimiter
+}
+
+// initWebRateLimiter installs the rate limiters onto the provided app.  Their
+// state is kept in redis when it is configured, so that all the horizon
+// servers using it share the same quotas.
 func initWebRateLimiter(app *App) {
 	// Disabled
-	if app.config.RateLimit == nil {
+	if app.config.RateLimit == nil && len(app.config.RateLimitRules) == 0 {
 		return
 	}
 
-	rateLimiter, err := throttled.NewGCRARateLimiter(50000, *app.config.RateLimit)
+	store, err := ratelimit.NewStore(app.redis, app.config.RateLimitRedisKey+":", 50000)
 	if err != nil {
-		panic(fmt.Errorf("unable to create RateLimiter"))
+		panic(fmt.Errorf("unable to create RateLimiter store: %v", err))
 	}
 
-	httpRateLimiter := throttled.HTTPRateLimiter{
+	if app.config.RateLimit != nil {
+		app.web.rateLimiter = newHTTPRateLimiter(app, store, *app.config.RateLimit, VaryByRemoteIP{})
+	}
+
+	for _, rule := range app.config.RateLimitRules {
+		app.web.routeRateLimiters = append(app.web.routeRateLimiters, routeRateLimiter{
+			rule:    rule,
+			limiter: newHTTPRateLimiter(app, store, rule.Quota, varyByRule{rule.String()}),
+		})
+	}
+
+	app.web.rateLimitAllowlist = ratelimit.NewAllowlist(app.config.RateLimitAllowlist)
+}
+
+func newHTTPRateLimiter(
+	app *App,
+	store throttled.GCRAStore,
+	quota throttled.RateQuota,
+	varyBy ratelimit.VaryBy,
+) *throttled.HTTPRateLimiter {
+	rateLimiter, err := throttled.NewGCRARateLimiter(store, quota)
+	if err != nil {
+		panic(fmt.Errorf("unable to create RateLimiter: %v", err))
+	}
+
+	return &throttled.HTTPRateLimiter{
 		RateLimiter:   rateLimiter,
 		DeniedHandler: &RateLimitExceededAction{App: app, Action: Action{}},
+		VaryBy:        varyBy,
+	}
+}
+
+// rateLimiterFor returns the rate limiter that applies to `r`: the one of the
+// first matching per-route rule or else the default one.  It returns nil when
+// the request is not rate limited, including when its client is allowlisted.
+func (web *Web) rateLimiterFor(r *http.Request) *throttled.HTTPRateLimiter {
+	if web.rateLimitAllowlist.Allows(remoteAddrIP(r), r.Header.Get(apiKeyHeader)) {
+		return nil
+	}
+
+	for _, route := range web.routeRateLimiters {
+		if route.rule.Matches(r) {
+			return route.limiter
+		}
 	}
-	httpRateLimiter.VaryBy = VaryByRemoteIP{}
-	app.web.rateLimiter = &httpRateLimiter
+
+	return web.rateLimiter
 }
 
 type VaryByRemoteIP struct{}
@@ -193,6 +256,16 @@ func (v VaryByRemoteIP) Key(r *http.Request) string {
 	return remoteAddrIP(r)
 }
 
+// varyByRule keys the requests limited by a per-route rule by remote ip, in a
+// namespace of their own so that they do not use the default quota.
+type varyByRule struct {
+	rule string
+}
+
+func (v varyByRule) Key(r *http.Request) string {
+	return v.rule + " " + remoteAddrIP(r)
+}
+
 func remoteAddrIP(r *http.Request) string {
 	// To support IPv6
 	lastSemicolon := strings.LastIndex(r.RemoteAddr, ":")